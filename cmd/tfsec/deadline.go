@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// hardDeadlineGrace is how long the scan is given to stop by itself once --timeout expires. After that, the results
+// gathered so far are reported and tfsec exits, whatever it is still doing.
+const hardDeadlineGrace = 5 * time.Second
+
+// outputLock makes sure results are only written once, by either the scan or the hard deadline
+var outputLock sync.Mutex
+var outputWritten bool
+
+// startHardDeadline arranges for the results collected by tfsecScanner to be written, and for tfsec to exit with
+// timeoutExitCode, if the scan is still running hardDeadlineGrace after --timeout expires. If the results are being
+// streamed, those already written are left as they are.
+func startHardDeadline(w io.Writer, formatter formatters.Formatter, tfsecScanner *scanner.Scanner, streamer *resultStreamer, dir string, threshold severity.Severity) *time.Timer {
+	return time.AfterFunc(scanTimeout+hardDeadlineGrace, func() {
+		outputLock.Lock()
+		defer outputLock.Unlock()
+		if outputWritten {
+			return
+		}
+		outputWritten = true
+		_, _ = fmt.Fprintf(os.Stderr, "WARNING: The scan did not stop within %s of timing out, reporting the results gathered so far\n", hardDeadlineGrace)
+		if err := writeCollectedResults(w, formatter, tfsecScanner, streamer, dir, threshold); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "%s\n", err)
+		}
+		os.Exit(timeoutExitCode)
+	})
+}
+
+// writeCollectedResults reports the results which a scanner has collected so far, processed as they would be at the
+// end of a scan. When streamer is given, the results have already been written by it as they were found.
+func writeCollectedResults(w io.Writer, formatter formatters.Formatter, tfsecScanner *scanner.Scanner, streamer *resultStreamer, dir string, threshold severity.Severity) error {
+	results := tfsecScanner.CollectedResults()
+	if !noDedup {
+		results = result.Deduplicate(results)
+	}
+	if generateBaseline {
+		// an incomplete baseline would hide findings which weren't reached from later scans
+		_, _ = fmt.Fprintf(os.Stderr, "Not writing a baseline from incomplete results\n")
+		return nil
+	}
+	if listIgnoredFindings {
+		selected, err := selectResults(results, dir)
+		if err != nil {
+			return err
+		}
+		return listIgnored(os.Stdout, format, selected)
+	}
+	results, _, err := processResults(results, dir, threshold)
+	if err != nil {
+		return err
+	}
+	countResults(results)
+	if streamer != nil {
+		err = streamer.stop()
+	} else {
+		err = formatter(w, results, dir, getFormatterOptions()...)
+	}
+	if err != nil {
+		return err
+	}
+	return publishResults(results)
+}
+
+// writeResults formats the results of a scan, unless the hard deadline has already written them. When streamer is
+// given, the results have already been written by it as they were found.
+func writeResults(w io.Writer, formatter formatters.Formatter, results []result.Result, streamer *resultStreamer, dir string) error {
+	outputLock.Lock()
+	defer outputLock.Unlock()
+	if outputWritten {
+		return nil
+	}
+	outputWritten = true
+	if streamer != nil {
+		return streamer.stop()
+	}
+	return formatter(w, results, dir, getFormatterOptions()...)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// getScanDirs resolves the directories given on the command line, defaulting to the working directory. Each is
+// parsed as a separate root module, and duplicates are dropped so a root isn't scanned twice.
+func getScanDirs(args []string) ([]string, error) {
+	if len(args) == 0 || readStdin {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, err
+		}
+		return []string{dir}, nil
+	}
+
+	var dirs []string
+	seen := make(map[string]struct{})
+	for _, arg := range args {
+		dir, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		dirs = append(dirs, dir)
+	}
+	return dirs, nil
+}
+
+// commonDir finds the deepest directory containing all of dirs. Results and baselines are relative to it, so file
+// paths stay unambiguous when several roots are scanned together.
+func commonDir(dirs []string) string {
+	if len(dirs) == 0 {
+		return ""
+	}
+	common := strings.Split(filepath.Clean(dirs[0]), string(filepath.Separator))
+	for _, dir := range dirs[1:] {
+		parts := strings.Split(filepath.Clean(dir), string(filepath.Separator))
+		i := 0
+		for i < len(common) && i < len(parts) && common[i] == parts[i] {
+			i++
+		}
+		common = common[:i]
+	}
+	joined := strings.Join(common, string(filepath.Separator))
+	if joined == "" {
+		return string(filepath.Separator)
+	}
+	return joined
+}
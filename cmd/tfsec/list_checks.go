@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/olekukonko/tablewriter"
+)
+
+type listedCheck struct {
+	ID              string   `json:"id"`
+	LegacyID        string   `json:"legacy_id,omitempty"`
+	Provider        string   `json:"provider"`
+	Service         string   `json:"service"`
+	DefaultSeverity string   `json:"default_severity"`
+	Summary         string   `json:"summary"`
+	Links           []string `json:"links"`
+}
+
+// listChecks writes the registered checks, restricted by --filter-provider, without scanning anything
+func listChecks(w io.Writer, format string) error {
+	var checks []listedCheck
+	for _, r := range scanner.FilterRulesByProvider(scanner.GetRegisteredRules(), filterProviders) {
+		checks = append(checks, newListedCheck(r))
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(checks)
+	case "", "default", "table":
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"ID", "Legacy ID", "Provider", "Service", "Severity", "Summary"})
+		table.SetAutoWrapText(false)
+		for _, check := range checks {
+			table.Append([]string{check.ID, check.LegacyID, check.Provider, check.Service, check.DefaultSeverity, check.Summary})
+		}
+		table.Render()
+		return nil
+	default:
+		return fmt.Errorf("invalid format for --list-checks: '%s', should be json or table", format)
+	}
+}
+
+func newListedCheck(r rule.Rule) listedCheck {
+	links := r.DocumentationLinks()
+	if links == nil {
+		links = []string{}
+	}
+	return listedCheck{
+		ID:              r.ID(),
+		LegacyID:        r.LegacyID,
+		Provider:        string(r.Provider),
+		Service:         r.Service,
+		DefaultSeverity: string(r.DefaultSeverity),
+		Summary:         r.Documentation.Summary,
+		Links:           links,
+	}
+}
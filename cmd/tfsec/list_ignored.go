@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/olekukonko/tablewriter"
+)
+
+type ignoredFinding struct {
+	RuleID        string `json:"rule_id"`
+	Resource      string `json:"resource"`
+	Location      string `json:"location"`
+	Directive     string `json:"ignore_directive"`
+	Justification string `json:"justification,omitempty"`
+}
+
+// listIgnored writes the findings which are suppressed by ignore comments, along with the comment suppressing each
+func listIgnored(w io.Writer, format string, results []result.Result) error {
+	result.SortByLocation(results)
+	ignored := []ignoredFinding{}
+	for _, res := range results {
+		if res.Status != result.Ignored {
+			continue
+		}
+		ignored = append(ignored, ignoredFinding{
+			RuleID:        res.RuleID,
+			Resource:      res.Resource,
+			Location:      res.Range().String(),
+			Directive:     res.IgnoreDirective,
+			Justification: res.Justification,
+		})
+	}
+
+	switch strings.ToLower(format) {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "\t")
+		return encoder.Encode(ignored)
+	case "", "default", "table":
+		table := tablewriter.NewWriter(w)
+		table.SetHeader([]string{"Rule ID", "Resource", "Location", "Ignored By", "Justification"})
+		table.SetAutoWrapText(false)
+		for _, finding := range ignored {
+			table.Append([]string{finding.RuleID, finding.Resource, finding.Location, finding.Directive, finding.Justification})
+		}
+		table.Render()
+		_, _ = fmt.Fprintf(w, "%d finding(s) suppressed by ignore comments\n", len(ignored))
+		return nil
+	default:
+		return fmt.Errorf("invalid format for --list-ignored: '%s', should be json or table", format)
+	}
+}
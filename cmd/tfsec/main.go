@@ -1,10 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"runtime"
+	"time"
 
 	"strings"
 
@@ -12,6 +16,8 @@ import (
 
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/baseline"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/config"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/updater"
 
@@ -25,16 +31,25 @@ import (
 
 	"github.com/spf13/cobra"
 
-	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/logbuckets"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/publicip"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scancache"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/security"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
 	"github.com/aquasecurity/tfsec/version"
 )
 
 var showVersion = false
 var runUpdate = false
 var disableColours = false
+var colourMode = "auto"
+var colourTheme string
 var format string
 var softFail = false
 var filterResults string
@@ -55,37 +70,129 @@ var ignoreInfo = false
 var allDirs = false
 var runStatistics bool
 var ignoreHCLErrors bool
+var strict bool
+var conservative bool
 var stopOnCheckError bool
-var workspace string
+var workspace = "default"
 var passingGif bool
+var codeLines = 3
+var groupBy = "rule"
+var sortBy = "location"
+var postResultsURL string
+var postHeaders []string
+var postTimeout = 10 * time.Second
+var minimumSeverity string
+var showAll bool
+var exitCodeOnFindings = 1
+var failOnSeverity []string
+var failSeverities []severity.Severity
+var planFile string
+var downloadModules bool
+var requireIgnoreJustification bool
+var baselineFile string
+var generateBaseline bool
+var compareTo string
+var showStats bool
+var metricsFile string
+var includeRuleDocs bool
+var concurrency int
+var noCache bool
+var cacheDir string
+var changedFilesOnly bool
+var baseRef = "HEAD"
+var readStdin bool
+var excludePaths []string
+var scanDotTerraform bool
+var filterProviders []string
+var showChecks bool
+var applyFixes bool
+var noDedup bool
+var trackPassed bool
+var entropyThreshold float64
+var printConfig bool
+var noIgnores bool
+var listIgnoredFindings bool
+var loadedConfigFile string
+var scanTimeout time.Duration
+var scanContext = context.Background()
+
+// checkContext lets the checks run until the hard deadline, so whatever was parsed before --timeout expired is checked
+var checkContext = context.Background()
+
+// timeoutExitCode is used when --timeout expires, matching the timeout command
+const timeoutExitCode = 124
 
 func init() {
-	rootCmd.Flags().BoolVar(&ignoreHCLErrors, "ignore-hcl-errors", ignoreHCLErrors, "Stop and report an error if an HCL parse error is encountered")
+	rootCmd.Flags().BoolVar(&ignoreHCLErrors, "ignore-hcl-errors", ignoreHCLErrors, "Skip files which can't be parsed")
+	_ = rootCmd.Flags().MarkDeprecated("ignore-hcl-errors", "files which can't be parsed are skipped unless --strict is set")
+	rootCmd.Flags().BoolVar(&strict, "strict", strict, "Stop and report an error if any file can't be parsed, instead of scanning the rest")
 	rootCmd.Flags().BoolVar(&disableColours, "no-colour", disableColours, "Disable coloured output")
 	rootCmd.Flags().BoolVar(&disableColours, "no-color", disableColours, "Disable colored output (American style!)")
+	rootCmd.Flags().StringVar(&colourMode, "colour", colourMode, "When to colour output: auto, always or never. Auto disables colour if NO_COLOR is set or stdout is not a terminal.")
+	rootCmd.Flags().StringVar(&colourMode, "color", colourMode, "When to color output: auto, always or never (American style!)")
+	rootCmd.Flags().StringVar(&colourTheme, "colour-theme", colourTheme, fmt.Sprintf("Colour theme for severities in the default output, one of %s", formatters.ThemeNames()))
+	rootCmd.Flags().StringVar(&colourTheme, "color-theme", colourTheme, "Color theme for severities in the default output (American style!)")
 	rootCmd.Flags().BoolVarP(&showVersion, "version", "v", showVersion, "Show version information and exit")
 	rootCmd.Flags().BoolVar(&runUpdate, "update", runUpdate, "Update to latest version")
-	rootCmd.Flags().StringVarP(&format, "format", "f", format, "Select output format: default, json, csv, checkstyle, junit, sarif")
-	rootCmd.Flags().StringVarP(&excludedRuleIDs, "exclude", "e", excludedRuleIDs, "Provide comma-separated list of rule IDs to exclude from run.")
-	rootCmd.Flags().StringVarP(&includedRuleIDs, "include", "i", includedRuleIDs, "Provide comma-separated list of specific rules to include in the from run.")
+	rootCmd.Flags().StringVarP(&format, "format", "f", format, "Select output format: default, json, json-lines (one JSON result per line, written as the scan finds them), csv, checkstyle, junit, sarif, gitlab")
+	rootCmd.Flags().StringVarP(&excludedRuleIDs, "exclude", "e", excludedRuleIDs, "Provide comma-separated list of rule IDs to exclude from run. Wildcards such as aws-s3-* are supported.")
+	rootCmd.Flags().StringVarP(&includedRuleIDs, "include", "i", includedRuleIDs, "Provide comma-separated list of specific rules to include in the from run. Wildcards such as aws-s3-* are supported.")
 	rootCmd.Flags().StringVar(&filterResults, "filter-results", filterResults, "Filter results to return specific checks only (supports comma-delimited input).")
 	rootCmd.Flags().BoolVarP(&softFail, "soft-fail", "s", softFail, "Runs checks but suppresses error code")
-	rootCmd.Flags().StringSliceVar(&tfvarsPaths, "tfvars-file", tfvarsPaths, "Path to .tfvars file, can be used multiple times and evaluated in order of specification")
+	rootCmd.Flags().StringSliceVar(&tfvarsPaths, "tfvars-file", tfvarsPaths, "Path to .tfvars or .tfvars.json file, can be used multiple times and evaluated in order of specification")
+	rootCmd.Flags().StringVar(&planFile, "plan-file", planFile, "Scan the planned resources in the output of 'terraform show -json' instead of parsing HCL")
 	rootCmd.Flags().StringVar(&outputFlag, "out", outputFlag, "Set output file")
 	rootCmd.Flags().StringVar(&customCheckDir, "custom-check-dir", customCheckDir, "Explicitly the custom checks dir location")
-	rootCmd.Flags().StringVar(&configFile, "config-file", configFile, "Config file to use during run")
+	rootCmd.Flags().StringVar(&configFile, "config-file", configFile, "Config file to use during run. Defaults to .tfsec/config.json, .tfsec/config.yml or .tfsec/config.yaml in the scanned directory or any parent directory")
 	rootCmd.Flags().BoolVar(&debug.Enabled, "verbose", debug.Enabled, "Enable verbose logging")
 	rootCmd.Flags().BoolVar(&conciseOutput, "concise-output", conciseOutput, "Reduce the amount of output and no statistics")
+	rootCmd.Flags().BoolVar(&downloadModules, "download-modules", downloadModules, "Download remote git and registry modules which have not been cached by 'terraform init'")
 	rootCmd.Flags().BoolVar(&excludeDownloaded, "exclude-downloaded-modules", excludeDownloaded, "Remove results for downloaded modules in .terraform folder")
+	rootCmd.Flags().IntVar(&exitCodeOnFindings, "exit-code-on-findings", exitCodeOnFindings, "Exit code to use when problems are found")
 	rootCmd.Flags().BoolVar(&detailedExitCode, "detailed-exit-code", detailedExitCode, "Produce more detailed exit status codes.")
+	rootCmd.Flags().StringSliceVar(&failOnSeverity, "fail-on-severity", failOnSeverity, "Only let problems of the given severities affect the exit code, e.g. CRITICAL,HIGH. Other problems are still reported.")
 	rootCmd.Flags().BoolVar(&includePassed, "include-passed", includePassed, "Include passed checks in the result output")
+	rootCmd.Flags().BoolVar(&includeRuleDocs, "include-rule-docs", includeRuleDocs, "Include the summary, explanation, impact and resolution of the rule in each result of the json and json-lines output")
 	rootCmd.Flags().BoolVar(&includeIgnored, "include-ignored", includeIgnored, "Include ignored checks in the result output")
+	rootCmd.Flags().StringVar(&baselineFile, "baseline", baselineFile, "Suppress findings which are recorded in the given baseline file, so only new findings are reported")
+	rootCmd.Flags().BoolVar(&generateBaseline, "generate-baseline", generateBaseline, "Write the current findings to the file given by --baseline instead of reporting them")
+	rootCmd.Flags().StringVar(&compareTo, "compare-to", compareTo, "Only report findings which were added since the results in the given JSON output file, and list those which were resolved")
+	rootCmd.Flags().BoolVar(&noIgnores, "no-ignores", noIgnores, "Disable ignore comments and report every finding, e.g. for security reviews")
+	rootCmd.Flags().BoolVar(&listIgnoredFindings, "list-ignored", listIgnoredFindings, "List the findings which are suppressed by ignore comments, and the comment suppressing each, then exit. Use --format json or --format table.")
+	rootCmd.Flags().BoolVar(&requireIgnoreJustification, "require-ignore-justification", requireIgnoreJustification, "Only apply ignore comments which give a quoted justification, e.g. tfsec:ignore:<rule> \"reason\"")
 	rootCmd.Flags().BoolVar(&allDirs, "force-all-dirs", allDirs, "Don't search for tf files, include everything below provided directory.")
+	rootCmd.Flags().BoolVar(&showStats, "stats", showStats, "Print a summary of the results by severity, service and provider, along with the size and duration of the scan")
+	rootCmd.Flags().StringVar(&metricsFile, "metrics-file", metricsFile, "Write metrics for the findings and the scan to the given file in the Prometheus textfile format, e.g. for the node exporter textfile collector")
+	rootCmd.Flags().IntVar(&concurrency, "concurrency", concurrency, "Number of blocks to check concurrently (defaults to the number of CPUs)")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", noCache, "Parse and check every file each time it is loaded, rather than reusing the parse of unchanged files and the cached results of unchanged scans")
+	rootCmd.Flags().StringVar(&cacheDir, "cache-dir", cacheDir, "Directory to keep cached data such as downloaded modules and scan results in (defaults to tfsec in the user cache directory)")
+	rootCmd.Flags().BoolVar(&changedFilesOnly, "changed-files-only", changedFilesOnly, "Only report findings in files which have changed in the git working tree since --base-ref. All files are still parsed so references resolve correctly.")
+	rootCmd.Flags().StringVar(&baseRef, "base-ref", baseRef, "The git ref to compare against when using --changed-files-only")
+	rootCmd.Flags().BoolVar(&readStdin, "stdin", readStdin, "Scan terraform read from stdin rather than a directory. Equivalent to giving - as the directory.")
+	rootCmd.Flags().StringArrayVar(&excludePaths, "exclude-path", excludePaths, "Skip files and directories matching the glob pattern, relative to the scanned directory. Supports ** and can be repeated.")
+	rootCmd.Flags().BoolVar(&scanDotTerraform, "scan-dot-terraform", scanDotTerraform, "Scan .terraform directories, which are skipped by default")
+	rootCmd.Flags().StringSliceVar(&filterProviders, "filter-provider", filterProviders, "Only run checks for the given provider, e.g. aws. Can be repeated or comma separated.")
+	rootCmd.Flags().BoolVar(&printConfig, "print-config", printConfig, "Print the effective configuration as JSON, after merging the config file and flags, and exit")
+	rootCmd.Flags().BoolVar(&showChecks, "list-checks", showChecks, "List the registered checks and exit. Use --format json or --format table.")
+	rootCmd.Flags().BoolVar(&applyFixes, "fix", applyFixes, "Rewrite the templates in place to resolve findings for checks which support automatic fixes. Remaining findings are reported as usual.")
+	rootCmd.Flags().BoolVar(&noDedup, "no-dedup", noDedup, "Report every identical finding, rather than reporting findings with the same check, location and description once")
+	rootCmd.Flags().BoolVar(&conservative, "conservative", conservative, "Check both results of conditional expressions whose condition can't be resolved, and report a finding from either")
+	rootCmd.Flags().BoolVar(&trackPassed, "track-passed", trackPassed, "Record which checks passed or didn't apply to each resource, and include them in the passed and skipped sections of the JSON output")
+	rootCmd.Flags().Float64Var(&entropyThreshold, "secret-entropy-threshold", entropyThreshold, fmt.Sprintf("The entropy in bits per character above which literal tokens are reported as secrets (default %v)", security.DefaultEntropyThreshold))
 	rootCmd.Flags().BoolVar(&runStatistics, "run-statistics", runStatistics, "View statistics table of current findings.")
 	rootCmd.Flags().BoolVar(&ignoreWarnings, "ignore-warnings", ignoreWarnings, "[DEPRECATED] Don't show warnings in the output.")
 	rootCmd.Flags().BoolVar(&ignoreInfo, "ignore-info", ignoreWarnings, "[DEPRECATED] Don't show info results in the output.")
 	rootCmd.Flags().BoolVarP(&stopOnCheckError, "allow-checks-to-panic", "p", stopOnCheckError, "Allow panics to propagate up from rule checking")
-	rootCmd.Flags().StringVarP(&workspace, "workspace", "w", workspace, "Specify a workspace for ignore limits")
+	rootCmd.Flags().StringVarP(&workspace, "workspace", "w", workspace, "The terraform workspace to evaluate terraform.workspace as and apply workspace ignores for")
+	rootCmd.Flags().IntVar(&codeLines, "code-lines", codeLines, "Number of lines of code to include either side of each result")
+	rootCmd.Flags().StringVar(&groupBy, "group-by", groupBy, "Group default output by 'rule' or 'resource'")
+	rootCmd.Flags().StringVar(&postResultsURL, "post-results", postResultsURL, "POST the results as JSON to the given URL once the scan is complete")
+	rootCmd.Flags().StringArrayVar(&postHeaders, "post-header", postHeaders, "Header to send with --post-results, as 'Name: value'. Can be repeated.")
+	rootCmd.Flags().DurationVar(&postTimeout, "post-timeout", postTimeout, "Timeout for each attempt to post results")
+	rootCmd.Flags().DurationVar(&scanTimeout, "timeout", scanTimeout, fmt.Sprintf("Stop the scan after the given duration, e.g. 5m, and report the results gathered so far, waiting at most %s more for the checks to stop", hardDeadlineGrace))
+	rootCmd.Flags().StringVar(&sortBy, "sort-by", sortBy, "Order results by 'location' (file, line and rule ID) or 'severity'")
+	rootCmd.Flags().StringVarP(&minimumSeverity, "minimum-severity", "m", minimumSeverity, "The minimum severity to report. One of CRITICAL, HIGH, MEDIUM, LOW, INFO.")
+	rootCmd.Flags().BoolVar(&showAll, "show-all", showAll, "Show results below the minimum severity without letting them affect the exit code")
 	rootCmd.Flags().BoolVar(&passingGif, "gif", passingGif, "Show a celebratory gif in the terminal if no problems are found (default formatter only)")
 }
 
@@ -97,13 +204,30 @@ func main() {
 }
 
 var rootCmd = &cobra.Command{
-	Use:   "tfsec [directory]",
+	Use:   "tfsec [directory...]",
 	Short: "tfsec is a terraform security scanner",
-	Long:  `tfsec is a simple tool to detect potential security vulnerabilities in your terraformed infrastructure.`,
+	Long: `tfsec is a simple tool to detect potential security vulnerabilities in your terraformed infrastructure.
+
+Exit codes:
+  0  no problems were found, all problems are of LOW or INFO severity, or --soft-fail is set
+  1  the scan could not be completed (e.g. parse, IO or configuration errors)
+  N  problems were found, where N is set by --exit-code-on-findings (default 1)
+
+With --detailed-exit-code, 0 means no problems, 1 means problems were found and 2 means only LOW or INFO severity problems were found.
+
+With --fail-on-severity, only problems of the listed severities are considered, including LOW and INFO if they're listed.
+
+If --timeout expires, the exit code is 124, even with --soft-fail.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
 
-		// disable colour if running on windows - colour formatting doesn't work
-		if disableColours || runtime.GOOS == "windows" {
+		colourEnabled, err := useColour()
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if colourEnabled {
+			tml.EnableFormatting()
+		} else {
 			debug.Log("Disabled formatting.")
 			tml.DisableFormatting()
 		}
@@ -127,46 +251,44 @@ var rootCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 
 		var dir string
-		var err error
-		var filterResultsList []string
 		var outputFile *os.File
 
 		if ignoreWarnings || ignoreInfo {
 			fmt.Fprint(os.Stderr, "WARNING: The --ignore-info and --ignore-warnings flags are deprecated and will soon be removed.\n")
 		}
 
-		if len(args) == 1 {
-			dir, err = filepath.Abs(args[0])
-		} else {
-			dir, err = os.Getwd()
+		if len(args) == 1 && args[0] == "-" {
+			readStdin = true
 		}
+		if readStdin && len(args) > 1 {
+			fmt.Println("directories can't be given when reading from stdin")
+			os.Exit(1)
+		}
+
+		dirs, err := getScanDirs(args)
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
+		// with several roots, config and results are relative to the directory containing them all
+		dir = commonDir(dirs)
 		tfsecDir := fmt.Sprintf("%s/.tfsec", dir)
 
+		loadedConfigFile = ""
 		if len(configFile) > 0 {
 			tfsecConfig, err = loadConfigFile(configFile)
 			if err != nil {
 				return err
 			}
-		} else {
-			jsonConfigFile := fmt.Sprintf("%s/%s", tfsecDir, "config.json")
-			yamlConfigFile := fmt.Sprintf("%s/%s", tfsecDir, "config.yml")
-			if _, err = os.Stat(jsonConfigFile); err == nil {
-				tfsecConfig, err = loadConfigFile(jsonConfigFile)
-				if err != nil {
-					return err
-				}
-			} else if _, err = os.Stat(yamlConfigFile); err == nil {
-				tfsecConfig, err = loadConfigFile(yamlConfigFile)
-				if err != nil {
-					return err
-				}
-			} else {
-				tfsecConfig = &config.Config{}
+			loadedConfigFile = configFile
+		} else if discoveredConfigFile, found := config.FindConfigFile(dir); found {
+			tfsecConfig, err = loadConfigFile(discoveredConfigFile)
+			if err != nil {
+				return err
 			}
+			loadedConfigFile = discoveredConfigFile
+		} else {
+			tfsecConfig = &config.Config{}
 		}
 
 		debug.Log("Loading custom checks...")
@@ -182,8 +304,11 @@ var rootCmd = &cobra.Command{
 		}
 		debug.Log("Custom checks loaded")
 
-		if len(filterResults) > 0 {
-			filterResultsList = strings.Split(filterResults, ",")
+		warnOnUnknownConfigRuleIDs()
+		warnOnUnknownProviders()
+
+		if showChecks {
+			return listChecks(os.Stdout, format)
 		}
 
 		if outputFlag != "" {
@@ -201,42 +326,212 @@ var rootCmd = &cobra.Command{
 			outputFile = os.Stdout
 		}
 
-		formatter, err := getFormatter()
-		if err != nil {
+		var threshold severity.Severity
+		if minimumSeverity == "" {
+			// the command line flag takes precedence over the config file
+			threshold = severity.Severity(tfsecConfig.MinimumSeverity)
+		} else {
+			threshold = severity.StringToSeverity(minimumSeverity)
+			if threshold == severity.None {
+				fmt.Printf("invalid minimum severity specified: '%s'\n", minimumSeverity)
+				os.Exit(1)
+			}
+		}
+
+		failSeverities = nil
+		for _, s := range failOnSeverity {
+			sev := severity.StringToSeverity(strings.TrimSpace(s))
+			if !sev.IsValid() {
+				fmt.Printf("invalid fail-on-severity specified: '%s'\n", s)
+				os.Exit(1)
+			}
+			failSeverities = append(failSeverities, sev)
+		}
+
+		// the command line flag takes precedence over the config file
+		if entropyThreshold > 0 {
+			security.SetEntropyThreshold(entropyThreshold)
+		} else if tfsecConfig.EntropyThreshold > 0 {
+			security.SetEntropyThreshold(tfsecConfig.EntropyThreshold)
+		}
+		tagging.SetRequiredTags(tfsecConfig.RequiredTags)
+		ports.SetSensitivePorts(tfsecConfig.SensitivePorts)
+		logbuckets.SetConvention(tfsecConfig.LogBucketPatterns, tfsecConfig.LogBucketTags)
+		retention.SetMinimums(tfsecConfig.RetentionDays)
+		protection.SetProtectedTypes(tfsecConfig.ProtectedTypes)
+		publicip.SetAllowed(tfsecConfig.PublicIPTags, tfsecConfig.PublicIPLabels)
+
+		// the command line flag takes precedence over the config file
+		theme := colourTheme
+		if theme == "" {
+			theme = tfsecConfig.ColourTheme
+		}
+		if theme == "" {
+			theme = "default"
+		}
+		if err := formatters.SetSeverityTheme(theme, tfsecConfig.SeverityColours); err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		if len(tfvarsPaths) == 0 && unusedTfvarsPresent(dir) {
-			fmt.Fprintf(os.Stderr, "Warning: A tfvars file was found but not automatically used. Did you mean to specify the --tfvars-file flag?\n")
+		if printConfig {
+			return writeEffectiveConfig(os.Stdout, threshold)
+		}
+
+		if generateBaseline && baselineFile == "" {
+			fmt.Println("--generate-baseline requires a file to be given with --baseline")
+			os.Exit(1)
+		}
+
+		if trackPassed && strings.ToLower(format) != "json" {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: --track-passed only affects the json format\n")
+		}
+
+		if includeRuleDocs && strings.ToLower(format) != "json" && strings.ToLower(format) != "json-lines" {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: --include-rule-docs only affects the json and json-lines formats\n")
+		}
+
+		if noIgnores && listIgnoredFindings {
+			fmt.Println("--no-ignores can't be used with --list-ignored")
+			os.Exit(1)
+		}
+
+		if applyFixes && readStdin {
+			fmt.Println("--fix can't be used when reading from stdin")
+			os.Exit(1)
+		}
+
+		if groupBy != "rule" && groupBy != "resource" {
+			fmt.Printf("invalid group-by specified: '%s'\n", groupBy)
+			os.Exit(1)
+		}
+
+		if sortBy != "location" && sortBy != "severity" {
+			fmt.Printf("invalid sort-by specified: '%s'\n", sortBy)
+			os.Exit(1)
 		}
 
-		debug.Log("Starting parser...")
-		modules, err := parser.New(dir, getParserOptions()...).ParseDirectory()
+		// ignored findings are listed as a table or json rather than through a formatter
+		if listIgnoredFindings && strings.ToLower(format) == "table" {
+			format = "default"
+		}
+		formatter, err := getFormatter()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		debug.Log("Starting scanner...")
-		results := scanner.New(getScannerOptions()...).Scan(modules)
-		results = updateResultSeverity(results)
-		results = removeDuplicatesAndUnwanted(results, ignoreWarnings, excludeDownloaded)
-		if len(filterResultsList) > 0 {
-			var filteredResult []result.Result
-			for _, result := range results {
-				for _, ruleID := range filterResultsList {
-					if result.RuleID == ruleID {
-						filteredResult = append(filteredResult, result)
+		if scanTimeout > 0 {
+			var cancel, cancelChecks context.CancelFunc
+			scanContext, cancel = context.WithTimeout(context.Background(), scanTimeout)
+			defer cancel()
+			checkContext, cancelChecks = context.WithTimeout(context.Background(), scanTimeout+hardDeadlineGrace)
+			defer cancelChecks()
+		}
+		scannerOptions := getScannerOptions()
+		var streamer *resultStreamer
+		if canStreamResults() {
+			streamer = newResultStreamer(outputFile, dir, threshold)
+			scannerOptions = append(scannerOptions, scanner.OptionWithResultHandler(streamer.handle))
+		}
+		tfsecScanner := scanner.New(scannerOptions...)
+		if scanTimeout > 0 {
+			defer startHardDeadline(outputFile, formatter, tfsecScanner, streamer, dir, threshold).Stop()
+		}
+
+		if noCache {
+			parser.SetParseCacheEnabled(false)
+		}
+		if cacheDir != "" {
+			parser.SetCacheDir(cacheDir)
+		}
+
+		var results []result.Result
+		var cached bool
+		var scanCacheDir, scanKey string
+		if useScanCache() {
+			scanCacheDir, scanKey = getScanCache()
+			if scanKey != "" {
+				results, cached = scancache.Load(scanCacheDir, scanKey)
+			}
+		}
+
+		if cached {
+			debug.Log("Using the cached results of an unchanged scan")
+			// nothing was scanned, so nothing was streamed
+			streamer = nil
+		} else {
+			var modules []block.Module
+			if readStdin {
+				debug.Log("Reading from stdin...")
+				var src []byte
+				src, err = ioutil.ReadAll(os.Stdin)
+				if err == nil {
+					modules, err = parser.New(dir, getParserOptions()...).ParseSource(src, stdinFilename)
+				}
+			} else if planFile != "" {
+				debug.Log("Loading plan file...")
+				modules, err = parser.LoadPlanFile(planFile)
+			} else {
+				for _, scanDir := range dirs {
+					if len(tfvarsPaths) == 0 && unusedTfvarsPresent(scanDir) {
+						fmt.Fprintf(os.Stderr, "Warning: A tfvars file was found but not automatically used. Did you mean to specify the --tfvars-file flag?\n")
+					}
+
+					// each root is parsed separately so variables and locals don't leak between them
+					debug.Log("Starting parser for %s...", scanDir)
+					var dirModules []block.Module
+					dirModules, err = parser.New(scanDir, getParserOptions()...).ParseDirectory()
+					// modules parsed before a timeout are kept, so they can still be checked
+					modules = append(modules, dirModules...)
+					if err != nil {
+						break
 					}
 				}
 			}
-			results = filteredResult
+			if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			debug.Log("Starting scanner...")
+			results = tfsecScanner.Scan(modules)
+			// incomplete results mustn't be reused by later runs
+			if scanKey != "" && err == nil && scanContext.Err() == nil {
+				if err := scancache.Save(scanCacheDir, scanKey, scanCacheInputs(), results); err != nil {
+					debug.Log("Failed to cache the scan: %s", err)
+				}
+			}
+		}
+		timedOut := scanContext.Err() != nil
+		if timedOut {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: The scan timed out after %s, so the results are incomplete\n", scanTimeout)
+		}
+		if listIgnoredFindings || generateBaseline {
+			selected, err := selectResults(results, dir)
+			if err != nil {
+				return err
+			}
+			if listIgnoredFindings {
+				return listIgnored(os.Stdout, format, selected)
+			}
+			if timedOut {
+				// an incomplete baseline would hide findings which weren't reached from later scans
+				_, _ = fmt.Fprintf(os.Stderr, "Not writing a baseline from incomplete results\n")
+				os.Exit(timeoutExitCode)
+			}
+			if err := baseline.Generate(selected, dir).Save(baselineFile); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(os.Stderr, "Baseline of %d findings written to %s\n", len(selected)-countPassedResults(selected), baselineFile)
+			return nil
 		}
 
-		for _, result := range results {
-			metrics.AddResult(result.Severity)
+		results, failingResults, err := processResults(results, dir, threshold)
+		if err != nil {
+			return err
 		}
+		countResults(results)
 
 		if runStatistics {
 			statistics := scanner.Statistics{}
@@ -247,50 +542,50 @@ var rootCmd = &cobra.Command{
 			return nil
 		}
 
-		if err := formatter(outputFile, results, dir, getFormatterOptions()...); err != nil {
+		if err := writeResults(outputFile, formatter, results, streamer, dir); err != nil {
 			return err
 		}
 
-		// Soft fail always takes precedence. If set, only execution errors
-		// produce a failure exit code (1).
-		if softFail {
-			return nil
+		if showStats {
+			formatters.PrintSummary(os.Stderr, formatters.NewSummary(results))
 		}
 
-		if detailedExitCode {
-			os.Exit(getDetailedExitCode(results))
+		if err := publishResults(results); err != nil {
+			return err
 		}
 
-		// If all failed rules are of LOW severity, then produce a success
-		// exit code (0).
-		if allInfo(results) {
+		if timedOut {
+			os.Exit(timeoutExitCode)
+		}
+
+		// Soft fail always takes precedence. If set, only execution errors
+		// produce a failure exit code (1).
+		if softFail {
 			return nil
 		}
 
-		os.Exit(1)
+		if exitCode := getExitCode(failingResults); exitCode != 0 {
+			os.Exit(exitCode)
+		}
 		return nil
 	},
 }
 
 func getParserOptions() []parser.Option {
 	var opts []parser.Option
+	if len(excludePaths) > 0 {
+		opts = append(opts, parser.OptionWithExcludePaths(excludePaths))
+	}
+	if scanDotTerraform {
+		opts = append(opts, parser.OptionScanDotTerraform())
+	}
 	if allDirs {
 		opts = append(opts, parser.OptionDoNotSearchTfFiles())
 	}
-	var validTfVarFiles []string
 	if len(tfvarsPaths) > 0 {
-		for _, tfvarsPath := range tfvarsPaths {
-			tfvp, err := filepath.Abs(tfvarsPath)
-			if err != nil {
-				fmt.Println(err)
-			}
-			if _, err := os.Stat(tfvp); err == nil {
-				validTfVarFiles = append(validTfVarFiles, tfvp)
-			}
-		}
-		opts = append(opts, parser.OptionWithTFVarsPaths(validTfVarFiles))
+		opts = append(opts, parser.OptionWithTFVarsPaths(validTfvarsPaths()))
 	}
-	if !ignoreHCLErrors {
+	if strict {
 		opts = append(opts, parser.OptionStopOnHCLError())
 	}
 
@@ -298,16 +593,64 @@ func getParserOptions() []parser.Option {
 		opts = append(opts, parser.OptionWithWorkspaceName(workspace))
 	}
 
+	if downloadModules {
+		opts = append(opts, parser.OptionDownloadModules())
+	}
+
+	opts = append(opts, parser.OptionWithContext(scanContext))
+
 	return opts
 }
 
+// getExitCode decides the exit status from the results at or above the minimum severity
+func getExitCode(results []result.Result) int {
+	if len(failSeverities) > 0 {
+		results = withSeverities(results, failSeverities)
+	}
+
+	if detailedExitCode {
+		return getDetailedExitCode(results)
+	}
+
+	// severities chosen with --fail-on-severity always fail, even LOW and INFO
+	if len(failSeverities) > 0 {
+		for _, res := range results {
+			if res.Status != result.Passed && res.Status != result.Ignored {
+				return exitCodeOnFindings
+			}
+		}
+		return 0
+	}
+
+	// If all failed rules are of LOW or INFO severity, then produce a success
+	// exit code (0).
+	if allInfo(results) {
+		return 0
+	}
+
+	return exitCodeOnFindings
+}
+
+func withSeverities(results []result.Result, severities []severity.Severity) []result.Result {
+	var filtered []result.Result
+	for _, res := range results {
+		for _, sev := range severities {
+			if res.Severity == sev {
+				filtered = append(filtered, res)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func getDetailedExitCode(results []result.Result) int {
 	// If there are no failed rules, then produce a success exit code (0).
 	if len(results) == 0 || len(results) == countPassedResults(results) {
 		return 0
 	}
 
-	// If there are some failed rules but they are all of LOW severity, then
+	// If there are some failed rules but they are all of LOW or INFO severity, then
 	// produce a special failure exit code (2).
 	if allInfo(results) {
 		return 2
@@ -335,7 +678,7 @@ func removeDuplicatesAndUnwanted(results []result.Result, ignoreWarnings bool, e
 			continue
 		}
 
-		if ignoreInfo && res.Severity == severity.Low {
+		if ignoreInfo && (res.Severity == severity.Low || res.Severity == severity.Info) {
 			continue
 		}
 
@@ -344,6 +687,19 @@ func removeDuplicatesAndUnwanted(results []result.Result, ignoreWarnings bool, e
 	return returnVal
 }
 
+func removeBelowSeverity(results []result.Result, threshold severity.Severity) []result.Result {
+	if threshold == severity.None {
+		return results
+	}
+	var filtered []result.Result
+	for _, res := range results {
+		if res.Severity.Rank() >= threshold.Rank() {
+			filtered = append(filtered, res)
+		}
+	}
+	return filtered
+}
+
 func getFormatterOptions() []formatters.FormatterOption {
 	var options []formatters.FormatterOption
 	if conciseOutput {
@@ -355,32 +711,86 @@ func getFormatterOptions() []formatters.FormatterOption {
 	if passingGif {
 		options = append(options, formatters.PassingGif)
 	}
+	if groupBy == "resource" {
+		options = append(options, formatters.GroupByResource)
+	}
+	if includeRuleDocs {
+		options = append(options, formatters.IncludeRuleDocs)
+	}
 	return options
 }
 
+// validTfvarsPaths returns the absolute paths of the tfvars files given with --tfvars-file which exist
+func validTfvarsPaths() []string {
+	var validTfVarFiles []string
+	for _, tfvarsPath := range tfvarsPaths {
+		tfvp, err := filepath.Abs(tfvarsPath)
+		if err != nil {
+			fmt.Println(err)
+		}
+		if _, err := os.Stat(tfvp); err == nil {
+			validTfVarFiles = append(validTfVarFiles, tfvp)
+		}
+	}
+	return validTfVarFiles
+}
+
 func getScannerOptions() []scanner.Option {
 	var options []scanner.Option
-	if includePassed {
+	// junit reports passed checks as passing testcases so CI trend graphs stay accurate
+	if includePassed || strings.EqualFold(format, "junit") {
 		options = append(options, scanner.OptionIncludePassed())
 	}
 	if includeIgnored {
 		options = append(options, scanner.OptionIncludeIgnored())
 	}
+	if requireIgnoreJustification {
+		options = append(options, scanner.OptionRequireIgnoreJustification())
+	}
+	if conservative {
+		options = append(options, scanner.OptionConservative())
+	}
+	if noIgnores {
+		options = append(options, scanner.OptionNoIgnores())
+	}
+	if listIgnoredFindings {
+		options = append(options, scanner.OptionOnlyIgnored())
+	}
 	if workspace != "" {
 		options = append(options, scanner.OptionWithWorkspaceName(workspace))
 	}
+	if concurrency > 0 {
+		options = append(options, scanner.OptionConcurrency(concurrency))
+	}
+	if len(filterProviders) > 0 {
+		options = append(options, scanner.OptionFilterProviders(filterProviders))
+	}
+	if noDedup {
+		options = append(options, scanner.OptionDisableDeduplication())
+	}
+	if trackPassed {
+		options = append(options, scanner.OptionTrackPassed())
+	}
 
 	if stopOnCheckError {
 		options = append(options, scanner.OptionStopOnErrors())
 	}
 
+	options = append(options, scanner.OptionWithContext(checkContext))
+
+	allExcludedRuleIDs, allIncludedRuleIDs := getRuleIDFilters()
+	options = append(options, scanner.OptionExcludeRules(allExcludedRuleIDs))
+
+	options = append(options, scanner.OptionIncludeRules(allIncludedRuleIDs))
+	return options
+}
+
+// getRuleIDFilters merges the rules excluded and included on the command line with those in the config file
+func getRuleIDFilters() (excluded []string, included []string) {
 	var allExcludedRuleIDs []string
 	for _, exclude := range strings.Split(excludedRuleIDs, ",") {
 		allExcludedRuleIDs = append(allExcludedRuleIDs, strings.TrimSpace(exclude))
 	}
-	allExcludedRuleIDs = mergeWithoutDuplicates(allExcludedRuleIDs, tfsecConfig.ExcludedChecks)
-
-        options = append(options, scanner.OptionExcludeRules(allExcludedRuleIDs))
 
 	var allIncludedRuleIDs []string
 	if len(includedRuleIDs) > 0 {
@@ -388,10 +798,28 @@ func getScannerOptions() []scanner.Option {
 			allIncludedRuleIDs = append(allIncludedRuleIDs, strings.TrimSpace(include))
 		}
 	}
-	allIncludedRuleIDs = mergeWithoutDuplicates(allIncludedRuleIDs, tfsecConfig.IncludedChecks)
 
-	options = append(options, scanner.OptionIncludeRules(allIncludedRuleIDs))
-	return options
+	allExcludedRuleIDs = expandRuleIDPatterns(allExcludedRuleIDs, "--exclude")
+	allIncludedRuleIDs = expandRuleIDPatterns(allIncludedRuleIDs, "--include")
+
+	// rules named on the command line take precedence over the opposite setting in the config file
+	configExcludedRuleIDs := withoutRuleIDs(expandRuleIDPatterns(tfsecConfig.ExcludedChecks, "exclude in the config file"), allIncludedRuleIDs)
+	configIncludedRuleIDs := withoutRuleIDs(expandRuleIDPatterns(tfsecConfig.IncludedChecks, "include in the config file"), allExcludedRuleIDs)
+
+	allExcludedRuleIDs = mergeWithoutDuplicates(allExcludedRuleIDs, configExcludedRuleIDs)
+	allIncludedRuleIDs = mergeWithoutDuplicates(allIncludedRuleIDs, configIncludedRuleIDs)
+
+	return allExcludedRuleIDs, allIncludedRuleIDs
+}
+
+// expandRuleIDPatterns replaces wildcards in a list of rule IDs with the rules they match, warning about patterns
+// which match nothing as they're likely to be mistakes
+func expandRuleIDPatterns(ruleIDs []string, source string) []string {
+	expanded, unmatched := scanner.ExpandRuleIDPatterns(ruleIDs)
+	for _, pattern := range unmatched {
+		_, _ = fmt.Fprintf(os.Stderr, "WARNING: the pattern '%s' given to %s doesn't match any rules\n", pattern, source)
+	}
+	return expanded
 }
 
 func mergeWithoutDuplicates(left, right []string) []string {
@@ -408,9 +836,49 @@ func mergeWithoutDuplicates(left, right []string) []string {
 	return results
 }
 
+func withoutRuleIDs(ruleIDs []string, remove []string) []string {
+	var filtered []string
+	for _, ruleID := range ruleIDs {
+		if !checkInList(ruleID, remove) {
+			filtered = append(filtered, ruleID)
+		}
+	}
+	return filtered
+}
+
+func checkInList(ruleID string, list []string) bool {
+	for _, item := range list {
+		if item == ruleID {
+			return true
+		}
+	}
+	return false
+}
+
+// warnOnUnknownConfigRuleIDs reports config entries which don't match any rule, as these are likely to be typos
+func warnOnUnknownConfigRuleIDs() {
+	rules := scanner.GetRegisteredRules()
+	for _, ruleID := range tfsecConfig.RuleIDs() {
+		// patterns are checked when they're expanded
+		if scanner.IsRuleIDPattern(ruleID) {
+			continue
+		}
+		known := false
+		for _, r := range rules {
+			if r.MatchesID(ruleID) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: the config file refers to an unknown rule '%s'\n", ruleID)
+		}
+	}
+}
+
 func allInfo(results []result.Result) bool {
 	for _, res := range results {
-		if res.Severity != severity.Low && res.Status != result.Passed && res.Status != result.Ignored {
+		if res.Severity != severity.Low && res.Severity != severity.Info && res.Status != result.Passed && res.Status != result.Ignored {
 			return false
 		}
 	}
@@ -437,12 +905,37 @@ func updateResultSeverity(results []result.Result) []result.Result {
 	return overriddenResults
 }
 
+// useColour decides whether output should be coloured. In auto mode colour is used only when writing to a terminal
+// and NO_COLOR (https://no-color.org) isn't set.
+func useColour() (bool, error) {
+	if disableColours {
+		return false, nil
+	}
+	switch strings.ToLower(colourMode) {
+	case "always":
+		return true, nil
+	case "never":
+		return false, nil
+	case "auto":
+		// colour formatting doesn't work on windows
+		if os.Getenv("NO_COLOR") != "" || runtime.GOOS == "windows" {
+			return false, nil
+		}
+		info, err := os.Stdout.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid colour mode specified: '%s', should be one of auto, always or never", colourMode)
+	}
+}
+
 func getFormatter() (formatters.Formatter, error) {
 	switch strings.ToLower(format) {
 	case "", "default":
 		return formatters.FormatDefault, nil
 	case "json":
 		return formatters.FormatJSON, nil
+	case "json-lines":
+		return formatters.FormatJSONLines, nil
 	case "csv":
 		return formatters.FormatCSV, nil
 	case "checkstyle":
@@ -453,6 +946,8 @@ func getFormatter() (formatters.Formatter, error) {
 		return formatters.FormatText, nil
 	case "sarif":
 		return formatters.FormatSarif, nil
+	case "gitlab":
+		return formatters.FormatGitLab, nil
 	default:
 		return nil, fmt.Errorf("invalid format specified: '%s'", format)
 	}
@@ -463,6 +958,52 @@ func loadConfigFile(configFilePath string) (*config.Config, error) {
 	return config.LoadConfig(configFilePath)
 }
 
+// warnOnUnknownProviders reports --filter-provider values which no checks are registered for, as they're likely typos
+func warnOnUnknownProviders() {
+	for _, p := range filterProviders {
+		if len(scanner.FilterRulesByProvider(scanner.GetRegisteredRules(), []string{p})) == 0 {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: no checks are registered for provider '%s'\n", p)
+		}
+	}
+}
+
+// stdinFilename is used in the ranges of results for terraform read from stdin
+const stdinFilename = "<stdin>"
+
+func printResolvedResults(resolved []result.Result) {
+	if len(resolved) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(os.Stderr, "%d finding(s) resolved since %s:\n", len(resolved), compareTo)
+	for _, res := range resolved {
+		_, _ = fmt.Fprintf(os.Stderr, "  %s %s (%s)\n", res.RuleID, res.Resource, res.Location)
+	}
+}
+
+// writeMetricsFile replaces the metrics file in a single rename, so the textfile collector never reads a partial file
+func writeMetricsFile(path string, results []result.Result) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), ".tfsec-metrics-*")
+	if err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if err := formatters.WritePrometheusMetrics(f, results); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	// the collector usually runs as another user, so the metrics must be readable by everyone
+	if err := os.Chmod(f.Name(), 0644); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		return fmt.Errorf("failed to write metrics file: %w", err)
+	}
+	return nil
+}
+
 func countPassedResults(results []result.Result) int {
 	passed := 0
 
@@ -476,10 +1017,14 @@ func countPassedResults(results []result.Result) int {
 }
 
 func unusedTfvarsPresent(checkDir string) bool {
-	glob := fmt.Sprintf("%s/*.tfvars", checkDir)
-	debug.Log("checking for tfvars files using glob: %s", glob)
-	if matches, err := filepath.Glob(glob); err == nil && len(matches) > 0 {
-		return true
+	for _, glob := range []string{
+		fmt.Sprintf("%s/*.tfvars", checkDir),
+		fmt.Sprintf("%s/*.tfvars.json", checkDir),
+	} {
+		debug.Log("checking for tfvars files using glob: %s", glob)
+		if matches, err := filepath.Glob(glob); err == nil && len(matches) > 0 {
+			return true
+		}
 	}
 	return false
 }
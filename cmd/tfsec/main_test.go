@@ -1,13 +1,25 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/config"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+
 	"github.com/aquasecurity/tfsec/pkg/result"
 
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_IfIgnoreWarningsSetShouldRemoveWarningScanResults(t *testing.T) {
@@ -43,3 +55,351 @@ func Test_IfIgnoreWarningsIsNotSetThenWarningShouldBeInScanResults(t *testing.T)
 	actualResults := removeDuplicatesAndUnwanted(twoScanResultsWithOneWarning, false, false)
 	assert.Len(t, actualResults, expectedResultsAfterFiltering)
 }
+
+func Test_IfMinimumSeveritySetShouldRemoveLowerSeverityResults(t *testing.T) {
+	results := []result.Result{
+		{
+			RuleID:   "1",
+			Severity: severity.Critical,
+		},
+		{
+			RuleID:   "2",
+			Severity: severity.High,
+		},
+		{
+			RuleID:   "3",
+			Severity: severity.Medium,
+		},
+		{
+			RuleID:   "4",
+			Severity: severity.Low,
+		},
+		{
+			RuleID:   "5",
+			Severity: severity.Info,
+		},
+	}
+
+	assert.Len(t, removeBelowSeverity(results, severity.High), 2)
+	assert.Len(t, removeBelowSeverity(results, severity.Low), 4)
+	assert.Len(t, removeBelowSeverity(results, severity.Info), 5)
+	assert.Len(t, removeBelowSeverity(results, severity.None), 5)
+}
+
+func Test_InfoSeverityIsLowestRank(t *testing.T) {
+	assert.Equal(t, severity.Info, severity.StringToSeverity("info"))
+	assert.True(t, severity.Info.Rank() > severity.None.Rank())
+	for _, sev := range []severity.Severity{severity.Critical, severity.High, severity.Medium, severity.Low} {
+		assert.Greater(t, sev.Rank(), severity.Info.Rank())
+	}
+}
+
+func Test_DetailedExitCodeTreatsInfoAsLow(t *testing.T) {
+	assert.Equal(t, 2, getDetailedExitCode([]result.Result{
+		{RuleID: "1", Severity: severity.Info, Status: result.Failed},
+		{RuleID: "2", Severity: severity.Low, Status: result.Failed},
+	}))
+	assert.Equal(t, 1, getDetailedExitCode([]result.Result{
+		{RuleID: "1", Severity: severity.Info, Status: result.Failed},
+		{RuleID: "2", Severity: severity.Medium, Status: result.Failed},
+	}))
+}
+
+func Test_CommandLineRuleIDsTakePrecedenceOverConfig(t *testing.T) {
+	configExcludes := []string{"aws-s3-enable-versioning", "aws-s3-enable-bucket-logging"}
+	cliIncludes := []string{"aws-s3-enable-versioning"}
+
+	assert.Equal(t, []string{"aws-s3-enable-bucket-logging"}, withoutRuleIDs(configExcludes, cliIncludes))
+	assert.Equal(t, configExcludes, withoutRuleIDs(configExcludes, nil))
+}
+
+func Test_SeverityOverridesApplyBeforeMinimumSeverity(t *testing.T) {
+	tfsecConfig = &config.Config{
+		SeverityOverrides: map[string]string{
+			"aws-s3-enable-versioning": "HIGH",
+			"AWS018":                   "LOW",
+		},
+	}
+	defer func() { tfsecConfig = &config.Config{} }()
+
+	results := []result.Result{
+		{
+			RuleID:   "aws-s3-enable-versioning",
+			Severity: severity.Medium,
+		},
+		{
+			RuleID:       "aws-vpc-add-description-to-security-group",
+			LegacyRuleID: "AWS018",
+			Severity:     severity.High,
+		},
+	}
+
+	failing := removeBelowSeverity(updateResultSeverity(results), severity.High)
+	require.Len(t, failing, 1)
+	assert.Equal(t, "aws-s3-enable-versioning", failing[0].RuleID)
+	assert.Equal(t, severity.High, failing[0].Severity)
+}
+
+func Test_ListChecksAsJSON(t *testing.T) {
+	filterProviders = []string{"aws"}
+	defer func() { filterProviders = nil }()
+
+	var buffer bytes.Buffer
+	require.NoError(t, listChecks(&buffer, "json"))
+
+	var checks []listedCheck
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &checks))
+	require.NotEmpty(t, checks)
+	for _, check := range checks {
+		assert.Equal(t, "aws", check.Provider)
+		assert.NotEmpty(t, check.ID)
+		assert.NotEmpty(t, check.DefaultSeverity)
+		assert.NotEmpty(t, check.Links)
+	}
+}
+
+func Test_ListChecksRejectsUnknownFormat(t *testing.T) {
+	assert.Error(t, listChecks(&bytes.Buffer{}, "sarif"))
+}
+
+func Test_ColourModes(t *testing.T) {
+	defer func() { colourMode = "auto" }()
+
+	colourMode = "always"
+	enabled, err := useColour()
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	colourMode = "never"
+	enabled, err = useColour()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	// NO_COLOR disables colour even where stdout would be a terminal
+	colourMode = "auto"
+	t.Setenv("NO_COLOR", "1")
+	enabled, err = useColour()
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	colourMode = "sometimes"
+	_, err = useColour()
+	assert.Error(t, err)
+}
+
+func Test_RuleIDPatternsAreExpanded(t *testing.T) {
+	expanded := expandRuleIDPatterns([]string{"aws-s3-*", "AWS018", "nothing-matches-*"}, "--exclude")
+	assert.Contains(t, expanded, "aws-s3-enable-versioning")
+	assert.Contains(t, expanded, "aws-s3-enable-bucket-logging")
+	assert.Contains(t, expanded, "AWS018")
+	for _, ruleID := range expanded {
+		if ruleID != "AWS018" {
+			assert.True(t, strings.HasPrefix(ruleID, "aws-s3-"), ruleID)
+		}
+	}
+
+	expanded = expandRuleIDPatterns([]string{"*-no-public-*"}, "--include")
+	assert.Contains(t, expanded, "aws-vpc-no-public-ingress-sgr")
+	assert.NotContains(t, expanded, "aws-s3-enable-versioning")
+}
+
+func Test_FailOnSeverityLimitsExitCode(t *testing.T) {
+	defer func() { failSeverities = nil }()
+
+	results := []result.Result{
+		{RuleID: "1", Severity: severity.High, Status: result.Failed},
+		{RuleID: "2", Severity: severity.Low, Status: result.Failed},
+	}
+	assert.Equal(t, exitCodeOnFindings, getExitCode(results))
+
+	failSeverities = []severity.Severity{severity.Critical}
+	assert.Equal(t, 0, getExitCode(results))
+
+	failSeverities = []severity.Severity{severity.Critical, severity.High}
+	assert.Equal(t, exitCodeOnFindings, getExitCode(results))
+
+	// a severity which is chosen explicitly fails, even if it wouldn't by default
+	failSeverities = []severity.Severity{severity.Low}
+	assert.Equal(t, exitCodeOnFindings, getExitCode(results))
+
+	failSeverities = []severity.Severity{severity.Medium}
+	assert.Equal(t, 0, getExitCode(append(results, result.Result{RuleID: "3", Severity: severity.Medium, Status: result.Ignored})))
+}
+
+func Test_PrintConfigShowsEffectiveRules(t *testing.T) {
+	defer func() {
+		tfsecConfig = &config.Config{}
+		excludedRuleIDs = ""
+		filterProviders = nil
+		loadedConfigFile = ""
+	}()
+
+	tfsecConfig = &config.Config{
+		ExcludedChecks:    []string{"aws-s3-*"},
+		SeverityOverrides: map[string]string{"aws-s3-enable-versioning": "LOW"},
+	}
+	excludedRuleIDs = "aws-ec2-enable-at-rest-encryption"
+	filterProviders = []string{"aws"}
+	loadedConfigFile = "/tmp/.tfsec/config.json"
+
+	var buffer bytes.Buffer
+	require.NoError(t, writeEffectiveConfig(&buffer, severity.Medium))
+
+	var effective effectiveConfig
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &effective))
+	assert.Equal(t, "/tmp/.tfsec/config.json", effective.ConfigFile)
+	assert.Equal(t, "MEDIUM", effective.MinimumSeverity)
+	assert.Equal(t, "LOW", effective.SeverityOverrides["aws-s3-enable-versioning"])
+	assert.Contains(t, effective.ExcludedChecks, "aws-s3-enable-versioning")
+	assert.Contains(t, effective.ExcludedChecks, "aws-ec2-enable-at-rest-encryption")
+	assert.Contains(t, effective.Rules, "aws-rds-no-public-db-access")
+	for _, ruleID := range effective.Rules {
+		assert.True(t, strings.HasPrefix(ruleID, "aws-"), ruleID)
+		assert.False(t, strings.HasPrefix(ruleID, "aws-s3-"), ruleID)
+		assert.NotEqual(t, "aws-ec2-enable-at-rest-encryption", ruleID)
+	}
+}
+
+func Test_CommonDirOfScanRoots(t *testing.T) {
+	assert.Equal(t, filepath.FromSlash("/repo/envs"), commonDir([]string{
+		filepath.FromSlash("/repo/envs/prod"),
+		filepath.FromSlash("/repo/envs/staging"),
+	}))
+	assert.Equal(t, filepath.FromSlash("/repo"), commonDir([]string{
+		filepath.FromSlash("/repo/envs/prod"),
+		filepath.FromSlash("/repo/modules"),
+		filepath.FromSlash("/repo"),
+	}))
+	assert.Equal(t, filepath.FromSlash("/repo/envs/prod"), commonDir([]string{filepath.FromSlash("/repo/envs/prod")}))
+	assert.Equal(t, filepath.FromSlash("/repo/envs"), commonDir([]string{
+		filepath.FromSlash("/repo/envs/prod"),
+		filepath.FromSlash("/repo/envs/production"),
+	}))
+}
+
+func Test_ScanDirsAreDeduplicated(t *testing.T) {
+	dirs, err := getScanDirs([]string{"envs/prod", "envs/staging", "envs/prod/"})
+	require.NoError(t, err)
+	require.Len(t, dirs, 2)
+	assert.True(t, filepath.IsAbs(dirs[0]))
+	assert.True(t, strings.HasSuffix(dirs[1], filepath.FromSlash("envs/staging")))
+}
+
+func Test_MetricsFileIsReplaced(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "tfsec.prom")
+	require.NoError(t, ioutil.WriteFile(path, []byte("stale\n"), 0600))
+
+	require.NoError(t, writeMetricsFile(path, []result.Result{
+		{RuleID: "aws-s3-enable-versioning", RuleProvider: "aws", RuleService: "s3", Severity: severity.Medium},
+	}))
+
+	data, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "stale")
+	assert.Contains(t, string(data), `tfsec_findings_total{provider="aws",service="s3",severity="medium"} 1`)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+
+	// the temporary file is renamed into place, so nothing else is left in the directory
+	entries, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_HardDeadlineWritesCollectedResults(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "rule" {
+	type        = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, ".tf", t)
+	tfsecScanner := scanner.New()
+	require.NotEmpty(t, tfsecScanner.Scan(modules))
+
+	var buffer bytes.Buffer
+	require.NoError(t, writeCollectedResults(&buffer, formatters.FormatJSON, tfsecScanner, nil, ".", severity.None))
+
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+	var ruleIDs []string
+	for _, res := range output.Results {
+		ruleIDs = append(ruleIDs, res.RuleID)
+	}
+	assert.Contains(t, ruleIDs, "aws-vpc-no-public-ingress-sgr")
+}
+
+func Test_HardDeadlineProcessesResultsLikeTheScan(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "rule" {
+	type        = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, ".tf", t)
+	tfsecScanner := scanner.New()
+	require.NotEmpty(t, tfsecScanner.Scan(modules))
+
+	defer func(previous string) { filterResults = previous }(filterResults)
+	defer func(previous string) { metricsFile = previous }(metricsFile)
+	filterResults = "aws-vpc-add-description-to-security-group"
+	metricsFile = filepath.Join(t.TempDir(), "tfsec.prom")
+
+	var buffer bytes.Buffer
+	require.NoError(t, writeCollectedResults(&buffer, formatters.FormatJSON, tfsecScanner, nil, ".", severity.None))
+
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+	require.NotEmpty(t, output.Results)
+	for _, res := range output.Results {
+		assert.Equal(t, "aws-vpc-add-description-to-security-group", res.RuleID)
+	}
+	assert.FileExists(t, metricsFile)
+}
+
+func Test_JSONLinesAreStreamedWhileScanning(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "first" {
+	type        = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "second" {
+	type        = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, ".tf", t)
+
+	var buffer bytes.Buffer
+	streamer := newResultStreamer(&buffer, ".", severity.None)
+	var linesWritten []int
+	tfsecScanner := scanner.New(scanner.OptionWithResultHandler(func(results []result.Result) {
+		streamer.handle(results)
+		linesWritten = append(linesWritten, strings.Count(buffer.String(), "\n"))
+	}))
+	results := tfsecScanner.Scan(modules)
+	require.NoError(t, streamer.stop())
+
+	// the results of the first block were written before the second was checked
+	require.Len(t, linesWritten, 2)
+	assert.Greater(t, linesWritten[0], 0)
+	assert.Greater(t, linesWritten[1], linesWritten[0])
+
+	reported, _, err := processResults(results, ".", severity.None)
+	require.NoError(t, err)
+	var expected, streamed []string
+	for _, res := range reported {
+		expected = append(expected, res.RuleID+":"+res.Range().String())
+	}
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var res result.Result
+		require.NoError(t, json.Unmarshal([]byte(line), &res))
+		streamed = append(streamed, res.RuleID+":"+res.Location.String())
+	}
+	assert.ElementsMatch(t, expected, streamed)
+
+	// nothing is written once the scan has finished
+	streamer.handle(results)
+	assert.Equal(t, linesWritten[1], strings.Count(buffer.String(), "\n"))
+}
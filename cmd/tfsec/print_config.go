@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/logbuckets"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/publicip"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/security"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+type effectiveConfig struct {
+	ConfigFile        string            `json:"config_file,omitempty"`
+	Rules             []string          `json:"rules"`
+	ExcludedChecks    []string          `json:"exclude"`
+	IncludedChecks    []string          `json:"include"`
+	SeverityOverrides map[string]string `json:"severity_overrides"`
+	MinimumSeverity   string            `json:"minimum_severity,omitempty"`
+	FailOnSeverity    []string          `json:"fail_on_severity"`
+	FilterProviders   []string          `json:"filter_providers"`
+	TfvarsFiles       []string          `json:"tfvars_files"`
+	CustomCheckDir    string            `json:"custom_check_dir"`
+	ExcludePaths      []string          `json:"exclude_paths"`
+	Workspace         string            `json:"workspace"`
+	EntropyThreshold  float64           `json:"secret_entropy_threshold"`
+	RequiredTags      []string          `json:"required_tags"`
+	SensitivePorts    []int             `json:"sensitive_ports"`
+	LogBucketPatterns []string          `json:"log_bucket_patterns"`
+	LogBucketTags     map[string]string `json:"log_bucket_tags"`
+	RetentionDays     map[string]int    `json:"minimum_retention_days"`
+	ProtectedTypes    []string          `json:"protected_resource_types"`
+	PublicIPTags      []string          `json:"public_ip_allowed_tags"`
+	PublicIPLabels    map[string]string `json:"public_ip_allowed_labels"`
+}
+
+// writeEffectiveConfig writes the configuration a scan would use once the config file and flags are merged, so
+// users can see why a check did or didn't run
+func writeEffectiveConfig(w io.Writer, threshold severity.Severity) error {
+	excluded, included := getRuleIDFilters()
+	excluded = sortedRuleIDs(excluded)
+	included = sortedRuleIDs(included)
+
+	rules := []string{}
+	for _, r := range scanner.FilterRulesByProvider(scanner.GetRegisteredRules(), filterProviders) {
+		if checkInList(r.ID(), excluded) || checkInList(r.LegacyID, excluded) {
+			continue
+		}
+		if len(included) > 0 && !checkInList(r.ID(), included) && !checkInList(r.LegacyID, included) {
+			continue
+		}
+		rules = append(rules, r.ID())
+	}
+	sort.Strings(rules)
+
+	severityOverrides := tfsecConfig.SeverityOverrides
+	if severityOverrides == nil {
+		severityOverrides = map[string]string{}
+	}
+
+	logBucketTags := logbuckets.Tags()
+	if logBucketTags == nil {
+		logBucketTags = map[string]string{}
+	}
+
+	publicIPLabels := publicip.AllowedLabels()
+	if publicIPLabels == nil {
+		publicIPLabels = map[string]string{}
+	}
+
+	failOn := []string{}
+	for _, sev := range failSeverities {
+		failOn = append(failOn, string(sev))
+	}
+
+	effective := effectiveConfig{
+		ConfigFile:        loadedConfigFile,
+		Rules:             rules,
+		ExcludedChecks:    excluded,
+		IncludedChecks:    included,
+		SeverityOverrides: severityOverrides,
+		MinimumSeverity:   string(threshold),
+		FailOnSeverity:    failOn,
+		FilterProviders:   nonNilStrings(filterProviders),
+		TfvarsFiles:       nonNilStrings(validTfvarsPaths()),
+		CustomCheckDir:    customCheckDir,
+		ExcludePaths:      nonNilStrings(excludePaths),
+		Workspace:         workspace,
+		EntropyThreshold:  security.EntropyThreshold(),
+		RequiredTags:      nonNilStrings(tagging.RequiredTags()),
+		SensitivePorts:    ports.SensitivePorts(),
+		LogBucketPatterns: nonNilStrings(logbuckets.NamePatterns()),
+		LogBucketTags:     logBucketTags,
+		RetentionDays:     retention.Minimums(),
+		ProtectedTypes:    protection.ProtectedTypes(),
+		PublicIPTags:      nonNilStrings(publicip.AllowedTags()),
+		PublicIPLabels:    publicIPLabels,
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+	return encoder.Encode(effective)
+}
+
+// sortedRuleIDs sorts a list of rule IDs, dropping the empty entries left by splitting empty flags
+func sortedRuleIDs(ruleIDs []string) []string {
+	sorted := []string{}
+	for _, ruleID := range ruleIDs {
+		if ruleID != "" {
+			sorted = append(sorted, ruleID)
+		}
+	}
+	sort.Strings(sorted)
+	return sorted
+}
+
+// nonNilStrings makes sure empty lists are written as [] rather than null
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/baseline"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/compare"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/fixer"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/gitdiff"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/webhook"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// selectResults applies the severity overrides and keeps the results asked for by --filter-results and
+// --changed-files-only, without duplicates or the results of downloaded modules. This is all that's applied before the
+// results are listed as ignored findings or written as a baseline.
+func selectResults(results []result.Result, dir string) ([]result.Result, error) {
+	results = updateResultSeverity(results)
+	results = removeDuplicatesAndUnwanted(results, ignoreWarnings, excludeDownloaded)
+
+	if len(filterResults) > 0 {
+		filterResultsList := strings.Split(filterResults, ",")
+		var filteredResult []result.Result
+		for _, result := range results {
+			for _, ruleID := range filterResultsList {
+				if result.RuleID == ruleID {
+					filteredResult = append(filteredResult, result)
+				}
+			}
+		}
+		results = filteredResult
+	}
+
+	if changedFilesOnly {
+		changed, err := getChangedFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		var changedResults []result.Result
+		for _, res := range results {
+			if gitdiff.Contains(changed, res.Range().Filename) {
+				changedResults = append(changedResults, res)
+			}
+		}
+		results = changedResults
+	}
+	return results, nil
+}
+
+var changedFilesLock sync.Mutex
+var changedFiles = make(map[string]map[string]struct{})
+
+// getChangedFiles lists the files changed since --base-ref, only asking git once for each directory, as streamed
+// results are selected a block at a time
+func getChangedFiles(dir string) (map[string]struct{}, error) {
+	changedFilesLock.Lock()
+	defer changedFilesLock.Unlock()
+	if changed, ok := changedFiles[dir]; ok {
+		return changed, nil
+	}
+	changed, err := gitdiff.ChangedFiles(dir, baseRef)
+	if err != nil {
+		return nil, err
+	}
+	changedFiles[dir] = changed
+	return changed, nil
+}
+
+// processResults turns the results of a scan into those which are reported, whether the scan finished or was stopped
+// by the hard deadline. On top of selectResults, it removes the results known to --baseline or --compare-to, applies
+// --fix and the minimum severity, and sorts the results and adds their code snippets. It returns the results to report
+// and those of them which fail the scan.
+func processResults(results []result.Result, dir string, threshold severity.Severity) ([]result.Result, []result.Result, error) {
+	results, err := selectResults(results, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if baselineFile != "" {
+		known, err := baseline.Load(baselineFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		results = known.Filter(results, dir)
+	}
+
+	if compareTo != "" {
+		previous, err := compare.LoadPrevious(compareTo)
+		if err != nil {
+			return nil, nil, err
+		}
+		var resolved []result.Result
+		results, resolved = compare.Diff(previous, results)
+		printResolvedResults(resolved)
+	}
+
+	if applyFixes {
+		fixed, remaining, err := fixer.Fix(results)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, _ = fmt.Fprintf(os.Stderr, "Fixed %d findings, %d remain\n", len(fixed), len(remaining)-countPassedResults(remaining))
+		results = remaining
+	}
+
+	failingResults := removeBelowSeverity(results, threshold)
+	if !showAll {
+		results = failingResults
+	}
+
+	if sortBy == "severity" {
+		result.SortBySeverity(results)
+	} else {
+		result.SortByLocation(results)
+	}
+
+	for i, result := range results {
+		results[i] = *result.WithCodeSnippet(codeLines)
+	}
+	return results, failingResults, nil
+}
+
+// countResults adds the results which are reported to the metrics. It is kept apart from processResults, as streamed
+// results are processed a block at a time and then again once the scan has finished.
+func countResults(results []result.Result) {
+	for _, result := range results {
+		metrics.AddResult(result.Severity)
+	}
+}
+
+// publishResults writes the --metrics-file and sends the results to --post-results once they have been reported
+func publishResults(results []result.Result) error {
+	if metricsFile != "" {
+		if err := writeMetricsFile(metricsFile, results); err != nil {
+			return err
+		}
+	}
+
+	if postResultsURL != "" {
+		// a failure to deliver results shouldn't change the outcome of the scan
+		if err := webhook.Post(postResultsURL, results, postHeaders, postTimeout); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "WARNING: %s\n", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scancache"
+	"github.com/aquasecurity/tfsec/version"
+)
+
+// useScanCache decides whether the results of this scan can be taken from, and stored in, the scan cache. Fixes need
+// the parsed blocks, and stdin and plan files aren't read from files which the cache can check.
+func useScanCache() bool {
+	return !noCache && !readStdin && planFile == "" && !applyFixes
+}
+
+// getScanCache returns the cache directory, and the key which identifies this scan by the tfsec build and everything
+// outside the scanned files which changes its results: the command line, the working directory, the config file, the
+// custom checks, TF_VAR_ variables and the date, as ignores can expire. The key is empty if the scan can't be
+// identified.
+func getScanCache() (string, string) {
+	dir, err := parser.CacheDir()
+	if err != nil {
+		return "", ""
+	}
+	build := version.Version
+	if build == "" {
+		// locally built binaries have no version, so they're told apart by their content
+		executable, err := os.Executable()
+		if err != nil {
+			return "", ""
+		}
+		hash, err := hashFile(executable)
+		if err != nil {
+			return "", ""
+		}
+		build = hash
+	}
+
+	workingDir, err := os.Getwd()
+	if err != nil {
+		return "", ""
+	}
+	parts := []string{build, workingDir, time.Now().Format("2006-01-02")}
+	parts = append(parts, os.Args[1:]...)
+
+	var env []string
+	for _, variable := range os.Environ() {
+		if strings.HasPrefix(variable, "TF_VAR_") {
+			env = append(env, variable)
+		}
+	}
+	sort.Strings(env)
+	parts = append(parts, env...)
+
+	checkFiles := []string{loadedConfigFile}
+	if infos, err := ioutil.ReadDir(customCheckDir); err == nil {
+		for _, info := range infos {
+			if !info.IsDir() {
+				checkFiles = append(checkFiles, filepath.Join(customCheckDir, info.Name()))
+			}
+		}
+	}
+	for _, path := range checkFiles {
+		if path == "" {
+			continue
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return "", ""
+		}
+		parts = append(parts, path, hash)
+	}
+	return dir, scancache.Key(parts...)
+}
+
+func hashFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// scanCacheInputs lists the files and directories which parsing read, which the cached results depend on
+func scanCacheInputs() scancache.Inputs {
+	files, dirs := parser.Inputs()
+	return scancache.Inputs{
+		Files: files,
+		Dirs:  dirs,
+	}
+}
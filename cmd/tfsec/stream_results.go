@@ -0,0 +1,80 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// canStreamResults decides whether json-lines results can be written as the scan finds them. --baseline, --compare-to
+// and --fix need all of the results before any are reported, and --list-ignored and --run-statistics don't write
+// results through the formatter.
+func canStreamResults() bool {
+	return strings.ToLower(format) == "json-lines" && baselineFile == "" && compareTo == "" && !applyFixes &&
+		!listIgnoredFindings && !runStatistics
+}
+
+// resultStreamer writes json-lines results as each block is checked, processed as they would be at the end of a scan
+type resultStreamer struct {
+	lock      sync.Mutex
+	writer    *formatters.JSONLinesWriter
+	dir       string
+	threshold severity.Severity
+	// the hash codes and deduplication keys of the results written so far
+	hashCodes map[string]bool
+	keys      map[string]bool
+	stopped   bool
+	err       error
+}
+
+func newResultStreamer(w io.Writer, dir string, threshold severity.Severity) *resultStreamer {
+	return &resultStreamer{
+		writer:    formatters.NewJSONLinesWriter(w, getFormatterOptions()...),
+		dir:       dir,
+		threshold: threshold,
+		hashCodes: make(map[string]bool),
+		keys:      make(map[string]bool),
+	}
+}
+
+// handle writes the results of a block. It is given to the scanner as its result handler.
+func (streamer *resultStreamer) handle(results []result.Result) {
+	streamer.lock.Lock()
+	defer streamer.lock.Unlock()
+	if streamer.stopped || streamer.err != nil {
+		return
+	}
+	if !noDedup {
+		results = result.Deduplicate(results)
+	}
+	results, _, err := processResults(results, streamer.dir, streamer.threshold)
+	if err != nil {
+		streamer.err = err
+		return
+	}
+	for _, res := range results {
+		// results which the end of the scan would merge with one already written are left out
+		hashCode, key := res.HashCode(), res.DeduplicationKey()
+		if streamer.hashCodes[hashCode] || (!noDedup && streamer.keys[key]) {
+			continue
+		}
+		streamer.hashCodes[hashCode] = true
+		streamer.keys[key] = true
+		if err := streamer.writer.Write(res); err != nil {
+			streamer.err = err
+			return
+		}
+	}
+}
+
+// stop makes sure nothing more is written, returning the first error met while streaming
+func (streamer *resultStreamer) stop() error {
+	streamer.lock.Lock()
+	defer streamer.lock.Unlock()
+	streamer.stopped = true
+	return streamer.err
+}
@@ -0,0 +1,121 @@
+package baseline
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+const baselineVersion = 1
+
+// Baseline records known findings so that only new findings are reported
+type Baseline struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+}
+
+// Entry identifies a finding. Line numbers are recorded but only used to match findings which aren't raised against a
+// named resource, so unrelated edits which move a resource around the file don't cause its findings to reappear.
+type Entry struct {
+	RuleID    string `json:"rule_id"`
+	Resource  string `json:"resource,omitempty"`
+	Filename  string `json:"filename"`
+	StartLine int    `json:"start_line"`
+}
+
+// Generate creates a baseline of the failed results, with filenames relative to baseDir
+func Generate(results []result.Result, baseDir string) *Baseline {
+	baseline := &Baseline{
+		Version:  baselineVersion,
+		Findings: []Entry{},
+	}
+	for _, res := range results {
+		if res.Status == result.Passed {
+			continue
+		}
+		baseline.Findings = append(baseline.Findings, newEntry(res, baseDir))
+	}
+	sort.Slice(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.StartLine != b.StartLine {
+			return a.StartLine < b.StartLine
+		}
+		return a.RuleID < b.RuleID
+	})
+	return baseline
+}
+
+func newEntry(res result.Result, baseDir string) Entry {
+	rng := res.Range()
+	filename := rng.Filename
+	if relative, err := filepath.Rel(baseDir, filename); err == nil {
+		filename = relative
+	}
+	return Entry{
+		RuleID:    res.RuleID,
+		Resource:  res.ResourceName(),
+		Filename:  filepath.ToSlash(filename),
+		StartLine: rng.StartLine,
+	}
+}
+
+func (e Entry) key() string {
+	if e.Resource != "" {
+		return fmt.Sprintf("%s|%s|%s", e.RuleID, e.Filename, e.Resource)
+	}
+	return fmt.Sprintf("%s|%s|%d", e.RuleID, e.Filename, e.StartLine)
+}
+
+// Load reads a baseline previously written by Save
+func Load(path string) (*Baseline, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file '%s': %w", path, err)
+	}
+	var baseline Baseline
+	if err := json.Unmarshal(data, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file '%s': %w", path, err)
+	}
+	if baseline.Version != baselineVersion {
+		return nil, fmt.Errorf("baseline file '%s' has unsupported version %d", path, baseline.Version)
+	}
+	return &baseline, nil
+}
+
+// Save writes the baseline to the given path
+func (b *Baseline) Save(path string) error {
+	data, err := json.MarshalIndent(b, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0600)
+}
+
+// Filter removes the results which are present in the baseline. Each baseline entry suppresses at most one result,
+// so a resource which gains an extra finding for the same rule is still reported.
+func (b *Baseline) Filter(results []result.Result, baseDir string) []result.Result {
+	known := make(map[string]int)
+	for _, entry := range b.Findings {
+		known[entry.key()]++
+	}
+
+	var filtered []result.Result
+	for _, res := range results {
+		if res.Status != result.Passed {
+			key := newEntry(res, baseDir).key()
+			if known[key] > 0 {
+				known[key]--
+				continue
+			}
+		}
+		filtered = append(filtered, res)
+	}
+	return filtered
+}
@@ -0,0 +1,61 @@
+package baseline_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/baseline"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BaselineSuppressesKnownFindingsAfterLineDrift(t *testing.T) {
+	original := testutil.ScanHCL(`
+resource "aws_security_group_rule" "known" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, original)
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, baseline.Generate(original, resultDir(original)).Save(path))
+
+	known, err := baseline.Load(path)
+	require.NoError(t, err)
+
+	changed := testutil.ScanHCL(`
+resource "aws_security_group_rule" "added" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "known" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+
+	remaining := known.Filter(changed, resultDir(changed))
+	require.NotEmpty(t, remaining)
+	for _, res := range remaining {
+		assert.Equal(t, "aws_security_group_rule.added", res.ResourceName())
+	}
+	assert.Len(t, remaining, len(changed)-len(original))
+}
+
+func Test_BaselineRejectsUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`{"version": 99, "findings": []}`), 0600))
+
+	_, err := baseline.Load(path)
+	assert.Error(t, err)
+}
+
+func resultDir(results []result.Result) string {
+	return filepath.Dir(results[0].Range().Filename)
+}
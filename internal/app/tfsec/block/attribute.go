@@ -11,6 +11,7 @@ type Attribute interface {
 	Range() Range
 	Name() string
 	Contains(checkValue interface{}, equalityOptions ...EqualityOption) bool
+	ContainsIgnoreCase(checkValue interface{}) bool
 	NotContains(checkValue interface{}, equalityOptions ...EqualityOption) bool
 	HasIntersect(checkValues ...interface{}) bool
 	StartsWith(prefix interface{}) bool
@@ -18,16 +19,22 @@ type Attribute interface {
 	Equals(checkValue interface{}, equalityOptions ...EqualityOption) bool
 	NotEqual(checkValue interface{}, equalityOptions ...EqualityOption) bool
 	RegexMatches(pattern interface{}) bool
+	RegexMatchesAny(patterns []string) bool
 	IsAny(options ...interface{}) bool
 	IsNotAny(options ...interface{}) bool
 	IsNone(options ...interface{}) bool
+	IsOneOf(values ...string) bool
+	IsOneOfIgnoreCase(values ...string) bool
+	IsNoneOf(values ...string) bool
+	IsNoneOfIgnoreCase(values ...string) bool
 	IsTrue() bool
 	IsFalse() bool
 	IsEmpty() bool
 	IsNotEmpty() bool
 	IsNil() bool
 	IsNotNil() bool
-	MapValue(mapKey string) cty.Value
+	MapValue() map[string]cty.Value
+	GetMapValue(mapKey string) (cty.Value, bool)
 	LessThan(checkValue interface{}) bool
 	LessThanOrEqualTo(checkValue interface{}) bool
 	GreaterThan(checkValue interface{}) bool
@@ -35,13 +42,19 @@ type Attribute interface {
 	IsDataBlockReference() bool
 	Reference() (*Reference, error)
 	AllReferences() []*Reference
+	TraversalsByKey() map[string]string
 	IsResourceBlockReference(resourceType string) bool
 	ReferencesBlock(b Block) bool
 	IsResolvable() bool
 	IsNotResolvable() bool
+	IsUnknownOfType(t cty.Type) bool
+	AsTypeConstraint() (cty.Type, bool)
 	IsString() bool
 	IsNumber() bool
 	IsBool() bool
+	AsStringValue() (string, bool)
+	AsIntValue() (int, bool)
+	AsBoolValue() (bool, bool)
 	ValueAsStrings() []string
 	IsIterable() bool
 	Each(f func(key cty.Value, val cty.Value))
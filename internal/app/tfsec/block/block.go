@@ -10,11 +10,15 @@ type Block interface {
 	HasModuleBlock() bool
 	GetModuleBlock() (Block, error)
 	Type() string
+	// Labels returns every label of the block in order, such as ["aws_s3_bucket", "my-bucket"] for a resource.
 	Labels() []string
 	Range() Range
-	GetFirstMatchingBlock(names ...string) Block
+	GetFirstBlockOfTypes(names ...string) Block
+	GetFirstMatchingBlock(blockType string, predicate func(Block) bool) (Block, bool)
 	GetBlock(name string) Block
 	AllBlocks() Blocks
+	AllBlocksRecursive() Blocks
+	GetBlocksByTypeRecursive(blockType string) Blocks
 	GetBlocks(name string) Blocks
 	GetAttributes() []Attribute
 	GetAttribute(name string) Attribute
@@ -23,7 +27,12 @@ type Block interface {
 	LocalName() string
 	FullName() string
 	UniqueName() string
+	// TypeLabel returns the first label. For resource and data blocks this is the type, such as aws_eks_cluster; for
+	// module, variable, output and provider blocks, which have a single label, it is the name. It is empty for blocks
+	// without labels, such as locals and nested blocks.
 	TypeLabel() string
+	// NameLabel returns the second label, which is the name of a resource or data block. It is empty for blocks with
+	// fewer than two labels, so the name of a module block is its TypeLabel.
 	NameLabel() string
 	Clone(index cty.Value) Block
 	IsCountExpanded() bool
@@ -41,4 +50,10 @@ type Block interface {
 	IsNil() bool
 	IsNotNil() bool
 	InjectBlock(block Block, name string)
+	// HasUnknownCondition returns true if an attribute of the block, or of a block nested in it, is a conditional
+	// expression whose condition can't be resolved.
+	HasUnknownCondition() bool
+	// WithConditionalBranch returns a copy of the block in which conditional expressions whose condition can't be
+	// resolved take their true or false result, so both outcomes can be checked.
+	WithConditionalBranch(result bool) Block
 }
@@ -1,6 +1,7 @@
 package block
 
 import (
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -8,8 +9,11 @@ import (
 )
 
 type Context struct {
-	ctx    *hcl.EvalContext
-	parent *Context
+	ctx               *hcl.EvalContext
+	parent            *Context
+	defaultTags       map[string]cty.Value
+	providers         map[string]Block
+	requiredProviders map[string]RequiredProvider
 }
 
 func NewContext(ctx *hcl.EvalContext, parent *Context) *Context {
@@ -45,6 +49,83 @@ func (c *Context) Root() *Context {
 	return root
 }
 
+// SetDefaultTags records the default_tags of a provider configuration, which is referred to as e.g. aws or aws.east
+func (c *Context) SetDefaultTags(providerRef string, tags cty.Value) {
+	if c.defaultTags == nil {
+		c.defaultTags = make(map[string]cty.Value)
+	}
+	c.defaultTags[providerRef] = tags
+}
+
+// DefaultTags returns the default_tags of a provider configuration, looking through parent contexts if they aren't
+// set on this one
+func (c *Context) DefaultTags(providerRef string) (cty.Value, bool) {
+	for current := c; current != nil; current = current.parent {
+		if tags, ok := current.defaultTags[providerRef]; ok {
+			return tags, true
+		}
+	}
+	return cty.NilVal, false
+}
+
+// DefaultTagProviders lists the provider configurations which have default_tags recorded on this context
+func (c *Context) DefaultTagProviders() []string {
+	var providerRefs []string
+	for providerRef := range c.defaultTags {
+		providerRefs = append(providerRefs, providerRef)
+	}
+	sort.Strings(providerRefs)
+	return providerRefs
+}
+
+// SetProvider records the provider block which configures a provider, which is referred to as e.g. aws or aws.east
+func (c *Context) SetProvider(providerRef string, providerBlock Block) {
+	if c.providers == nil {
+		c.providers = make(map[string]Block)
+	}
+	c.providers[providerRef] = providerBlock
+}
+
+// Provider returns the provider block for a provider configuration, looking through parent contexts if it isn't set
+// on this one
+func (c *Context) Provider(providerRef string) (Block, bool) {
+	for current := c; current != nil; current = current.parent {
+		if providerBlock, ok := current.providers[providerRef]; ok {
+			return providerBlock, true
+		}
+	}
+	return nil, false
+}
+
+// Providers lists the provider configurations which are recorded on this context
+func (c *Context) Providers() []string {
+	var providerRefs []string
+	for providerRef := range c.providers {
+		providerRefs = append(providerRefs, providerRef)
+	}
+	sort.Strings(providerRefs)
+	return providerRefs
+}
+
+// SetRequiredProvider records an entry of the required_providers of a terraform block, keyed by its local name
+func (c *Context) SetRequiredProvider(localName string, requiredProvider RequiredProvider) {
+	if c.requiredProviders == nil {
+		c.requiredProviders = make(map[string]RequiredProvider)
+	}
+	c.requiredProviders[localName] = requiredProvider
+}
+
+// RequiredProvider returns the required_providers entry for a local provider name, looking through parent contexts
+// if it isn't set on this one
+func (c *Context) RequiredProvider(localName string) (RequiredProvider, bool) {
+	for current := c; current != nil; current = current.parent {
+		if requiredProvider, ok := current.requiredProviders[localName]; ok {
+			return requiredProvider, true
+		}
+	}
+	return RequiredProvider{}, false
+}
+
 func (c *Context) Get(parts ...string) cty.Value {
 	if len(parts) == 0 {
 		return cty.NilVal
@@ -2,11 +2,11 @@ package block
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
@@ -15,8 +15,18 @@ import (
 type HCLAttribute struct {
 	hclAttribute *hcl.Attribute
 	ctx          *Context
+	branch       conditionalBranch
 }
 
+// conditionalBranch chooses the result of conditional expressions whose condition can't be resolved
+type conditionalBranch int
+
+const (
+	unknownBranch conditionalBranch = iota
+	trueBranch
+	falseBranch
+)
+
 func NewHCLAttribute(attr *hcl.Attribute, ctx *Context) *HCLAttribute {
 	return &HCLAttribute{
 		hclAttribute: attr,
@@ -42,6 +52,33 @@ func (attr *HCLAttribute) IsNotResolvable() bool {
 	return !attr.IsResolvable()
 }
 
+// IsUnknownOfType returns true if the value can't be resolved but is known to be of the given type, e.g. because it
+// comes from a variable which declares its type but has no default
+func (attr *HCLAttribute) IsUnknownOfType(t cty.Type) (unknown bool) {
+	if attr == nil {
+		return false
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			unknown = false
+		}
+	}()
+	val := attr.evaluate(attr.hclAttribute.Expr)
+	return !val.IsKnown() && val.Type().Equals(t)
+}
+
+// AsTypeConstraint reads the attribute as a type constraint, such as the type of a variable
+func (attr *HCLAttribute) AsTypeConstraint() (cty.Type, bool) {
+	if attr == nil {
+		return cty.NilType, false
+	}
+	t, diags := typeexpr.TypeConstraint(attr.hclAttribute.Expr)
+	if diags.HasErrors() {
+		return cty.NilType, false
+	}
+	return t, true
+}
+
 func (attr *HCLAttribute) Type() cty.Type {
 	if attr == nil {
 		return cty.NilType
@@ -88,6 +125,31 @@ func (attr *HCLAttribute) IsBool() bool {
 	return !attr.Value().IsNull() && attr.Value().IsKnown() && attr.Value().Type() == cty.Bool
 }
 
+func (attr *HCLAttribute) AsStringValue() (string, bool) {
+	if !attr.IsString() {
+		return "", false
+	}
+	return attr.Value().AsString(), true
+}
+
+func (attr *HCLAttribute) AsIntValue() (int, bool) {
+	if !attr.IsNumber() {
+		return 0, false
+	}
+	var intVal int
+	if err := gocty.FromCtyValue(attr.Value(), &intVal); err != nil {
+		return 0, false
+	}
+	return intVal, true
+}
+
+func (attr *HCLAttribute) AsBoolValue() (bool, bool) {
+	if !attr.IsBool() {
+		return false, false
+	}
+	return attr.Value().True(), true
+}
+
 func (attr *HCLAttribute) Value() (ctyVal cty.Value) {
 	if attr == nil {
 		return cty.NilVal
@@ -97,13 +159,63 @@ func (attr *HCLAttribute) Value() (ctyVal cty.Value) {
 			ctyVal = cty.NilVal
 		}
 	}()
-	ctyVal, _ = attr.hclAttribute.Expr.Value(attr.ctx.Inner())
+	ctyVal = attr.evaluate(attr.hclAttribute.Expr)
 	if !ctyVal.IsKnown() {
 		return cty.NilVal
 	}
 	return ctyVal
 }
 
+// evaluate works out the value of an expression. A conditional expression whose condition can be resolved takes the
+// value of the branch it selects; if the condition can't be resolved, the value is unknown unless the attribute
+// belongs to a block returned by WithConditionalBranch.
+func (attr *HCLAttribute) evaluate(expr hcl.Expression) cty.Value {
+	val, _ := expr.Value(attr.ctx.Inner())
+	if val.IsKnown() || attr.branch == unknownBranch {
+		return val
+	}
+	if parens, ok := expr.(*hclsyntax.ParenthesesExpr); ok {
+		return attr.evaluate(parens.Expression)
+	}
+	conditional, ok := expr.(*hclsyntax.ConditionalExpr)
+	if !ok {
+		return val
+	}
+	if condition, _ := conditional.Condition.Value(attr.ctx.Inner()); condition.IsKnown() {
+		return val
+	}
+	if attr.branch == trueBranch {
+		return attr.evaluate(conditional.TrueResult)
+	}
+	return attr.evaluate(conditional.FalseResult)
+}
+
+// hasUnknownCondition returns true if the attribute is a conditional expression whose condition can't be resolved
+func (attr *HCLAttribute) hasUnknownCondition() (unknown bool) {
+	if attr == nil {
+		return false
+	}
+	defer func() {
+		if err := recover(); err != nil {
+			unknown = false
+		}
+	}()
+	expr := attr.hclAttribute.Expr
+	for {
+		parens, ok := expr.(*hclsyntax.ParenthesesExpr)
+		if !ok {
+			break
+		}
+		expr = parens.Expression
+	}
+	conditional, ok := expr.(*hclsyntax.ConditionalExpr)
+	if !ok {
+		return false
+	}
+	condition, _ := conditional.Condition.Value(attr.ctx.Inner())
+	return !condition.IsKnown()
+}
+
 func (attr *HCLAttribute) Range() Range {
 	if attr == nil {
 		return Range{}
@@ -170,7 +282,7 @@ func (attr *HCLAttribute) listContains(val cty.Value, stringToLookFor string, ig
 			// References without a value can't logically "contain" a some string to check against.
 			return false
 		}
-		if !value.IsKnown() {
+		if !stringToTest.IsKnown() || stringToTest.IsNull() || stringToTest.Type() != cty.String {
 			continue
 		}
 		if ignoreCase && strings.EqualFold(stringToTest.AsString(), stringToLookFor) {
@@ -234,7 +346,7 @@ func (attr *HCLAttribute) Contains(checkValue interface{}, equalityOptions ...Eq
 		}
 	}
 	val := attr.Value()
-	if val.IsNull() {
+	if val.IsNull() || !val.IsKnown() {
 		return false
 	}
 
@@ -244,10 +356,14 @@ func (attr *HCLAttribute) Contains(checkValue interface{}, equalityOptions ...Eq
 
 	stringToLookFor := fmt.Sprintf("%v", checkValue)
 
-	if val.Type().IsListType() || val.Type().IsTupleType() {
+	if val.Type().IsListType() || val.Type().IsTupleType() || val.Type().IsSetType() {
 		return attr.listContains(val, stringToLookFor, ignoreCase)
 	}
 
+	if val.Type() != cty.String {
+		return false
+	}
+
 	if ignoreCase && containsIgnoreCase(val.AsString(), stringToLookFor) {
 		return true
 	}
@@ -255,6 +371,10 @@ func (attr *HCLAttribute) Contains(checkValue interface{}, equalityOptions ...Eq
 	return strings.Contains(val.AsString(), stringToLookFor)
 }
 
+func (attr *HCLAttribute) ContainsIgnoreCase(checkValue interface{}) bool {
+	return attr.Contains(checkValue, IgnoreCase)
+}
+
 func containsIgnoreCase(left, substring string) bool {
 	return strings.Contains(strings.ToLower(left), strings.ToLower(substring))
 }
@@ -321,19 +441,27 @@ func (attr *HCLAttribute) RegexMatches(pattern interface{}) bool {
 	if attr == nil {
 		return false
 	}
-	patternVal := fmt.Sprintf("%v", pattern)
-	re, err := regexp.Compile(patternVal)
+	re, err := CompileRegex(fmt.Sprintf("%v", pattern))
 	if err != nil {
 		debug.Log("an error occurred while compiling the regex: %s", err)
 		return false
 	}
-	if attr.Value().Type() == cty.String {
+	if attr.IsString() {
 		match := re.MatchString(attr.Value().AsString())
 		return match
 	}
 	return false
 }
 
+func (attr *HCLAttribute) RegexMatchesAny(patterns []string) bool {
+	for _, pattern := range patterns {
+		if attr.RegexMatches(pattern) {
+			return true
+		}
+	}
+	return false
+}
+
 func (attr *HCLAttribute) IsNotAny(options ...interface{}) bool {
 	return !attr.IsAny(options...)
 }
@@ -375,6 +503,41 @@ func (attr *HCLAttribute) IsAny(options ...interface{}) bool {
 	return false
 }
 
+// IsOneOf returns true if the attribute is a string matching one of the allowed values. Attributes which can't be
+// resolved to a string are never one of the values.
+func (attr *HCLAttribute) IsOneOf(values ...string) bool {
+	value, ok := attr.AsStringValue()
+	return ok && matchesOneOf(value, values, false)
+}
+
+// IsOneOfIgnoreCase is IsOneOf with values compared case-insensitively
+func (attr *HCLAttribute) IsOneOfIgnoreCase(values ...string) bool {
+	value, ok := attr.AsStringValue()
+	return ok && matchesOneOf(value, values, true)
+}
+
+// IsNoneOf returns true if the attribute is a string matching none of the disallowed values. Unlike IsNone, it is
+// false for attributes which can't be resolved to a string, as their value could be any of them.
+func (attr *HCLAttribute) IsNoneOf(values ...string) bool {
+	value, ok := attr.AsStringValue()
+	return ok && !matchesOneOf(value, values, false)
+}
+
+// IsNoneOfIgnoreCase is IsNoneOf with values compared case-insensitively
+func (attr *HCLAttribute) IsNoneOfIgnoreCase(values ...string) bool {
+	value, ok := attr.AsStringValue()
+	return ok && !matchesOneOf(value, values, true)
+}
+
+func matchesOneOf(value string, values []string, ignoreCase bool) bool {
+	for _, candidate := range values {
+		if candidate == value || (ignoreCase && strings.EqualFold(candidate, value)) {
+			return true
+		}
+	}
+	return false
+}
+
 func (attr *HCLAttribute) IsNone(options ...interface{}) bool {
 	if attr == nil {
 		return false
@@ -437,134 +600,90 @@ func (attr *HCLAttribute) IsFalse() bool {
 	return false
 }
 
+// IsEmpty returns true if the attribute is null, or an empty string, list or map. An attribute whose value can't be
+// resolved is not considered empty, as there's no way to know what its value will be.
 func (attr *HCLAttribute) IsEmpty() bool {
 	if attr == nil {
 		return false
 	}
-	if attr.Value().Type() == cty.String {
-		return len(attr.Value().AsString()) == 0
-	}
-	if attr.Type().IsListType() || attr.Type().IsTupleType() {
-		return len(attr.Value().AsValueSlice()) == 0
-	}
-	if attr.Type().IsMapType() || attr.Type().IsObjectType() {
-		return len(attr.Value().AsValueMap()) == 0
-	}
-	if attr.Value().Type() == cty.Number {
-		// a number can't ever be empty
+	val := attr.Value()
+	if val == cty.NilVal {
 		return false
 	}
-	if attr.Value().IsNull() {
-		return attr.isNullAttributeEmpty()
+	if val.IsNull() {
+		return true
 	}
-	return true
+	switch {
+	case val.Type() == cty.String:
+		return len(val.AsString()) == 0
+	case val.Type().IsListType(), val.Type().IsTupleType(), val.Type().IsSetType(),
+		val.Type().IsMapType(), val.Type().IsObjectType():
+		return val.LengthInt() == 0
+	}
+	return false
 }
 
 func (attr *HCLAttribute) IsNotEmpty() bool {
 	return !attr.IsEmpty()
 }
 
-func (attr *HCLAttribute) isNullAttributeEmpty() bool {
+func (attr *HCLAttribute) MapValue() map[string]cty.Value {
 	if attr == nil {
-		return false
+		return map[string]cty.Value{}
 	}
-	switch t := attr.hclAttribute.Expr.(type) {
-	case *hclsyntax.FunctionCallExpr, *hclsyntax.ScopeTraversalExpr,
-		*hclsyntax.ConditionalExpr, *hclsyntax.LiteralValueExpr:
-		return false
-	case *hclsyntax.TemplateExpr:
-		// walk the parts of the expression to ensure that it has a literal value
-		for _, p := range t.Parts {
-			switch pt := p.(type) {
-			case *hclsyntax.LiteralValueExpr:
-				if pt != nil && !pt.Val.IsNull() {
-					return false
-				}
-			case *hclsyntax.ScopeTraversalExpr:
-				return false
-			}
-		}
+	val := attr.Value()
+	if val.IsNull() || !val.IsKnown() || !(val.Type().IsObjectType() || val.Type().IsMapType()) {
+		return map[string]cty.Value{}
 	}
-	return true
+	attrMap := val.AsValueMap()
+	if attrMap == nil {
+		return map[string]cty.Value{}
+	}
+	return attrMap
 }
 
-func (attr *HCLAttribute) MapValue(mapKey string) cty.Value {
-	if attr == nil {
-		return cty.NilVal
+func (attr *HCLAttribute) GetMapValue(mapKey string) (cty.Value, bool) {
+	value, ok := attr.MapValue()[mapKey]
+	if !ok {
+		return cty.NilVal, false
 	}
-	if attr.Type().IsObjectType() || attr.Type().IsMapType() {
-		attrMap := attr.Value().AsValueMap()
-		for key, value := range attrMap {
-			if key == mapKey {
-				return value
-			}
-		}
-	}
-	return cty.NilVal
+	return value, true
 }
 
+// LessThan returns true if the attribute is a number less than checkValue, which can be any integer or float type.
+// Like the other numeric comparisons, it is false for attributes which are null, unknown or not numbers.
 func (attr *HCLAttribute) LessThan(checkValue interface{}) bool {
-	if attr == nil {
-		return false
-	}
-	if attr.Value().Type() == cty.Number {
-		checkNumber, err := gocty.ToCtyValue(checkValue, cty.Number)
-		if err != nil {
-			debug.Log("Error converting number for equality check. %s", err)
-			return false
-		}
-
-		return attr.Value().LessThan(checkNumber).True()
-	}
-	return false
+	cmp, ok := attr.compareNumber(checkValue)
+	return ok && cmp < 0
 }
 
 func (attr *HCLAttribute) LessThanOrEqualTo(checkValue interface{}) bool {
-	if attr == nil {
-		return false
-	}
-	if attr.Value().Type() == cty.Number {
-		checkNumber, err := gocty.ToCtyValue(checkValue, cty.Number)
-		if err != nil {
-			debug.Log("Error converting number for equality check. %s", err)
-			return false
-		}
-
-		return attr.Value().LessThanOrEqualTo(checkNumber).True()
-	}
-	return false
+	cmp, ok := attr.compareNumber(checkValue)
+	return ok && cmp <= 0
 }
 
 func (attr *HCLAttribute) GreaterThan(checkValue interface{}) bool {
-	if attr == nil {
-		return false
-	}
-	if attr.Value().Type() == cty.Number {
-		checkNumber, err := gocty.ToCtyValue(checkValue, cty.Number)
-		if err != nil {
-			debug.Log("Error converting number for equality check. %s", err)
-			return false
-		}
-
-		return attr.Value().GreaterThan(checkNumber).True()
-	}
-	return false
+	cmp, ok := attr.compareNumber(checkValue)
+	return ok && cmp > 0
 }
 
 func (attr *HCLAttribute) GreaterThanOrEqualTo(checkValue interface{}) bool {
-	if attr == nil {
-		return false
-	}
-	if attr.Value().Type() == cty.Number {
-		checkNumber, err := gocty.ToCtyValue(checkValue, cty.Number)
-		if err != nil {
-			debug.Log("Error converting number for equality check. %s", err)
-			return false
-		}
+	cmp, ok := attr.compareNumber(checkValue)
+	return ok && cmp >= 0
+}
 
-		return attr.Value().GreaterThanOrEqualTo(checkNumber).True()
+// compareNumber compares a resolved numeric attribute with checkValue, returning -1, 0 or +1 as the attribute is less
+// than, equal to or greater than it. ok is false if either can't be compared as a number.
+func (attr *HCLAttribute) compareNumber(checkValue interface{}) (cmp int, ok bool) {
+	if !attr.IsNumber() {
+		return 0, false
 	}
-	return false
+	checkNumber, err := gocty.ToCtyValue(checkValue, cty.Number)
+	if err != nil {
+		debug.Log("Error converting number for comparison. %s", err)
+		return 0, false
+	}
+	return attr.Value().AsBigFloat().Cmp(checkNumber.AsBigFloat()), true
 }
 
 func (attr *HCLAttribute) IsDataBlockReference() bool {
@@ -640,6 +759,12 @@ func (attr *HCLAttribute) Reference() (*Reference, error) {
 		}
 		return refs[0], nil
 	default:
+		// expressions in JSON syntax don't expose their structure, but do list the traversals they contain
+		if _, isNative := t.(hclsyntax.Expression); !isNative {
+			if traversals := t.Variables(); len(traversals) > 0 {
+				return createDotReferenceFromTraversal(traversals[0])
+			}
+		}
 		return nil, fmt.Errorf("not a reference: no scope traversal")
 	}
 }
@@ -651,6 +776,8 @@ func (attr *HCLAttribute) AllReferences() []*Reference {
 	var refs []*Reference
 	refs = append(refs, attr.referencesInTemplate()...)
 	refs = append(refs, attr.referencesInConditional()...)
+	refs = append(refs, attr.referencesInList()...)
+	refs = append(refs, attr.referencesInJSON()...)
 	ref, err := attr.Reference()
 	if err == nil {
 		refs = append(refs, ref)
@@ -658,6 +785,60 @@ func (attr *HCLAttribute) AllReferences() []*Reference {
 	return refs
 }
 
+// TraversalsByKey returns the traversals which an object of traversals maps its keys to, such as the providers of a
+// module block, e.g. { aws = aws.west } gives aws: aws.west. Entries which aren't traversals are left out.
+func (attr *HCLAttribute) TraversalsByKey() map[string]string {
+	if attr == nil {
+		return nil
+	}
+	pairs, diags := hcl.ExprMap(attr.hclAttribute.Expr)
+	if diags.HasErrors() {
+		return nil
+	}
+	traversals := make(map[string]string)
+	for _, pair := range pairs {
+		key, keyDiags := hcl.AbsTraversalForExpr(pair.Key)
+		value, valueDiags := hcl.AbsTraversalForExpr(pair.Value)
+		if keyDiags.HasErrors() || valueDiags.HasErrors() {
+			continue
+		}
+		traversals[traversalString(key)] = traversalString(value)
+	}
+	return traversals
+}
+
+func traversalString(traversal hcl.Traversal) string {
+	var parts []string
+	for _, step := range traversal {
+		switch part := step.(type) {
+		case hcl.TraverseRoot:
+			parts = append(parts, part.Name)
+		case hcl.TraverseAttr:
+			parts = append(parts, part.Name)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// referencesInJSON returns every reference in an expression in JSON syntax, other than the first which is returned by
+// Reference
+func (attr *HCLAttribute) referencesInJSON() []*Reference {
+	if attr == nil {
+		return nil
+	}
+	if _, isNative := attr.hclAttribute.Expr.(hclsyntax.Expression); isNative {
+		return nil
+	}
+	var refs []*Reference
+	traversals := attr.hclAttribute.Expr.Variables()
+	for i := 1; i < len(traversals); i++ {
+		if ref, err := createDotReferenceFromTraversal(traversals[i]); err == nil {
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
 func (attr *HCLAttribute) referencesInTemplate() []*Reference {
 	if attr == nil {
 		return nil
@@ -696,6 +877,24 @@ func (attr *HCLAttribute) referencesInConditional() []*Reference {
 	return refs
 }
 
+func (attr *HCLAttribute) referencesInList() []*Reference {
+	if attr == nil {
+		return nil
+	}
+	var refs []*Reference
+	switch t := attr.hclAttribute.Expr.(type) {
+	case *hclsyntax.TupleConsExpr:
+		for _, expr := range t.Exprs {
+			ref, err := createDotReferenceFromTraversal(expr.Variables()...)
+			if err != nil {
+				continue
+			}
+			refs = append(refs, ref)
+		}
+	}
+	return refs
+}
+
 func (attr *HCLAttribute) IsResourceBlockReference(resourceType string) bool {
 	if attr == nil {
 		return false
@@ -2,6 +2,7 @@ package block
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
@@ -14,13 +15,13 @@ import (
 )
 
 type HCLBlock struct {
-	hclBlock         *hcl.Block
-	context          *Context
-	moduleBlock      Block
-	expanded         bool
-	cloneIndex       int
-	childBlocks      []Block
-	cachedAttributes []Attribute
+	hclBlock    *hcl.Block
+	context     *Context
+	moduleBlock Block
+	expanded    bool
+	cloneIndex  int
+	childBlocks []Block
+	branch      conditionalBranch
 }
 
 func NewHCLBlock(hclBlock *hcl.Block, ctx *Context, moduleBlock Block) Block {
@@ -35,11 +36,19 @@ func NewHCLBlock(hclBlock *hcl.Block, ctx *Context, moduleBlock Block) Block {
 			children = append(children, NewHCLBlock(b.AsHCLBlock(), ctx, moduleBlock))
 		}
 	default:
-		content, _, diag := hclBlock.Body.PartialContent(schema.TerraformSchema_0_12)
+		content, remain, diag := hclBlock.Body.PartialContent(schema.TerraformSchema_0_12)
 		if diag == nil {
 			for _, hb := range content.Blocks {
 				children = append(children, NewHCLBlock(hb, ctx, moduleBlock))
 			}
+			// values in these blocks are expressions, never nested blocks, even if they are objects
+			switch hclBlock.Type {
+			case "locals", "module", "variable", "output":
+			default:
+				for _, hb := range jsonNestedBlocks(remain) {
+					children = append(children, NewHCLBlock(hb, ctx, moduleBlock))
+				}
+			}
 		}
 	}
 	return &HCLBlock{
@@ -50,6 +59,57 @@ func NewHCLBlock(hclBlock *hcl.Block, ctx *Context, moduleBlock Block) Block {
 	}
 }
 
+// jsonNestedBlocks decodes the object properties of a JSON body as nested blocks. Without the provider schema there's
+// no way to tell a nested block from an object attribute in JSON syntax, so such properties remain available as
+// attributes too.
+func jsonNestedBlocks(body hcl.Body) []*hcl.Block {
+	attrs, diag := body.JustAttributes()
+	if diag.HasErrors() {
+		return nil
+	}
+
+	var names []string
+	for name, attr := range attrs {
+		if isObjectExpression(attr.Expr) {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	var blockSchema hcl.BodySchema
+	for _, name := range names {
+		blockSchema.Blocks = append(blockSchema.Blocks, hcl.BlockHeaderSchema{Type: name})
+	}
+	content, _, diag := body.PartialContent(&blockSchema)
+	if diag.HasErrors() {
+		return nil
+	}
+	return content.Blocks
+}
+
+// isObjectExpression returns true if the expression is an object, or a list made up only of objects
+func isObjectExpression(expr hcl.Expression) bool {
+	val, _ := expr.Value(nil)
+	if val == cty.NilVal {
+		return false
+	}
+	if val.Type().IsObjectType() {
+		return true
+	}
+	if !val.Type().IsTupleType() || val.LengthInt() == 0 {
+		return false
+	}
+	for _, elementType := range val.Type().TupleElementTypes() {
+		if !elementType.IsObjectType() {
+			return false
+		}
+	}
+	return true
+}
+
 func (b *HCLBlock) InjectBlock(block Block, name string) {
 	block.(*HCLBlock).hclBlock.Labels = []string{}
 	block.(*HCLBlock).hclBlock.Type = name
@@ -137,6 +197,9 @@ func (b *HCLBlock) Type() string {
 }
 
 func (b *HCLBlock) Labels() []string {
+	if b == nil || b.hclBlock == nil {
+		return nil
+	}
 	return b.hclBlock.Labels
 }
 
@@ -159,7 +222,8 @@ func (b *HCLBlock) Range() Range {
 	}
 }
 
-func (b *HCLBlock) GetFirstMatchingBlock(names ...string) Block {
+// GetFirstBlockOfTypes returns the first child block of any of the given types, checking the types in order
+func (b *HCLBlock) GetFirstBlockOfTypes(names ...string) Block {
 	var returnBlock *HCLBlock
 	for _, name := range names {
 		childBlock := b.GetBlock(name)
@@ -170,6 +234,17 @@ func (b *HCLBlock) GetFirstMatchingBlock(names ...string) Block {
 	return returnBlock
 }
 
+// GetFirstMatchingBlock returns the first child block of the given type for which the predicate is true
+func (b *HCLBlock) GetFirstMatchingBlock(blockType string, predicate func(Block) bool) (Block, bool) {
+	for _, child := range b.GetBlocks(blockType) {
+		if predicate(child) {
+			return child, true
+		}
+	}
+	var returnBlock *HCLBlock
+	return returnBlock, false
+}
+
 func (b *HCLBlock) getHCLAttributes() hcl.Attributes {
 	switch body := b.hclBlock.Body.(type) {
 	case *hclsyntax.Body:
@@ -211,6 +286,38 @@ func (b *HCLBlock) AllBlocks() Blocks {
 	return b.childBlocks
 }
 
+func (b *HCLBlock) AllBlocksRecursive() Blocks {
+	if b == nil || b.hclBlock == nil {
+		return nil
+	}
+	return b.collectBlocks(make(map[Block]struct{}))
+}
+
+// collectBlocks tracks the blocks it has seen, so a block injected beneath itself can't cause infinite recursion
+func (b *HCLBlock) collectBlocks(visited map[Block]struct{}) Blocks {
+	if _, seen := visited[b]; seen {
+		return nil
+	}
+	visited[b] = struct{}{}
+	results := Blocks{b}
+	for _, child := range b.childBlocks {
+		if hclChild, ok := child.(*HCLBlock); ok {
+			results = append(results, hclChild.collectBlocks(visited)...)
+		}
+	}
+	return results
+}
+
+func (b *HCLBlock) GetBlocksByTypeRecursive(blockType string) Blocks {
+	var results Blocks
+	for _, child := range b.AllBlocksRecursive() {
+		if child != b && child.Type() == blockType {
+			results = append(results, child)
+		}
+	}
+	return results
+}
+
 func (b *HCLBlock) GetBlocks(name string) Blocks {
 	if b == nil || b.hclBlock == nil {
 		return nil
@@ -229,13 +336,11 @@ func (b *HCLBlock) GetAttributes() []Attribute {
 	if b == nil || b.hclBlock == nil {
 		return nil
 	}
-	if b.cachedAttributes != nil {
-		//return b.cachedAttributes
-	}
 	for _, attr := range b.getHCLAttributes() {
-		results = append(results, NewHCLAttribute(attr, b.context))
+		hclAttr := NewHCLAttribute(attr, b.context)
+		hclAttr.branch = b.branch
+		results = append(results, hclAttr)
 	}
-	b.cachedAttributes = results
 	return results
 }
 
@@ -275,6 +380,51 @@ func (b *HCLBlock) GetNestedAttribute(name string) Attribute {
 	return returnAttr
 }
 
+// HasUnknownCondition returns true if an attribute of the block, or of a block nested in it, is a conditional
+// expression whose condition can't be resolved
+func (b *HCLBlock) HasUnknownCondition() bool {
+	for _, checkBlock := range b.AllBlocksRecursive() {
+		for _, attr := range checkBlock.GetAttributes() {
+			if hclAttr, ok := attr.(*HCLAttribute); ok && hclAttr.hasUnknownCondition() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WithConditionalBranch returns a copy of the block in which every conditional expression whose condition can't be
+// resolved takes its true or false result, including in nested blocks
+func (b *HCLBlock) WithConditionalBranch(result bool) Block {
+	if b == nil || b.hclBlock == nil {
+		return b
+	}
+	branch := falseBranch
+	if result {
+		branch = trueBranch
+	}
+	return b.withBranch(branch, make(map[*HCLBlock]*HCLBlock))
+}
+
+// withBranch tracks the blocks it has copied, so a block injected beneath itself can't cause infinite recursion
+func (b *HCLBlock) withBranch(branch conditionalBranch, copied map[*HCLBlock]*HCLBlock) *HCLBlock {
+	if existing, ok := copied[b]; ok {
+		return existing
+	}
+	clone := *b
+	clone.branch = branch
+	copied[b] = &clone
+	clone.childBlocks = make([]Block, len(b.childBlocks))
+	for i, child := range b.childBlocks {
+		if hclChild, ok := child.(*HCLBlock); ok {
+			clone.childBlocks[i] = hclChild.withBranch(branch, copied)
+		} else {
+			clone.childBlocks[i] = child
+		}
+	}
+	return &clone
+}
+
 func (b *HCLBlock) Reference() *Reference {
 
 	var parts []string
@@ -370,7 +520,7 @@ func (b *HCLBlock) InModule() bool {
 }
 
 func (b *HCLBlock) Label() string {
-	return strings.Join(b.hclBlock.Labels, ".")
+	return strings.Join(b.Labels(), ".")
 }
 
 func (b *HCLBlock) HasBlock(childElement string) bool {
@@ -381,7 +531,11 @@ func (b *HCLBlock) IsResourceType(resourceType string) bool {
 	return b.TypeLabel() == resourceType
 }
 
+// IsEmpty returns true if the block has neither attributes nor child blocks. A missing block is not considered empty.
 func (b *HCLBlock) IsEmpty() bool {
+	if b == nil || b.hclBlock == nil {
+		return false
+	}
 	return len(b.AllBlocks()) == 0 && len(b.GetAttributes()) == 0
 }
 
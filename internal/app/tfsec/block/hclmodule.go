@@ -69,6 +69,27 @@ func (c *HCLModule) GetReferencedBlock(referringAttr Attribute) (Block, error) {
 	return nil, fmt.Errorf("no referenced block found in '%s'", referringAttr.Name())
 }
 
+func (c *HCLModule) GetReferencedBlocks(referringAttr Attribute) Blocks {
+	var results Blocks
+	for _, ref := range referringAttr.AllReferences() {
+		for _, block := range c.blocks {
+			if ref.RefersTo(block) && !containsBlock(results, block) {
+				results = append(results, block)
+			}
+		}
+	}
+	return results
+}
+
+func containsBlock(blocks Blocks, b Block) bool {
+	for _, existing := range blocks {
+		if existing == b {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *HCLModule) GetReferencingResources(originalBlock Block, referencingLabel string, referencingAttributeName string) (Blocks, error) {
 	return c.getReferencingBlocks(originalBlock, "resource", referencingLabel, referencingAttributeName)
 }
@@ -6,5 +6,6 @@ type Module interface {
 	GetDatasByType(label string) Blocks
 	GetProviderBlocksByProvider(providerName string, alias string) Blocks
 	GetReferencedBlock(referringAttr Attribute) (Block, error)
+	GetReferencedBlocks(referringAttr Attribute) Blocks
 	GetReferencingResources(originalBlock Block, referencingLabel string, referencingAttributeName string) (Blocks, error)
 }
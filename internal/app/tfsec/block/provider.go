@@ -0,0 +1,37 @@
+package block
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RequiredProvider is an entry of the required_providers of a terraform block
+type RequiredProvider struct {
+	Source  string
+	Version string
+}
+
+// ProviderRef returns the provider configuration a resource or data block uses, e.g. aws.east, defaulting to the
+// unaliased configuration of the provider its type belongs to
+func ProviderRef(b Block) string {
+	if providerAttr := b.GetAttribute("provider"); providerAttr.IsNotNil() {
+		if ref, err := providerAttr.Reference(); err == nil {
+			return ref.String()
+		}
+	}
+	return strings.SplitN(b.TypeLabel(), "_", 2)[0]
+}
+
+// ProviderBlockRef returns the reference a provider block is known by, including its alias if it has one
+func ProviderBlockRef(providerBlock Block) string {
+	if alias, ok := providerBlock.GetAttribute("alias").AsStringValue(); ok {
+		return fmt.Sprintf("%s.%s", providerBlock.TypeLabel(), alias)
+	}
+	return providerBlock.TypeLabel()
+}
+
+// ProviderConfig returns the provider block which governs a resource or data block. The second return value is false
+// if the provider is not configured, in which case terraform uses an empty configuration.
+func ProviderConfig(b Block) (Block, bool) {
+	return b.Context().Provider(ProviderRef(b))
+}
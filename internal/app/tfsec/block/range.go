@@ -2,7 +2,6 @@ package block
 
 import (
 	"fmt"
-	"io/ioutil"
 	"strings"
 )
 
@@ -22,7 +21,7 @@ func (r Range) String() string {
 }
 
 func (r Range) ReadLines(includeCommentsAfterLines bool) (lines []string, comments []string, err error) {
-	data, err := ioutil.ReadFile(r.Filename)
+	data, err := ReadSource(r.Filename)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,34 @@
+package block
+
+import (
+	"regexp"
+	"sync"
+)
+
+var regexCache = struct {
+	sync.RWMutex
+	patterns map[string]*regexp.Regexp
+}{
+	patterns: make(map[string]*regexp.Regexp),
+}
+
+// CompileRegex compiles the pattern, reusing the result for patterns which have already been compiled. Checks should
+// call this when they are registered so that invalid patterns are reported up front rather than during a scan.
+func CompileRegex(pattern string) (*regexp.Regexp, error) {
+	regexCache.RLock()
+	re, ok := regexCache.patterns[pattern]
+	regexCache.RUnlock()
+	if ok {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	regexCache.Lock()
+	regexCache.patterns[pattern] = re
+	regexCache.Unlock()
+	return re, nil
+}
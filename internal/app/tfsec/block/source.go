@@ -0,0 +1,22 @@
+package block
+
+import (
+	"io/ioutil"
+	"sync"
+)
+
+var sources sync.Map
+
+// RegisterSource records the content of a file which doesn't exist on disk, such as terraform read from stdin, so
+// that code can still be read from the ranges within it
+func RegisterSource(filename string, data []byte) {
+	sources.Store(filename, data)
+}
+
+// ReadSource reads the content of a file, preferring any content registered with RegisterSource
+func ReadSource(filename string) ([]byte, error) {
+	if data, ok := sources.Load(filename); ok {
+		return data.([]byte), nil
+	}
+	return ioutil.ReadFile(filename)
+}
@@ -1,42 +1,173 @@
 package cidr
 
 import (
+	"fmt"
+	"net"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/zclconf/go-cty/cty"
 )
 
+// privateRanges covers RFC1918 and RFC4193 private address space, along with the loopback ranges
+var privateRanges = mustParseCIDRs(
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"127.0.0.0/8",
+	"fc00::/7",
+	"::1/128",
+)
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, network)
+	}
+	return nets
+}
+
 func IsAttributeOpen(attr block.Attribute) bool {
-	if attr.IsNil() || attr.Value().IsNull() {
+	for _, cidrStr := range attributeCIDRs(attr) {
+		if IsOpen(cidrStr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPrivate checks whether every CIDR in the attribute falls within private address space
+func IsPrivate(attr block.Attribute) bool {
+	cidrs := attributeCIDRs(attr)
+	if len(cidrs) == 0 {
+		return false
+	}
+	for _, cidrStr := range cidrs {
+		if !IsPrivateCIDR(cidrStr) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsPublic checks whether any CIDR in the attribute includes publicly routable address space
+func IsPublic(attr block.Attribute) bool {
+	for _, cidrStr := range attributeCIDRs(attr) {
+		if IsPublicCIDR(cidrStr) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsPrivateCIDR(cidrStr string) bool {
+	network, ok := parseNetwork(cidrStr)
+	if !ok {
+		return false
+	}
+	ones, bits := network.Mask.Size()
+	for _, private := range privateRanges {
+		privateOnes, privateBits := private.Mask.Size()
+		if bits == privateBits && ones >= privateOnes && private.Contains(network.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+func IsPublicCIDR(cidrStr string) bool {
+	if cidrStr == "*" {
+		return true
+	}
+	if _, ok := parseNetwork(cidrStr); !ok {
 		return false
 	}
+	return !IsPrivateCIDR(cidrStr)
+}
+
+// IsOpen checks for a CIDR which covers every address, for either IPv4 (0.0.0.0/0) or IPv6 (::/0)
+func IsOpen(cidrStr string) bool {
+	cidrStr = strings.TrimSpace(cidrStr)
+	if cidrStr == "*" {
+		return true
+	}
+	if _, network, err := net.ParseCIDR(cidrStr); err == nil {
+		ones, _ := network.Mask.Size()
+		return ones == 0
+	}
+	return strings.HasSuffix(cidrStr, "/0")
+}
+
+// Overlaps checks whether two CIDRs share any addresses. CIDRs of different address families never overlap.
+func Overlaps(a, b string) (bool, error) {
+	networkA, ok := parseNetwork(a)
+	if !ok {
+		return false, fmt.Errorf("invalid CIDR '%s'", a)
+	}
+	networkB, ok := parseNetwork(b)
+	if !ok {
+		return false, fmt.Errorf("invalid CIDR '%s'", b)
+	}
+	if len(networkA.IP) != len(networkB.IP) {
+		return false, nil
+	}
+	return networkA.Contains(networkB.IP) || networkB.Contains(networkA.IP), nil
+}
+
+// AttributeOverlaps checks whether any CIDR in the attribute overlaps the target CIDR
+func AttributeOverlaps(attr block.Attribute, target string) bool {
+	for _, cidrStr := range attributeCIDRs(attr) {
+		if overlaps, err := Overlaps(cidrStr, target); err == nil && overlaps {
+			return true
+		}
+	}
+	return false
+}
+
+// parseNetwork accepts either a CIDR or a single address, which is treated as a network of one address
+func parseNetwork(cidrStr string) (*net.IPNet, bool) {
+	if _, network, err := net.ParseCIDR(cidrStr); err == nil {
+		return network, true
+	}
+	ip := net.ParseIP(cidrStr)
+	if ip == nil {
+		return nil, false
+	}
+	if ipv4 := ip.To4(); ipv4 != nil {
+		return &net.IPNet{IP: ipv4, Mask: net.CIDRMask(32, 32)}, true
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, true
+}
+
+func attributeCIDRs(attr block.Attribute) []string {
+	if attr.IsNil() || attr.Value().IsNull() {
+		return nil
+	}
 
 	var cidrList []cty.Value
 	if attr.Type() == cty.String {
 		cidrList = []cty.Value{attr.Value()}
-	} else {
+	} else if attr.Type().IsListType() || attr.Type().IsSetType() || attr.Type().IsTupleType() {
 		cidrList = attr.Value().AsValueSlice()
 	}
 
+	var cidrs []string
 	for _, cidr := range cidrList {
 		if cidr.Type() != cty.String {
 			continue
 		}
 
-		if !cidr.IsKnown() {
+		if !cidr.IsKnown() || cidr.IsNull() {
 			continue
 		}
 
-		cidrStr := cidr.AsString()
-		if IsOpen(cidrStr) {
-			return true
-		}
+		cidrs = append(cidrs, cidr.AsString())
 	}
 
-	return false
-}
-
-func IsOpen(cidrStr string) bool {
-	return strings.HasSuffix(cidrStr, "/0") || cidrStr == "*"
+	return cidrs
 }
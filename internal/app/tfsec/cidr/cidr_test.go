@@ -0,0 +1,177 @@
+package cidr_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PrivateAndPublicCIDRs(t *testing.T) {
+	var tests = []struct {
+		name            string
+		cidrs           string
+		expectedPrivate bool
+		expectedPublic  bool
+	}{
+		{
+			name:            "single rfc1918 range",
+			cidrs:           `"10.1.0.0/16"`,
+			expectedPrivate: true,
+		},
+		{
+			name:            "list of private ranges",
+			cidrs:           `["172.16.4.0/24", "192.168.0.0/16", "127.0.0.1/32", "fd00:1234::/64"]`,
+			expectedPrivate: true,
+		},
+		{
+			name:           "range wider than rfc1918",
+			cidrs:          `"172.0.0.0/8"`,
+			expectedPublic: true,
+		},
+		{
+			name:           "open range",
+			cidrs:          `"0.0.0.0/0"`,
+			expectedPublic: true,
+		},
+		{
+			name:           "list mixing private and public ranges",
+			cidrs:          `["10.0.0.0/8", "8.8.8.8/32"]`,
+			expectedPublic: true,
+		},
+		{
+			name:           "public ipv6 range",
+			cidrs:          `"2001:db8::/32"`,
+			expectedPublic: true,
+		},
+		{
+			name:  "unresolvable range",
+			cidrs: `var.cidrs`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "rule" {
+	cidr_blocks = `+test.cidrs+`
+}`, ".tf", t)
+			require.Len(t, modules, 1)
+			rules := modules[0].GetResourcesByType("aws_security_group_rule")
+			require.Len(t, rules, 1)
+			attr := rules[0].GetAttribute("cidr_blocks")
+			assert.Equal(t, test.expectedPrivate, cidr.IsPrivate(attr))
+			assert.Equal(t, test.expectedPublic, cidr.IsPublic(attr))
+		})
+	}
+}
+
+func Test_IsOpen(t *testing.T) {
+	var tests = []struct {
+		cidr     string
+		expected bool
+	}{
+		{cidr: "0.0.0.0/0", expected: true},
+		{cidr: "::/0", expected: true},
+		{cidr: "0::/0", expected: true},
+		{cidr: "*", expected: true},
+		{cidr: "2001:db8::/32", expected: false},
+		{cidr: "::1/128", expected: false},
+		{cidr: "10.0.0.0/16", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.cidr, func(t *testing.T) {
+			assert.Equal(t, test.expected, cidr.IsOpen(test.cidr))
+		})
+	}
+}
+
+func Test_IsAttributeOpen(t *testing.T) {
+	var tests = []struct {
+		name     string
+		cidrs    string
+		expected bool
+	}{
+		{
+			name:     "open ipv4 range",
+			cidrs:    `["0.0.0.0/0"]`,
+			expected: true,
+		},
+		{
+			name:     "open ipv6 range",
+			cidrs:    `["::/0"]`,
+			expected: true,
+		},
+		{
+			name:     "mixed list with open ipv6 range",
+			cidrs:    `["10.0.0.0/16", "2001:db8::/32", "::/0"]`,
+			expected: true,
+		},
+		{
+			name:     "mixed list without open range",
+			cidrs:    `["10.0.0.0/16", "2001:db8::/32"]`,
+			expected: false,
+		},
+		{
+			name:     "single ipv6 string",
+			cidrs:    `"2001:db8::/32"`,
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "rule" {
+	ipv6_cidr_blocks = `+test.cidrs+`
+}`, ".tf", t)
+			require.Len(t, modules, 1)
+			rules := modules[0].GetResourcesByType("aws_security_group_rule")
+			require.Len(t, rules, 1)
+			assert.Equal(t, test.expected, cidr.IsAttributeOpen(rules[0].GetAttribute("ipv6_cidr_blocks")))
+		})
+	}
+}
+
+func Test_Overlaps(t *testing.T) {
+	var tests = []struct {
+		a        string
+		b        string
+		expected bool
+	}{
+		{a: "10.0.0.0/8", b: "10.1.2.0/24", expected: true},
+		{a: "10.1.2.0/24", b: "10.0.0.0/8", expected: true},
+		{a: "192.168.0.0/16", b: "10.0.0.0/8", expected: false},
+		{a: "0.0.0.0/0", b: "8.8.8.8", expected: true},
+		{a: "2001:db8::/32", b: "2001:db8:1::/48", expected: true},
+		{a: "::/0", b: "10.0.0.0/8", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.a+" "+test.b, func(t *testing.T) {
+			overlaps, err := cidr.Overlaps(test.a, test.b)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, overlaps)
+		})
+	}
+
+	_, err := cidr.Overlaps("10.0.0.0/33", "10.0.0.0/8")
+	assert.Error(t, err)
+}
+
+func Test_AttributeOverlaps(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "rule" {
+	cidr_blocks = ["192.168.0.0/16", "10.1.2.0/24"]
+}`, ".tf", t)
+	require.Len(t, modules, 1)
+	rules := modules[0].GetResourcesByType("aws_security_group_rule")
+	require.Len(t, rules, 1)
+	attr := rules[0].GetAttribute("cidr_blocks")
+	assert.True(t, cidr.AttributeOverlaps(attr, "10.0.0.0/8"))
+	assert.False(t, cidr.AttributeOverlaps(attr, "172.16.0.0/12"))
+	assert.False(t, cidr.AttributeOverlaps(attr, "not-a-cidr"))
+}
@@ -0,0 +1,60 @@
+package compare
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+// LoadPrevious reads the results from a file written by the json formatter
+func LoadPrevious(path string) ([]result.Result, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read previous results '%s': %w", path, err)
+	}
+	var output formatters.JSONOutput
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("failed to parse previous results '%s': %w", path, err)
+	}
+	return output.Results, nil
+}
+
+// Diff finds the failed results which were added and resolved since the previous scan. Findings are matched on rule
+// ID, resource and description rather than location, so unrelated edits which move a resource don't affect the diff.
+// Results which didn't fail are left in added as they are, so they can still be reported with --include-passed.
+func Diff(previous []result.Result, current []result.Result) (added []result.Result, resolved []result.Result) {
+	known := make(map[string]int)
+	for _, res := range previous {
+		if res.Status == result.Failed {
+			known[key(res)]++
+		}
+	}
+
+	for _, res := range current {
+		if res.Status == result.Failed {
+			if k := key(res); known[k] > 0 {
+				known[k]--
+				continue
+			}
+		}
+		added = append(added, res)
+	}
+
+	for _, res := range previous {
+		if res.Status != result.Failed {
+			continue
+		}
+		if k := key(res); known[k] > 0 {
+			known[k]--
+			resolved = append(resolved, res)
+		}
+	}
+	return added, resolved
+}
+
+func key(res result.Result) string {
+	return fmt.Sprintf("%s|%s|%s", res.RuleID, res.Resource, res.Description)
+}
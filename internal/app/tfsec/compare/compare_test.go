@@ -0,0 +1,71 @@
+package compare_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/compare"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiffReportsAddedAndResolvedFindings(t *testing.T) {
+	previous := testutil.ScanHCL(`
+resource "aws_security_group_rule" "kept" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "removed" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, previous)
+
+	path := filepath.Join(t.TempDir(), "previous.json")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, formatters.FormatJSON(f, previous, ""))
+	require.NoError(t, f.Close())
+
+	loaded, err := compare.LoadPrevious(path)
+	require.NoError(t, err)
+	require.Len(t, loaded, len(previous))
+
+	current := testutil.ScanHCL(`
+
+resource "aws_security_group_rule" "added" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "kept" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+
+	added, resolved := compare.Diff(loaded, current)
+	require.NotEmpty(t, added)
+	require.NotEmpty(t, resolved)
+	for _, res := range added {
+		assert.Equal(t, "aws_security_group_rule.added", res.Resource)
+	}
+	for _, res := range resolved {
+		assert.Equal(t, "aws_security_group_rule.removed", res.Resource)
+	}
+}
+
+func Test_LoadPreviousRejectsInvalidJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "previous.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("not json"), 0600))
+
+	_, err := compare.LoadPrevious(path)
+	assert.Error(t, err)
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/pkg/severity"
@@ -16,6 +18,52 @@ type Config struct {
 	SeverityOverrides map[string]string `json:"severity_overrides,omitempty" yaml:"severity_overrides,omitempty"`
 	ExcludedChecks    []string          `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 	IncludedChecks    []string          `json:"include,omitempty" yaml:"include,omitempty"`
+	MinimumSeverity   string            `json:"minimum_severity,omitempty" yaml:"minimum_severity,omitempty"`
+	EntropyThreshold  float64           `json:"secret_entropy_threshold,omitempty" yaml:"secret_entropy_threshold,omitempty"`
+	RequiredTags      []string          `json:"required_tags,omitempty" yaml:"required_tags,omitempty"`
+	ColourTheme       string            `json:"colour_theme,omitempty" yaml:"colour_theme,omitempty"`
+	SeverityColours   map[string]string `json:"severity_colours,omitempty" yaml:"severity_colours,omitempty"`
+	SensitivePorts    []int             `json:"sensitive_ports,omitempty" yaml:"sensitive_ports,omitempty"`
+	LogBucketPatterns []string          `json:"log_bucket_patterns,omitempty" yaml:"log_bucket_patterns,omitempty"`
+	LogBucketTags     map[string]string `json:"log_bucket_tags,omitempty" yaml:"log_bucket_tags,omitempty"`
+	RetentionDays     map[string]int    `json:"minimum_retention_days,omitempty" yaml:"minimum_retention_days,omitempty"`
+	ProtectedTypes    []string          `json:"protected_resource_types,omitempty" yaml:"protected_resource_types,omitempty"`
+	PublicIPTags      []string          `json:"public_ip_allowed_tags,omitempty" yaml:"public_ip_allowed_tags,omitempty"`
+	PublicIPLabels    map[string]string `json:"public_ip_allowed_labels,omitempty" yaml:"public_ip_allowed_labels,omitempty"`
+}
+
+var configFileNames = []string{"config.json", "config.yml", "config.yaml"}
+
+// FindConfigFile looks for a config file in the .tfsec directory of dir, then of each parent directory in turn
+func FindConfigFile(dir string) (string, bool) {
+	for {
+		for _, name := range configFileNames {
+			configFilePath := filepath.Join(dir, ".tfsec", name)
+			if info, err := os.Stat(configFilePath); err == nil && !info.IsDir() {
+				return configFilePath, true
+			}
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// RuleIDs lists every rule ID referenced by the config
+func (c *Config) RuleIDs() []string {
+	var ids []string
+	ids = append(ids, c.ExcludedChecks...)
+	ids = append(ids, c.IncludedChecks...)
+	for id := range c.SeverityOverrides {
+		ids = append(ids, id)
+	}
+	for id := range c.RetentionDays {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
 }
 
 func LoadConfig(configFilePath string) (*Config, error) {
@@ -46,7 +94,51 @@ func LoadConfig(configFilePath string) (*Config, error) {
 		return nil, fmt.Errorf("couldn't process the file %s", configFilePath)
 	}
 
-	rewriteSeverityOverrides(config)
+	if err := rewriteSeverityOverrides(config); err != nil {
+		return nil, fmt.Errorf("failed to load config file '%s': %s", configFilePath, err)
+	}
+
+	if config.MinimumSeverity != "" {
+		minimumSeverity := severity.StringToSeverity(config.MinimumSeverity)
+		if !minimumSeverity.IsValid() {
+			return nil, fmt.Errorf("invalid minimum_severity '%s' in config file '%s', should be one of %s", config.MinimumSeverity, configFilePath, severity.ValidSeverity)
+		}
+		config.MinimumSeverity = string(minimumSeverity)
+	}
+
+	if config.EntropyThreshold < 0 {
+		return nil, fmt.Errorf("invalid secret_entropy_threshold %v in config file '%s', should not be negative", config.EntropyThreshold, configFilePath)
+	}
+
+	for _, tag := range config.RequiredTags {
+		if strings.TrimSpace(tag) == "" {
+			return nil, fmt.Errorf("invalid required_tags in config file '%s', tag keys should not be empty", configFilePath)
+		}
+	}
+
+	for _, pattern := range config.LogBucketPatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid log_bucket_patterns entry '%s' in config file '%s': %w", pattern, configFilePath, err)
+		}
+	}
+
+	for _, resourceType := range config.ProtectedTypes {
+		if strings.TrimSpace(resourceType) == "" {
+			return nil, fmt.Errorf("invalid protected_resource_types in config file '%s', resource types should not be empty", configFilePath)
+		}
+	}
+
+	for _, tag := range config.PublicIPTags {
+		if strings.TrimSpace(tag) == "" {
+			return nil, fmt.Errorf("invalid public_ip_allowed_tags in config file '%s', tags should not be empty", configFilePath)
+		}
+	}
+
+	for ruleID, days := range config.RetentionDays {
+		if days < 0 {
+			return nil, fmt.Errorf("invalid minimum_retention_days %d for rule '%s' in config file '%s', should not be negative", days, ruleID, configFilePath)
+		}
+	}
 
 	return config, nil
 }
@@ -54,7 +146,11 @@ func LoadConfig(configFilePath string) (*Config, error) {
 func rewriteSeverityOverrides(config *Config) error {
 
 	for k, s := range config.SeverityOverrides {
-		config.SeverityOverrides[k] = string(severity.StringToSeverity(s))
+		overridden := severity.StringToSeverity(s)
+		if !overridden.IsValid() {
+			return fmt.Errorf("severity override '%s' for rule '%s' is not a recognised option, should be one of %s", s, k, severity.ValidSeverity)
+		}
+		config.SeverityOverrides[k] = string(overridden)
 	}
 
 	return nil
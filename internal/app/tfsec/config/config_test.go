@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/config"
@@ -86,3 +87,128 @@ func load(t *testing.T, filename, content string) *config.Config {
 
 	return c
 }
+
+func TestMinimumSeverityIsLoaded(t *testing.T) {
+	content := `
+minimum_severity: high
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, "HIGH", c.MinimumSeverity)
+}
+
+func TestInvalidMinimumSeverityIsRejected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	configFileName := filepath.Join(dir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(configFileName, []byte("minimum_severity: urgent\n"), os.ModePerm))
+
+	_, err = config.LoadConfig(configFileName)
+	assert.Error(t, err)
+}
+
+func TestEntropyThresholdIsLoaded(t *testing.T) {
+	content := `
+secret_entropy_threshold: 5.2
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, 5.2, c.EntropyThreshold)
+}
+
+func TestRequiredTagsAreLoaded(t *testing.T) {
+	content := `{
+  "required_tags": ["Environment", "Owner"]
+}
+`
+	c := load(t, "config.json", content)
+
+	assert.Equal(t, []string{"Environment", "Owner"}, c.RequiredTags)
+}
+
+func TestLogBucketConventionIsLoaded(t *testing.T) {
+	content := `
+log_bucket_patterns:
+  - "*-access-logs"
+log_bucket_tags:
+  Purpose: logging
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, []string{"*-access-logs"}, c.LogBucketPatterns)
+	assert.Equal(t, map[string]string{"Purpose": "logging"}, c.LogBucketTags)
+}
+
+func TestRetentionDaysAreLoaded(t *testing.T) {
+	content := `
+minimum_retention_days:
+  aws-rds-backup-retention-specified: 14
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, map[string]int{"aws-rds-backup-retention-specified": 14}, c.RetentionDays)
+	assert.Equal(t, []string{"aws-rds-backup-retention-specified"}, c.RuleIDs())
+}
+
+func TestNegativeRetentionDaysAreRejected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	configFileName := filepath.Join(dir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(configFileName, []byte("minimum_retention_days:\n  aws-rds-backup-retention-specified: -1\n"), os.ModePerm))
+
+	_, err = config.LoadConfig(configFileName)
+	assert.Error(t, err)
+}
+
+func TestProtectedTypesAreLoaded(t *testing.T) {
+	content := `
+protected_resource_types:
+  - aws_db_instance
+  - aws_s3_bucket
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, []string{"aws_db_instance", "aws_s3_bucket"}, c.ProtectedTypes)
+}
+
+func TestConfigFileIsFoundInParentDirectory(t *testing.T) {
+	root, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".tfsec"), os.ModePerm))
+	configFileName := filepath.Join(root, ".tfsec", "config.yaml")
+	require.NoError(t, ioutil.WriteFile(configFileName, []byte("exclude:\n  - DP001\n"), os.ModePerm))
+
+	projectDir := filepath.Join(root, "environments", "prod")
+	require.NoError(t, os.MkdirAll(projectDir, os.ModePerm))
+
+	found, ok := config.FindConfigFile(projectDir)
+	require.True(t, ok)
+	assert.Equal(t, configFileName, found)
+}
+
+func TestInvalidSeverityOverrideIsRejected(t *testing.T) {
+	dir, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+
+	configFileName := filepath.Join(dir, "config.yml")
+	require.NoError(t, ioutil.WriteFile(configFileName, []byte("severity_overrides:\n  AWS018: SEVERE\n"), os.ModePerm))
+
+	_, err = config.LoadConfig(configFileName)
+	assert.Error(t, err)
+}
+
+func TestPublicIPAllowancesAreLoaded(t *testing.T) {
+	content := `
+public_ip_allowed_tags:
+  - bastion
+public_ip_allowed_labels:
+  exposure: internet
+`
+	c := load(t, "config.yml", content)
+
+	assert.Equal(t, []string{"bastion"}, c.PublicIPTags)
+	assert.Equal(t, map[string]string{"exposure": "internet"}, c.PublicIPLabels)
+}
@@ -0,0 +1,75 @@
+package coverage
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+)
+
+// checks run concurrently, so the records are guarded by lock
+var lock sync.Mutex
+
+var records []Record
+
+type Status string
+
+const (
+	Passed        Status = "passed"
+	NotApplicable Status = "not_applicable"
+)
+
+// Reasons explain why a check was given its status, in a form which is stable enough to be matched by tooling
+const (
+	ReasonNoFindings             = "no_findings"
+	ReasonRequiredLabelsMismatch = "required_labels_not_matched"
+)
+
+// Record is evidence that a check was considered for a resource, and whether it passed or didn't apply
+type Record struct {
+	RuleID       string      `json:"rule_id"`
+	LegacyRuleID string      `json:"legacy_rule_id,omitempty"`
+	Resource     string      `json:"resource"`
+	Location     block.Range `json:"location"`
+	Status       Status      `json:"status"`
+	Reason       string      `json:"reason"`
+}
+
+func Add(record Record) {
+	lock.Lock()
+	defer lock.Unlock()
+	records = append(records, record)
+}
+
+// Records returns the recorded checks with the given status, ordered by rule and then location
+func Records(status Status) []Record {
+	lock.Lock()
+	defer lock.Unlock()
+	var matching []Record
+	for _, record := range records {
+		if record.Status == status {
+			matching = append(matching, record)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		a, b := matching[i], matching[j]
+		if a.RuleID != b.RuleID {
+			return a.RuleID < b.RuleID
+		}
+		if a.Location.Filename != b.Location.Filename {
+			return a.Location.Filename < b.Location.Filename
+		}
+		if a.Location.StartLine != b.Location.StartLine {
+			return a.Location.StartLine < b.Location.StartLine
+		}
+		return a.Resource < b.Resource
+	})
+	return matching
+}
+
+// Reset discards the recorded checks
+func Reset() {
+	lock.Lock()
+	defer lock.Unlock()
+	records = nil
+}
@@ -4,39 +4,18 @@ import (
 	"fmt"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
 )
 
-func checkTags(block block.Block, spec *MatchSpec, module block.Module) bool {
+func checkTags(block block.Block, spec *MatchSpec, _ block.Module) bool {
 	expectedTag := fmt.Sprintf("%v", spec.MatchValue)
 
-	if block.HasChild("tags") {
-		tagsBlock := block.GetAttribute("tags")
-		if tagsBlock.Contains(expectedTag) {
-			return true
-		}
+	tags, ok := tagging.EffectiveTags(block)
+	if !ok {
+		return false
 	}
-
-	var alias string
-	if block.HasChild("provider") {
-		aliasRef, err := block.GetAttribute("provider").Reference()
-		if err == nil {
-			alias = aliasRef.String()
-		}
-	}
-
-	awsProviders := module.GetProviderBlocksByProvider("aws", alias)
-	for _, providerBlock := range awsProviders {
-		if providerBlock.HasChild("default_tags") {
-			defaultTags := providerBlock.GetBlock("default_tags")
-			if defaultTags.HasChild("tags") {
-				tags := defaultTags.GetAttribute("tags")
-				if tags.Contains(expectedTag) {
-					return true
-				}
-			}
-		}
-	}
-	return false
+	_, found := tags[expectedTag]
+	return found
 }
 
 func ofType(block block.Block, spec *MatchSpec) bool {
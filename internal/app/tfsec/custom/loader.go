@@ -1,6 +1,7 @@
 package custom
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -27,15 +28,28 @@ func Load(customCheckDir string) error {
 		return err
 	}
 
-	return loadCustomChecks(customCheckDir)
+	loading := make(map[string]string)
+	err = loadCustomChecks(customCheckDir, loading)
+
+	policies, errorList := loadRegoPolicies(customCheckDir, loading)
+	processFoundPolicies(policies)
+
+	if err != nil {
+		errorList = append([]string{err.Error()}, errorList...)
+	}
+	if len(errorList) > 0 {
+		return errors.New(strings.Join(errorList, "\n"))
+	}
+	return nil
 }
 
-func loadCustomChecks(customCheckDir string) error {
+func loadCustomChecks(customCheckDir string, loading map[string]string) error {
 	files, err := listFiles(customCheckDir, ".*_tfchecks.*")
 	if err != nil {
 		return err
 	}
 	var errorList []string
+	var toRegister []ChecksFile
 	for _, file := range files {
 		checkFilePath := path.Join(customCheckDir, file.Name())
 		err = Validate(checkFilePath)
@@ -48,7 +62,14 @@ func loadCustomChecks(customCheckDir string) error {
 			errorList = append(errorList, err.Error())
 			continue
 		}
+		if duplicates := checkForDuplicates(checkFilePath, checks, loading); len(duplicates) > 0 {
+			errorList = append(errorList, duplicates...)
+			continue
+		}
+		toRegister = append(toRegister, checks)
+	}
 
+	for _, checks := range toRegister {
 		processFoundChecks(checks)
 	}
 
@@ -67,14 +88,15 @@ func loadCheckFile(checkFilePath string) (ChecksFile, error) {
 	ext := filepath.Ext(checkFilePath)
 	switch strings.ToLower(ext) {
 	case ".json":
-		err = json.Unmarshal(checkFileContent, &checks)
-		if err != nil {
-			return checks, err
+		// unknown fields are rejected so that misspelt keys are reported rather than silently ignored
+		decoder := json.NewDecoder(bytes.NewReader(checkFileContent))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&checks); err != nil {
+			return checks, fmt.Errorf("check file %s is not valid: %w", checkFilePath, err)
 		}
 	case ".yml", ".yaml":
-		err = yaml.Unmarshal(checkFileContent, &checks)
-		if err != nil {
-			return checks, nil
+		if err := yaml.UnmarshalStrict(checkFileContent, &checks); err != nil {
+			return checks, fmt.Errorf("check file %s is not valid: %w", checkFilePath, err)
 		}
 	default:
 		return checks, fmt.Errorf("couldn't process the file %s", checkFilePath)
@@ -0,0 +1,113 @@
+package custom
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadYAMLCheck(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "acl_tfchecks.yaml"), []byte(`
+checks:
+  - code: CUS100
+    description: Buckets must be private
+    requiredTypes:
+      - resource
+    requiredLabels:
+      - aws_s3_bucket
+    severity: HIGH
+    errorMessage: The bucket is not private
+    matchSpec:
+      name: acl
+      action: equals
+      value: private
+`), 0600))
+
+	require.NoError(t, Load(dir))
+
+	results := scanTerraform(t, `
+resource "aws_s3_bucket" "public" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket" "private" {
+	acl = "private"
+}
+`)
+	var found []string
+	for _, res := range results {
+		if res.RuleID == "custom-custom-cus100" {
+			found = append(found, res.ResourceName())
+		}
+	}
+	assert.Equal(t, []string{"aws_s3_bucket.public"}, found)
+}
+
+func TestLoadRejectsMalformedYAMLCheck(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken_tfchecks.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+checks:
+  - code: CUS101
+    description: Misspelt key
+    requiredType:
+      - resource
+`), 0600))
+
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+	assert.Contains(t, err.Error(), "requiredType")
+}
+
+func TestLoadRejectsCheckWithoutMatchSpec(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "incomplete_tfchecks.yml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+checks:
+  - code: CUS102
+    description: No match spec
+    requiredTypes:
+      - resource
+    requiredLabels:
+      - aws_s3_bucket
+    severity: LOW
+`), 0600))
+
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "check.MatchSpec requires a value")
+}
+
+func TestLoadRejectsDuplicateCheckCodes(t *testing.T) {
+	check := `{
+  "checks": [
+    {
+      "code": "CUS200",
+      "description": "Buckets must be private",
+      "requiredTypes": ["resource"],
+      "requiredLabels": ["aws_s3_bucket"],
+      "severity": "HIGH",
+      "matchSpec": {"name": "acl", "action": "equals", "value": "private"}
+    }
+  ]
+}`
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "first_tfchecks.json"), []byte(check), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "second_tfchecks.json"), []byte(check), 0600))
+
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is also used in")
+
+	// the first definition was registered, so loading it again clashes with the registered check
+	single := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(single, "again_tfchecks.json"), []byte(check), 0600))
+	err = Load(single)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is already registered")
+}
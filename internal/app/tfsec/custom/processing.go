@@ -4,7 +4,6 @@ import (
 	"fmt"
 
 	"github.com/aquasecurity/tfsec/pkg/provider"
-	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
 
@@ -26,8 +25,7 @@ var matchFunctions = map[CheckAction]func(block.Block, *MatchSpec) bool{
 			return true
 		}
 
-		attribute := block.GetAttribute(spec.Name)
-		if attribute != nil {
+		if attribute := block.GetAttribute(spec.Name); attribute.IsNotNil() {
 			return attribute.IsEmpty()
 		}
 		childBlock := block.GetBlock(spec.Name)
@@ -125,34 +123,63 @@ var matchFunctions = map[CheckAction]func(block.Block, *MatchSpec) bool{
 
 func processFoundChecks(checks ChecksFile) {
 	for _, customCheck := range checks.Checks {
-		func(customCheck Check) {
-			debug.Log("Loading check: %s\n", customCheck.Code)
-			scanner.RegisterCheckRule(rule.Rule{
-				LegacyID:  customCheck.Code,
-				Service:   "custom",
-				ShortCode: customCheck.Code,
-				Documentation: rule.RuleDocumentation{
-					Summary:    customCheck.Description,
-					Links:      customCheck.RelatedLinks,
-					Impact:     customCheck.Impact,
-					Resolution: customCheck.Resolution,
-				},
-				Provider:        provider.CustomProvider,
-				RequiredTypes:   customCheck.RequiredTypes,
-				RequiredLabels:  customCheck.RequiredLabels,
-				RequiredSources: customCheck.RequiredSources,
-				DefaultSeverity: severity.Medium,
-				CheckFunc: func(set result.Set, rootBlock block.Block, module block.Module) {
-					matchSpec := customCheck.MatchSpec
-					if !evalMatchSpec(rootBlock, matchSpec, module) {
-						set.AddResult().
-							WithDescription("Custom check failed for resource %s. %s", rootBlock.FullName(), customCheck.ErrorMessage).
-							WithSeverity(customCheck.Severity)
-					}
-				},
-			})
-		}(*customCheck)
+		debug.Log("Loading check: %s\n", customCheck.Code)
+		scanner.RegisterCheckRule(newCustomRule(*customCheck))
+	}
+}
+
+// newCustomRule converts a check definition into a rule which is registered and run like any built-in rule
+func newCustomRule(customCheck Check) rule.Rule {
+	return rule.Rule{
+		LegacyID:  customCheck.Code,
+		Service:   "custom",
+		ShortCode: customCheck.Code,
+		Documentation: rule.RuleDocumentation{
+			Summary:    customCheck.Description,
+			Links:      customCheck.RelatedLinks,
+			Impact:     customCheck.Impact,
+			Resolution: customCheck.Resolution,
+		},
+		Provider:        provider.CustomProvider,
+		RequiredTypes:   customCheck.RequiredTypes,
+		RequiredLabels:  customCheck.RequiredLabels,
+		RequiredSources: customCheck.RequiredSources,
+		DefaultSeverity: customCheck.Severity,
+		CheckFunc: func(set result.Set, rootBlock block.Block, module block.Module) {
+			matchSpec := customCheck.MatchSpec
+			if !evalMatchSpec(rootBlock, matchSpec, module) {
+				set.AddResult().
+					WithDescription("Custom check failed for resource %s. %s", rootBlock.FullName(), customCheck.ErrorMessage).
+					WithSeverity(customCheck.Severity)
+			}
+		},
+	}
+}
+
+// checkForDuplicates reports checks whose ID or code is already used by a registered rule, or by another check or
+// policy being loaded, as duplicates would make results and ignores ambiguous
+func checkForDuplicates(checkFilePath string, checks ChecksFile, loading map[string]string) []string {
+	var errorList []string
+	for _, customCheck := range checks.Checks {
+		if duplicate := checkForDuplicate(newCustomRule(*customCheck).ID(), customCheck.Code, checkFilePath, loading); duplicate != "" {
+			errorList = append(errorList, duplicate)
+		}
+	}
+	return errorList
+}
+
+func checkForDuplicate(id string, code string, filePath string, loading map[string]string) string {
+	if _, err := scanner.GetRuleById(id); err == nil {
+		return fmt.Sprintf("check %s in %s: a check with ID '%s' is already registered", code, filePath, id)
+	}
+	if _, err := scanner.GetRuleByLegacyID(code); err == nil {
+		return fmt.Sprintf("check %s in %s: the code '%s' is already used by a registered check", code, filePath, code)
+	}
+	if previous, found := loading[id]; found {
+		return fmt.Sprintf("check %s in %s: the code '%s' is also used in %s", code, filePath, code, previous)
 	}
+	loading[id] = filePath
+	return ""
 }
 
 func evalMatchSpec(b block.Block, spec *MatchSpec, module block.Module) bool {
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
@@ -418,3 +419,24 @@ func createTestFile(filename, contents string) string {
 	}
 	return path
 }
+
+func TestValidateRejectsInvalidRegex(t *testing.T) {
+	check := &Check{
+		Code:           "custom-regex",
+		Description:    "Bucket names must follow the naming convention",
+		RequiredTypes:  []string{"resource"},
+		RequiredLabels: []string{"aws_s3_bucket"},
+		Severity:       severity.High,
+		MatchSpec: &MatchSpec{
+			Name:       "bucket",
+			Action:     RegexMatches,
+			MatchValue: "^acme-[a-z+$",
+		},
+	}
+	errs := validate(check)
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "is not a valid regular expression")
+
+	check.MatchSpec.MatchValue = "^acme-[a-z]+$"
+	assert.Empty(t, validate(check))
+}
@@ -0,0 +1,245 @@
+package custom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"path"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// defaultRegoSeverity is used for policies whose metadata doesn't set a severity
+const defaultRegoSeverity = severity.Medium
+
+// regoPolicy is a policy loaded from a .rego file. Its metadata comes from the __rego_metadata__ rule of the policy,
+// and its findings from the deny rule, which is evaluated with each block as input.
+type regoPolicy struct {
+	Code           string
+	Summary        string
+	Impact         string
+	Resolution     string
+	Links          []string
+	Severity       severity.Severity
+	RequiredTypes  []string
+	RequiredLabels []string
+	filename       string
+	denyQuery      rego.PreparedEvalQuery
+}
+
+func loadRegoPolicies(customCheckDir string, loading map[string]string) ([]*regoPolicy, []string) {
+	files, err := listFiles(customCheckDir, `.*\.rego$`)
+	if err != nil {
+		return nil, []string{err.Error()}
+	}
+	var policies []*regoPolicy
+	var errorList []string
+	for _, file := range files {
+		policyPath := path.Join(customCheckDir, file.Name())
+		policy, err := loadRegoPolicy(policyPath)
+		if err != nil {
+			errorList = append(errorList, err.Error())
+			continue
+		}
+		if duplicate := checkForDuplicate(newRegoRule(policy).ID(), policy.Code, policyPath, loading); duplicate != "" {
+			errorList = append(errorList, duplicate)
+			continue
+		}
+		policies = append(policies, policy)
+	}
+	return policies, errorList
+}
+
+func loadRegoPolicy(policyPath string) (*regoPolicy, error) {
+	src, err := ioutil.ReadFile(policyPath)
+	if err != nil {
+		return nil, err
+	}
+	module, err := ast.ParseModule(policyPath, string(src))
+	if err != nil {
+		return nil, fmt.Errorf("policy %s is not valid: %w", policyPath, err)
+	}
+	if module == nil {
+		return nil, fmt.Errorf("policy %s is empty", policyPath)
+	}
+	pkg := module.Package.Path.String()
+
+	ctx := context.Background()
+	metadata, err := rego.New(
+		rego.Query(pkg+".__rego_metadata__"),
+		rego.ParsedModule(module),
+	).Eval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s is not valid: %w", policyPath, err)
+	}
+	if len(metadata) == 0 || len(metadata[0].Expressions) == 0 {
+		return nil, fmt.Errorf("policy %s has no __rego_metadata__ rule", policyPath)
+	}
+	policy, err := newRegoPolicy(metadata[0].Expressions[0].Value)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s: %w", policyPath, err)
+	}
+
+	policy.filename = policyPath
+	policy.denyQuery, err = rego.New(
+		rego.Query(pkg+".deny"),
+		rego.ParsedModule(module),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("policy %s is not valid: %w", policyPath, err)
+	}
+	return policy, nil
+}
+
+// newRegoPolicy reads the metadata of a policy, e.g.
+//
+//	__rego_metadata__ := {
+//	  "id": "CUS002",
+//	  "summary": "Buckets must be private",
+//	  "severity": "HIGH",
+//	  "required_types": ["resource"],
+//	  "required_labels": ["aws_s3_bucket"],
+//	}
+func newRegoPolicy(value interface{}) (*regoPolicy, error) {
+	metadata, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("__rego_metadata__ must be an object")
+	}
+	policy := &regoPolicy{
+		Code:       stringField(metadata, "id"),
+		Summary:    stringField(metadata, "summary"),
+		Impact:     stringField(metadata, "impact"),
+		Resolution: stringField(metadata, "resolution"),
+		Severity:   defaultRegoSeverity,
+	}
+	if policy.Code == "" {
+		return nil, errors.New("__rego_metadata__ has no id")
+	}
+	if sev := stringField(metadata, "severity"); sev != "" {
+		policy.Severity = severity.StringToSeverity(sev)
+		if policy.Severity == severity.None {
+			return nil, fmt.Errorf("__rego_metadata__ has an unknown severity '%s'", sev)
+		}
+	}
+	policy.Links = stringsField(metadata, "links")
+	policy.RequiredTypes = stringsField(metadata, "required_types")
+	policy.RequiredLabels = stringsField(metadata, "required_labels")
+	return policy, nil
+}
+
+func stringField(fields map[string]interface{}, name string) string {
+	if value, ok := fields[name].(string); ok {
+		return value
+	}
+	return ""
+}
+
+func stringsField(fields map[string]interface{}, name string) []string {
+	values, ok := fields[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	var strs []string
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			strs = append(strs, str)
+		}
+	}
+	return strs
+}
+
+func processFoundPolicies(policies []*regoPolicy) {
+	for _, policy := range policies {
+		debug.Log("Loading Rego policy: %s\n", policy.Code)
+		scanner.RegisterCheckRule(newRegoRule(policy))
+	}
+}
+
+// newRegoRule converts a Rego policy into a rule which is registered and run like any built-in rule
+func newRegoRule(policy *regoPolicy) rule.Rule {
+	return rule.Rule{
+		LegacyID:  policy.Code,
+		Service:   "custom",
+		ShortCode: policy.Code,
+		Documentation: rule.RuleDocumentation{
+			Summary:    policy.Summary,
+			Links:      policy.Links,
+			Impact:     policy.Impact,
+			Resolution: policy.Resolution,
+		},
+		Provider:        provider.CustomProvider,
+		RequiredTypes:   policy.RequiredTypes,
+		RequiredLabels:  policy.RequiredLabels,
+		DefaultSeverity: policy.Severity,
+		CheckFunc: func(set result.Set, rootBlock block.Block, _ block.Module) {
+			denials, err := policy.deny(rootBlock)
+			if err != nil {
+				debug.Log("Failed to evaluate Rego policy %s for %s: %s", policy.filename, rootBlock.FullName(), err)
+				return
+			}
+			for _, denial := range denials {
+				set.AddResult().
+					WithDescription("Rego policy %s failed for resource %s. %s", policy.Code, rootBlock.FullName(), denial.message).
+					WithSeverity(denial.severity)
+			}
+		},
+	}
+}
+
+type denial struct {
+	message  string
+	severity severity.Severity
+}
+
+// deny evaluates the deny rule of the policy with the block as input. Each message is either a string, or an object
+// with a msg and optionally a severity, which overrides the severity of the policy.
+func (policy *regoPolicy) deny(b block.Block) ([]denial, error) {
+	resultSet, err := policy.denyQuery.Eval(context.Background(), rego.EvalInput(BlockInput(b)))
+	if err != nil {
+		return nil, err
+	}
+	var denials []denial
+	for _, res := range resultSet {
+		for _, expression := range res.Expressions {
+			messages, ok := expression.Value.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("deny must be a set of messages")
+			}
+			for _, message := range messages {
+				d, err := policy.newDenial(message)
+				if err != nil {
+					return nil, err
+				}
+				denials = append(denials, d)
+			}
+		}
+	}
+	return denials, nil
+}
+
+func (policy *regoPolicy) newDenial(message interface{}) (denial, error) {
+	switch msg := message.(type) {
+	case string:
+		return denial{message: msg, severity: policy.Severity}, nil
+	case map[string]interface{}:
+		d := denial{message: stringField(msg, "msg"), severity: policy.Severity}
+		if sev := stringField(msg, "severity"); sev != "" {
+			if d.severity = severity.StringToSeverity(sev); d.severity == severity.None {
+				return d, fmt.Errorf("unknown severity '%s'", sev)
+			}
+		}
+		return d, nil
+	default:
+		return denial{}, fmt.Errorf("deny messages must be strings or objects with a msg, not %T", message)
+	}
+}
@@ -0,0 +1,65 @@
+package custom
+
+import (
+	"encoding/json"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// BlockInput converts a block into the JSON-friendly document which Rego policies are given as input. Attribute
+// values which can't be resolved are null.
+//
+//	{
+//	  "type": "resource",
+//	  "labels": ["aws_s3_bucket", "my-bucket"],
+//	  "type_label": "aws_s3_bucket",
+//	  "name_label": "my-bucket",
+//	  "name": "aws_s3_bucket.my-bucket",
+//	  "range": {"filename": "main.tf", "start_line": 1, "end_line": 4},
+//	  "attributes": {"acl": "private"},
+//	  "blocks": [{"type": "versioning", ...}]
+//	}
+func BlockInput(b block.Block) map[string]interface{} {
+	attributes := make(map[string]interface{})
+	for _, attr := range b.GetAttributes() {
+		attributes[attr.Name()] = attributeInput(attr)
+	}
+
+	nested := []interface{}{}
+	for _, child := range b.AllBlocks() {
+		nested = append(nested, BlockInput(child))
+	}
+
+	rng := b.Range()
+	return map[string]interface{}{
+		"type":       b.Type(),
+		"labels":     b.Labels(),
+		"type_label": b.TypeLabel(),
+		"name_label": b.NameLabel(),
+		"name":       b.FullName(),
+		"range": map[string]interface{}{
+			"filename":   rng.Filename,
+			"start_line": rng.StartLine,
+			"end_line":   rng.EndLine,
+		},
+		"attributes": attributes,
+		"blocks":     nested,
+	}
+}
+
+func attributeInput(attr block.Attribute) interface{} {
+	val := attr.Value()
+	if !val.IsWhollyKnown() {
+		return nil
+	}
+	data, err := ctyjson.Marshal(val, val.Type())
+	if err != nil {
+		return nil
+	}
+	var input interface{}
+	if err := json.Unmarshal(data, &input); err != nil {
+		return nil
+	}
+	return input
+}
@@ -0,0 +1,48 @@
+package custom
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockInputIncludesAttributesAndNestedBlocks(t *testing.T) {
+	modules := ParseFromSource(`
+resource "aws_s3_bucket" "my-bucket" {
+	acl = "private"
+	tags = {
+		Owner = "security"
+	}
+	versioning {
+		enabled = true
+	}
+}
+`)
+	require.Len(t, modules, 1)
+	blocks := modules[0].GetBlocks()
+	require.Len(t, blocks, 1)
+
+	input := BlockInput(blocks[0])
+
+	// the input must survive a round trip through JSON, as that's how it's handed to policies
+	data, err := json.Marshal(input)
+	require.NoError(t, err)
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	assert.Equal(t, "resource", decoded["type"])
+	assert.Equal(t, "aws_s3_bucket.my-bucket", decoded["name"])
+	assert.Equal(t, "aws_s3_bucket", decoded["type_label"])
+	assert.Equal(t, "my-bucket", decoded["name_label"])
+	attributes := decoded["attributes"].(map[string]interface{})
+	assert.Equal(t, "private", attributes["acl"])
+	assert.Equal(t, map[string]interface{}{"Owner": "security"}, attributes["tags"])
+
+	nested := decoded["blocks"].([]interface{})
+	require.Len(t, nested, 1)
+	versioning := nested[0].(map[string]interface{})
+	assert.Equal(t, "versioning", versioning["type"])
+	assert.Equal(t, true, versioning["attributes"].(map[string]interface{})["enabled"])
+}
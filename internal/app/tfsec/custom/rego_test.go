@@ -0,0 +1,169 @@
+package custom
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func resultsForRule(results []result.Result, ruleID string) []result.Result {
+	var found []result.Result
+	for _, res := range results {
+		if res.RuleID == ruleID {
+			found = append(found, res)
+		}
+	}
+	return found
+}
+
+func TestLoadRegoPolicy(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "acl.rego"), []byte(`
+package custom.cus300
+
+__rego_metadata__ := {
+	"id": "CUS300",
+	"summary": "Buckets must be private",
+	"severity": "HIGH",
+	"required_types": ["resource"],
+	"required_labels": ["aws_s3_bucket"],
+}
+
+deny[msg] {
+	input.attributes.acl != "private"
+	msg := sprintf("the bucket has a %s ACL", [input.attributes.acl])
+}
+`), 0600))
+
+	require.NoError(t, Load(dir))
+
+	results := resultsForRule(scanTerraform(t, `
+resource "aws_s3_bucket" "public" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket" "private" {
+	acl = "private"
+}
+`), "custom-custom-cus300")
+	require.Len(t, results, 1)
+	assert.Equal(t, "aws_s3_bucket.public", results[0].ResourceName())
+	assert.Equal(t, severity.High, results[0].Severity)
+	assert.Contains(t, results[0].Description, "the bucket has a public-read ACL")
+	assert.Equal(t, "Buckets must be private", results[0].RuleSummary)
+}
+
+func TestRegoPolicySeverity(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "tags.rego"), []byte(`
+package custom.cus301
+
+__rego_metadata__ := {
+	"id": "CUS301",
+	"summary": "Buckets must be tagged",
+	"required_types": ["resource"],
+	"required_labels": ["aws_s3_bucket"],
+}
+
+deny[msg] {
+	not input.attributes.tags
+	msg := "the bucket has no tags"
+}
+
+deny[msg] {
+	input.attributes.tags
+	not input.attributes.tags.Owner
+	msg := {"msg": "the bucket has no owner", "severity": "LOW"}
+}
+`), 0600))
+
+	require.NoError(t, Load(dir))
+
+	results := resultsForRule(scanTerraform(t, `
+resource "aws_s3_bucket" "untagged" {
+}
+
+resource "aws_s3_bucket" "unowned" {
+	tags = {
+		Team = "security"
+	}
+}
+`), "custom-custom-cus301")
+	severities := make(map[string]severity.Severity)
+	for _, res := range results {
+		severities[res.ResourceName()] = res.Severity
+	}
+	assert.Equal(t, map[string]severity.Severity{
+		"aws_s3_bucket.untagged": severity.Medium,
+		"aws_s3_bucket.unowned":  severity.Low,
+	}, severities)
+}
+
+func TestLoadRejectsInvalidRegoPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "broken.rego")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+package custom.cus302
+
+deny[msg] {
+	msg :=
+}
+`), 0600))
+
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path)
+	assert.Contains(t, err.Error(), "is not valid")
+}
+
+func TestLoadRejectsRegoPolicyWithoutMetadata(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "anonymous.rego")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`
+package custom.cus303
+
+deny[msg] {
+	msg := "always"
+}
+`), 0600))
+
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no __rego_metadata__ rule")
+}
+
+func TestLoadRejectsRegoPolicyClashingWithCheck(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "acl_tfchecks.json"), []byte(`{
+  "checks": [
+    {
+      "code": "CUS304",
+      "description": "Buckets must be private",
+      "requiredTypes": ["resource"],
+      "requiredLabels": ["aws_s3_bucket"],
+      "severity": "HIGH",
+      "matchSpec": {"name": "acl", "action": "equals", "value": "private"}
+    }
+  ]
+}`), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "acl.rego"), []byte(`
+package custom.cus304
+
+__rego_metadata__ := {"id": "CUS304"}
+
+deny[msg] {
+	msg := "always"
+}
+`), 0600))
+
+	// the JSON check is registered first, so the policy clashes with it
+	err := Load(dir)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(dir, "acl.rego"))
+	assert.Contains(t, err.Error(), "is already registered")
+}
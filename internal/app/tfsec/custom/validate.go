@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/pkg/severity"
 )
 
@@ -30,7 +31,7 @@ func Validate(checkFilePath string) error {
 					return errors.New("check json is not valid")
 				}
 				errorStrings := getErrorStrings(errs)
-				return fmt.Errorf("check failed with the following errors;\n\n - %s\n\n%s\n", errorStrings, jsonContent)
+				return fmt.Errorf("check %s in %s failed with the following errors;\n\n - %s\n\n%s\n", check.Code, checkFilePath, errorStrings, jsonContent)
 			}
 			return nil
 		}(check); err != nil {
@@ -68,6 +69,9 @@ func validate(check *Check) []error {
 	if len(check.RequiredLabels) == 0 {
 		checkErrors = append(checkErrors, errors.New("check.RequiredLabels requires a value"))
 	}
+	if check.MatchSpec == nil {
+		return append(checkErrors, errors.New("check.MatchSpec requires a value"))
+	}
 	return validateMatchSpec(check.MatchSpec, check, checkErrors)
 }
 
@@ -80,6 +84,12 @@ func validateMatchSpec(spec *MatchSpec, check *Check, checkErrors []error) []err
 		checkErrors = append(checkErrors, errors.New("matchSpec.Name requires a value"))
 	}
 
+	if spec.Action == RegexMatches {
+		if _, err := block.CompileRegex(fmt.Sprintf("%v", spec.MatchValue)); err != nil {
+			checkErrors = append(checkErrors, fmt.Errorf("matchSpec.MatchValue[%v] is not a valid regular expression: %s", spec.MatchValue, err))
+		}
+	}
+
 	// if the check is one of `or`, `and`, then all PredicateMatchSpec's must also be valid
 	if spec.Action == "or" || spec.Action == "and" {
 		for _, predicateMatchSpec := range spec.PredicateMatchSpec {
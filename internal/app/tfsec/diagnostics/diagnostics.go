@@ -0,0 +1,129 @@
+package diagnostics
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// modules can be loaded more than once, so the recorded diagnostics are guarded by lock and de-duplicated
+var lock sync.Mutex
+
+var diagnostics []Diagnostic
+
+type Severity string
+
+// SummaryModuleLoadFailed is the summary of a diagnostic for a module which couldn't be loaded
+const SummaryModuleLoadFailed = "Failed to load module"
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+)
+
+// Diagnostic is a problem with the configuration itself, such as a file which can't be parsed, rather than a finding
+// of a check. Diagnostics explain which parts of the configuration couldn't be scanned.
+type Diagnostic struct {
+	Severity Severity     `json:"severity"`
+	Summary  string       `json:"summary"`
+	Detail   string       `json:"detail,omitempty"`
+	Location *block.Range `json:"location,omitempty"`
+}
+
+// String formats the diagnostic with its location, as used in warnings
+func (d Diagnostic) String() string {
+	message := d.Summary
+	if d.Detail != "" {
+		message = fmt.Sprintf("%s; %s", message, d.Detail)
+	}
+	if d.Location == nil {
+		return message
+	}
+	return fmt.Sprintf("%s: %s", d.Location, message)
+}
+
+// Warning formats the diagnostic as the warning written to stderr when it is first recorded
+func (d Diagnostic) Warning() string {
+	if d.Summary == SummaryModuleLoadFailed {
+		return fmt.Sprintf("WARNING: %s: %s", SummaryModuleLoadFailed, d.Detail)
+	}
+	return fmt.Sprintf("WARNING: HCL error: %s", d)
+}
+
+// Add records a diagnostic, returning false if the same diagnostic was already recorded
+func Add(diagnostic Diagnostic) bool {
+	lock.Lock()
+	defer lock.Unlock()
+	for _, existing := range diagnostics {
+		if existing.Severity == diagnostic.Severity && existing.Summary == diagnostic.Summary &&
+			existing.Detail == diagnostic.Detail && sameLocation(existing.Location, diagnostic.Location) {
+			return false
+		}
+	}
+	diagnostics = append(diagnostics, diagnostic)
+	return true
+}
+
+// FromHCL converts the diagnostics reported by the HCL parser, keeping the location of each where it is known
+func FromHCL(hclDiagnostics hcl.Diagnostics) []Diagnostic {
+	var converted []Diagnostic
+	for _, hclDiagnostic := range hclDiagnostics {
+		if hclDiagnostic == nil {
+			continue
+		}
+		diagnostic := Diagnostic{
+			Severity: Warning,
+			Summary:  hclDiagnostic.Summary,
+			Detail:   hclDiagnostic.Detail,
+		}
+		if hclDiagnostic.Severity == hcl.DiagError {
+			diagnostic.Severity = Error
+		}
+		if hclDiagnostic.Subject != nil {
+			diagnostic.Location = &block.Range{
+				Filename:  hclDiagnostic.Subject.Filename,
+				StartLine: hclDiagnostic.Subject.Start.Line,
+				EndLine:   hclDiagnostic.Subject.End.Line,
+			}
+		}
+		converted = append(converted, diagnostic)
+	}
+	return converted
+}
+
+// All returns the recorded diagnostics, ordered by location
+func All() []Diagnostic {
+	lock.Lock()
+	defer lock.Unlock()
+	all := make([]Diagnostic, len(diagnostics))
+	copy(all, diagnostics)
+	sort.SliceStable(all, func(i, j int) bool {
+		a, b := all[i].Location, all[j].Location
+		switch {
+		case a == nil || b == nil:
+			return a == nil && b != nil
+		case a.Filename != b.Filename:
+			return a.Filename < b.Filename
+		default:
+			return a.StartLine < b.StartLine
+		}
+	})
+	return all
+}
+
+func sameLocation(a, b *block.Range) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// Reset discards the recorded diagnostics
+func Reset() {
+	lock.Lock()
+	defer lock.Unlock()
+	diagnostics = nil
+}
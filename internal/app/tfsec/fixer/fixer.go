@@ -0,0 +1,94 @@
+package fixer
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+)
+
+// Fix rewrites the templates to resolve failed results whose rules have a FixFunc. Only the blocks raising the
+// results are edited, so formatting and comments elsewhere are left intact. The results which were fixed are returned
+// separately from those which remain.
+func Fix(results []result.Result) (fixed []result.Result, remaining []result.Result, err error) {
+	byFile := make(map[string][]result.Result)
+	var filenames []string
+	for _, res := range results {
+		filename, ok := fixableFile(res)
+		if !ok {
+			remaining = append(remaining, res)
+			continue
+		}
+		if _, found := byFile[filename]; !found {
+			filenames = append(filenames, filename)
+		}
+		byFile[filename] = append(byFile[filename], res)
+	}
+
+	for _, filename := range filenames {
+		fileFixed, fileRemaining, err := fixFile(filename, byFile[filename])
+		if err != nil {
+			return nil, nil, err
+		}
+		fixed = append(fixed, fileFixed...)
+		remaining = append(remaining, fileRemaining...)
+	}
+	return fixed, remaining, nil
+}
+
+// fixableFile finds the file to edit for the result, if its rule has a fix and the file is one of the user's templates
+func fixableFile(res result.Result) (string, bool) {
+	if res.Status != result.Failed || len(res.Blocks()) == 0 {
+		return "", false
+	}
+	r, err := scanner.GetRuleById(res.RuleID)
+	if err != nil || r.FixFunc == nil {
+		return "", false
+	}
+	filename := res.Blocks()[0].Range().Filename
+	if !strings.HasSuffix(filename, ".tf") || strings.Contains(filename, fmt.Sprintf("%c.terraform%c", os.PathSeparator, os.PathSeparator)) {
+		return "", false
+	}
+	return filename, true
+}
+
+func fixFile(filename string, results []result.Result) (fixed []result.Result, remaining []result.Result, err error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	file, diag := hclwrite.ParseConfig(src, filename, hcl.InitialPos)
+	if diag.HasErrors() {
+		return nil, nil, diag
+	}
+
+	for _, res := range results {
+		resourceBlock := res.Blocks()[0]
+		target := file.Body().FirstMatchingBlock(resourceBlock.Type(), resourceBlock.Labels())
+		r, _ := scanner.GetRuleById(res.RuleID)
+		if target == nil || !r.FixFunc(target.Body()) {
+			remaining = append(remaining, res)
+			continue
+		}
+		debug.Log("Fixed %s in %s", res.RuleID, resourceBlock.FullName())
+		fixed = append(fixed, res)
+	}
+
+	if output := file.Bytes(); !bytes.Equal(output, src) {
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := ioutil.WriteFile(filename, output, info.Mode()); err != nil {
+			return nil, nil, err
+		}
+	}
+	return fixed, remaining, nil
+}
@@ -0,0 +1,75 @@
+package fixer_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/fixer"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scanDir(t *testing.T, dir string) []result.Result {
+	modules, err := parser.New(dir, parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+	return scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+}
+
+func Test_FixRewritesFixableFindings(t *testing.T) {
+	path := testutil.CreateTestFile("main.tf", `
+# the volume holds customer data
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40 # GiB
+}
+
+resource "aws_eks_cluster" "cluster" {
+  name     = "cluster"
+  role_arn = var.cluster_arn
+}
+`)
+	dir := filepath.Dir(path)
+
+	results := scanDir(t, dir)
+	testutil.AssertCheckCode(t, "aws-ebs-enable-volume-encryption", "", results)
+	testutil.AssertCheckCode(t, "aws-eks-no-public-cluster-access", "", results)
+
+	fixed, remaining, err := fixer.Fix(results)
+	require.NoError(t, err)
+	require.Len(t, fixed, 1)
+	assert.Equal(t, "aws-ebs-enable-volume-encryption", fixed[0].RuleID)
+	testutil.AssertCheckCode(t, "aws-eks-no-public-cluster-access", "aws-ebs-enable-volume-encryption", remaining)
+
+	content, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "# the volume holds customer data")
+	assert.Contains(t, string(content), "size              = 40 # GiB")
+	assert.Contains(t, string(content), "encrypted         = true")
+
+	testutil.AssertCheckCode(t, "aws-eks-no-public-cluster-access", "aws-ebs-enable-volume-encryption", scanDir(t, dir))
+}
+
+func Test_FixUpdatesNestedBlocks(t *testing.T) {
+	path := testutil.CreateTestFile("main.tf", `
+resource "aws_eks_cluster" "cluster" {
+  name     = "cluster"
+  role_arn = var.cluster_arn
+
+  vpc_config {
+    endpoint_public_access = true
+  }
+}
+`)
+
+	fixed, _, err := fixer.Fix(scanDir(t, filepath.Dir(path)))
+	require.NoError(t, err)
+	require.Len(t, fixed, 1)
+
+	testutil.AssertCheckCode(t, "", "aws-eks-no-public-cluster-access", scanDir(t, filepath.Dir(path)))
+}
@@ -12,7 +12,7 @@ import (
 func FormatCSV(w io.Writer, results []result.Result, _ string, _ ...FormatterOption) error {
 
 	records := [][]string{
-		{"file", "start_line", "end_line", "rule_id", "severity", "description", "link", "passed"},
+		{"rule_id", "service", "severity", "resource", "file", "start_line", "end_line", "description", "link", "passed"},
 	}
 
 	for _, res := range results {
@@ -21,11 +21,13 @@ func FormatCSV(w io.Writer, results []result.Result, _ string, _ ...FormatterOpt
 			link = res.Links[0]
 		}
 		records = append(records, []string{
+			res.RuleID,
+			res.RuleService,
+			string(res.Severity),
+			res.ResourceName(),
 			res.Range().Filename,
 			strconv.Itoa(res.Range().StartLine),
 			strconv.Itoa(res.Range().EndLine),
-			res.RuleID,
-			string(res.Severity),
 			res.Description,
 			link,
 			strconv.FormatBool(res.Status == result.Passed),
@@ -3,7 +3,7 @@ package formatters
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
@@ -21,14 +21,13 @@ import (
 
 var severityFormat map[severity.Severity]string
 
+const defaultCodeLines = 3
+
 func FormatDefault(_ io.Writer, results []result.Result, _ string, options ...FormatterOption) error {
 	if severityFormat == nil {
-		severityFormat = map[severity.Severity]string{
-			severity.Low:      tml.Sprintf("<white>%s</white>", severity.Low),
-			severity.Medium:   tml.Sprintf("<yellow>%s</yellow>", severity.Medium),
-			severity.High:     tml.Sprintf("<red>%s</red>", severity.High),
-			severity.Critical: tml.Sprintf("<bold><red>%s</red></bold>", severity.Critical),
-			"":                tml.Sprintf("<white>UNKNOWN</white>"),
+		severityFormat = make(map[severity.Severity]string)
+		for sev := range severityTheme {
+			severityFormat[sev] = formatSeverity(sev)
 		}
 	}
 
@@ -37,6 +36,7 @@ func FormatDefault(_ io.Writer, results []result.Result, _ string, options ...Fo
 	includePassedChecks := false
 
 	var showGif bool
+	var groupByResource bool
 
 	for _, option := range options {
 		switch option {
@@ -47,6 +47,8 @@ func FormatDefault(_ io.Writer, results []result.Result, _ string, options ...Fo
 			showSuccessOutput = false
 		case PassingGif:
 			showGif = true
+		case GroupByResource:
+			groupByResource = true
 		}
 	}
 
@@ -60,6 +62,7 @@ func FormatDefault(_ io.Writer, results []result.Result, _ string, options ...Fo
 		if showStatistics {
 			_ = tml.Printf("\n")
 			printStatistics()
+			printFooter(NewSummary(results))
 		}
 		if showSuccessOutput {
 			terminal.PrintSuccessf("\nNo problems detected!\n\n")
@@ -68,12 +71,17 @@ func FormatDefault(_ io.Writer, results []result.Result, _ string, options ...Fo
 	}
 
 	fmt.Println("")
-	for i, res := range results {
-		printResult(res, i, includePassedChecks)
+	if groupByResource {
+		printResultsByResource(results, includePassedChecks)
+	} else {
+		for i, res := range results {
+			printResult(res, i, includePassedChecks)
+		}
 	}
 
 	if showStatistics {
 		printStatistics()
+		printFooter(NewSummary(results))
 	}
 
 	terminal.PrintErrorf("\n  %d potential problems detected.\n\n", len(results)-countPassedResults(results))
@@ -100,6 +108,9 @@ func printResult(res result.Result, i int, includePassedChecks bool) {
 
 `, res.RuleID, severity, res.Description, res.Range().String())
 	highlightCode(res)
+	if res.Occurrences > 1 {
+		_ = tml.Printf("  <white>Occurrences:</white> <blue>%d</blue>\n", res.Occurrences)
+	}
 	if res.LegacyRuleID != "" {
 		_ = tml.Printf("  <white>Legacy ID:  </white><blue>%s</blue>\n", res.LegacyRuleID)
 	}
@@ -109,6 +120,12 @@ func printResult(res result.Result, i int, includePassedChecks bool) {
 	if res.Resolution != "" {
 		_ = tml.Printf("  <white>Resolution: </white><blue>%s</blue>\n", res.Resolution)
 	}
+	if res.SuggestedFix != "" {
+		_ = tml.Printf("\n  <white>Suggested fix:</white>\n")
+		for _, line := range strings.Split(res.SuggestedFix, "\n") {
+			_ = tml.Printf("    <green>%s</green>\n", line)
+		}
+	}
 	if len(res.Links) > 0 {
 		_ = tml.Printf("\n  <white>More Info:</white>")
 	}
@@ -118,6 +135,32 @@ func printResult(res result.Result, i int, includePassedChecks bool) {
 	fmt.Printf("\n\n")
 }
 
+func printResultsByResource(results []result.Result, includePassedChecks bool) {
+	var resources []string
+	grouped := make(map[string][]result.Result)
+	for _, res := range results {
+		name := res.ResourceName()
+		if _, ok := grouped[name]; !ok {
+			resources = append(resources, name)
+		}
+		grouped[name] = append(grouped[name], res)
+	}
+	sort.Strings(resources)
+
+	var i int
+	for _, name := range resources {
+		resourceResults := grouped[name]
+		sort.SliceStable(resourceResults, func(a, b int) bool {
+			return resourceResults[a].Severity.Rank() > resourceResults[b].Severity.Rank()
+		})
+		_ = tml.Printf("  <bold>Resource: %s</bold>\n\n", name)
+		for _, res := range resourceResults {
+			printResult(res, i, includePassedChecks)
+			i++
+		}
+	}
+}
+
 func printStatistics() {
 
 	metrics.Add(metrics.FilesLoaded, parser.CountFiles())
@@ -139,6 +182,8 @@ func printStatistics() {
 		metrics.FilesLoaded,
 		metrics.BlocksLoaded,
 		metrics.ModuleLoadCount,
+		metrics.ChecksRun,
+		metrics.ChecksPassed,
 	} {
 		_ = tml.Printf("  <blue>%-20s</blue> %d\n", name, counts[name])
 	}
@@ -149,6 +194,7 @@ func printStatistics() {
 		severity.High,
 		severity.Medium,
 		severity.Low,
+		severity.Info,
 	} {
 		count := metrics.CountSeverity(sev)
 		_ = tml.Printf("  <blue>%-20s</blue> %d\n", strings.ToLower(string(sev)), count)
@@ -156,37 +202,48 @@ func printStatistics() {
 	_ = tml.Printf("  <blue>%-20s</blue> %d\n", "ignored", counts[metrics.IgnoredChecks])
 }
 
+// printFooter sums up the scan in a line, so a scan without findings still shows that files were checked
+func printFooter(summary Summary) {
+	var findings []string
+	for _, sev := range []severity.Severity{
+		severity.Critical,
+		severity.High,
+		severity.Medium,
+		severity.Low,
+		severity.Info,
+	} {
+		if count := summary.BySeverity[string(sev)]; count > 0 {
+			findings = append(findings, fmt.Sprintf("%d %s", count, strings.ToLower(string(sev))))
+		}
+	}
+	var breakdown string
+	if len(findings) > 0 {
+		breakdown = fmt.Sprintf(" (%s)", strings.Join(findings, ", "))
+	}
+	_ = tml.Printf("\n  <bold>%d checks run against %d files, %d passed, %d findings%s</bold>\n",
+		summary.ChecksRun, summary.FilesScanned, summary.ChecksPassed, summary.Failed, breakdown)
+}
+
 // highlight the lines of code which caused a problem, if available
 func highlightCode(result result.Result) {
 
-	data, err := ioutil.ReadFile(result.Range().Filename)
-	if err != nil {
+	lines := codeLines(result)
+	if len(lines) == 0 {
 		return
 	}
 
-	lines := append([]string{""}, strings.Split(string(data), "\n")...)
-
-	start := result.Range().StartLine - 3
-	if start <= 0 {
-		start = 1
-	}
-	end := result.Range().EndLine + 3
-	if end >= len(lines) {
-		end = len(lines) - 1
-	}
-
-	for lineNo := start; lineNo <= end; lineNo++ {
-		_ = tml.Printf("  <blue>% 6d</blue> | ", lineNo)
-		if lineNo >= result.Range().StartLine && lineNo <= result.Range().EndLine {
+	for _, line := range lines {
+		_ = tml.Printf("  <blue>% 6d</blue> | ", line.Number)
+		if line.Number >= result.Range().StartLine && line.Number <= result.Range().EndLine {
 			if result.Passed() {
-				_ = tml.Printf("<bold><green>%s</green></bold>", lines[lineNo])
-			} else if lineNo == result.Range().StartLine && result.RangeAnnotation != "" {
-				_ = tml.Printf("<bold><red>%s</red>    <blue>%s</blue></bold>", lines[lineNo], result.RangeAnnotation)
+				_ = tml.Printf("<bold><green>%s</green></bold>", line.Content)
+			} else if line.Number == result.Range().StartLine && result.RangeAnnotation != "" {
+				_ = tml.Printf("<bold><red>%s</red>    <blue>%s</blue></bold>", line.Content, result.RangeAnnotation)
 			} else {
-				_ = tml.Printf("<bold><red>%s</red></bold>", lines[lineNo])
+				_ = tml.Printf("<bold><red>%s</red></bold>", line.Content)
 			}
 		} else {
-			_ = tml.Printf("<yellow>%s</yellow>", lines[lineNo])
+			_ = tml.Printf("<yellow>%s</yellow>", line.Content)
 		}
 
 		fmt.Printf("\n")
@@ -195,6 +252,14 @@ func highlightCode(result result.Result) {
 	fmt.Println("")
 }
 
+// codeLines returns the snippet captured on the result, or reads the default amount of context if none was captured
+func codeLines(res result.Result) []result.CodeLine {
+	if len(res.Code) > 0 {
+		return res.Code
+	}
+	return res.Snippet(defaultCodeLines)
+}
+
 func countPassedResults(results []result.Result) int {
 	passed := 0
 
@@ -12,6 +12,8 @@ const (
 	ConciseOutput FormatterOption = iota
 	IncludePassed
 	PassingGif
+	GroupByResource
+	IncludeRuleDocs
 )
 
 // Formatter formats scan results into a specific format
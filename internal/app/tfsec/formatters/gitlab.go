@@ -0,0 +1,181 @@
+package formatters
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/aquasecurity/tfsec/version"
+)
+
+// see https://gitlab.com/gitlab-org/security-products/security-report-schemas/-/blob/master/dist/sast-report-format.json
+const gitlabSchemaVersion = "15.0.0"
+
+type gitlabReport struct {
+	Version         string                `json:"version"`
+	Scan            gitlabScan            `json:"scan"`
+	Vulnerabilities []gitlabVulnerability `json:"vulnerabilities"`
+}
+
+type gitlabScan struct {
+	Analyzer  gitlabScanner `json:"analyzer"`
+	Scanner   gitlabScanner `json:"scanner"`
+	Type      string        `json:"type"`
+	StartTime string        `json:"start_time"`
+	EndTime   string        `json:"end_time"`
+	Status    string        `json:"status"`
+}
+
+// gitlabTimeFormat is the format of the scan times required by the report schema, which has no time zone
+const gitlabTimeFormat = "2006-01-02T15:04:05"
+
+type gitlabScanner struct {
+	ID      string       `json:"id"`
+	Name    string       `json:"name"`
+	URL     string       `json:"url"`
+	Version string       `json:"version"`
+	Vendor  gitlabVendor `json:"vendor"`
+}
+
+type gitlabVendor struct {
+	Name string `json:"name"`
+}
+
+type gitlabVulnerability struct {
+	ID          string             `json:"id"`
+	Category    string             `json:"category"`
+	Name        string             `json:"name"`
+	Message     string             `json:"message"`
+	Description string             `json:"description"`
+	Severity    string             `json:"severity"`
+	Solution    string             `json:"solution,omitempty"`
+	Location    gitlabLocation     `json:"location"`
+	Identifiers []gitlabIdentifier `json:"identifiers"`
+	Links       []gitlabLink       `json:"links,omitempty"`
+}
+
+type gitlabLocation struct {
+	File      string `json:"file"`
+	StartLine int    `json:"start_line"`
+	EndLine   int    `json:"end_line,omitempty"`
+}
+
+type gitlabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	URL   string `json:"url,omitempty"`
+}
+
+type gitlabLink struct {
+	URL string `json:"url"`
+}
+
+func FormatGitLab(w io.Writer, results []result.Result, baseDir string, _ ...FormatterOption) error {
+
+	scannerVersion := version.Version
+	if scannerVersion == "" {
+		scannerVersion = "development"
+	}
+
+	// tfsec is both the analyzer which produced the report and the scanner which found the vulnerabilities
+	tool := gitlabScanner{
+		ID:      "tfsec",
+		Name:    "tfsec",
+		URL:     "https://tfsec.dev",
+		Version: scannerVersion,
+		Vendor: gitlabVendor{
+			Name: "tfsec",
+		},
+	}
+
+	report := gitlabReport{
+		Version: gitlabSchemaVersion,
+		Scan: gitlabScan{
+			Analyzer:  tool,
+			Scanner:   tool,
+			Type:      "sast",
+			StartTime: metrics.StartTime().UTC().Format(gitlabTimeFormat),
+			EndTime:   time.Now().UTC().Format(gitlabTimeFormat),
+			Status:    "success",
+		},
+		Vulnerabilities: []gitlabVulnerability{},
+	}
+
+	for _, res := range results {
+		if res.Passed() {
+			continue
+		}
+
+		filename := res.Range().Filename
+		if relativePath, err := filepath.Rel(baseDir, filename); err == nil {
+			filename = relativePath
+		}
+
+		var links []gitlabLink
+		for _, link := range res.Links {
+			links = append(links, gitlabLink{URL: link})
+		}
+
+		identifier := gitlabIdentifier{
+			Type:  "tfsec_rule_id",
+			Name:  res.RuleID,
+			Value: res.RuleID,
+		}
+		if len(res.Links) > 0 {
+			identifier.URL = res.Links[0]
+		}
+
+		report.Vulnerabilities = append(report.Vulnerabilities, gitlabVulnerability{
+			ID:          gitlabVulnerabilityID(res.RuleID, filename, res.Range().StartLine),
+			Category:    "sast",
+			Name:        res.RuleSummary,
+			Message:     res.Description,
+			Description: res.Impact,
+			Severity:    gitlabSeverity(res.Severity),
+			Solution:    res.Resolution,
+			Location: gitlabLocation{
+				File:      filename,
+				StartLine: res.Range().StartLine,
+				EndLine:   res.Range().EndLine,
+			},
+			Identifiers: []gitlabIdentifier{identifier},
+			Links:       links,
+		})
+	}
+
+	jsonWriter := json.NewEncoder(w)
+	jsonWriter.SetIndent("", "\t")
+
+	return jsonWriter.Encode(report)
+}
+
+// gitlabVulnerabilityID is stable across runs so GitLab can track findings between pipelines
+func gitlabVulnerabilityID(ruleID string, filename string, line int) string {
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s:%s:%d", ruleID, filename, line)))
+	return hex.EncodeToString(hash[:])
+}
+
+func gitlabSeverity(sev severity.Severity) string {
+	switch sev {
+	case severity.Critical:
+		return "Critical"
+	case severity.High:
+		return "High"
+	case severity.Medium:
+		return "Medium"
+	case severity.Low:
+		return "Low"
+	case severity.Info:
+		return "Info"
+	default:
+		return "Unknown"
+	}
+}
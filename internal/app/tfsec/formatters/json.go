@@ -3,17 +3,88 @@ package formatters
 import (
 	"encoding/json"
 	"io"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/coverage"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/version"
 )
 
+// JSONSchemaVersion describes the shape of JSONOutput. It is only bumped when a field is removed, renamed
+// or changes type, so consumers can safely branch their parsing on it.
+const JSONSchemaVersion = "1.0.0"
+
 type JSONOutput struct {
-	Results []result.Result `json:"results"`
+	SchemaVersion string                   `json:"schema_version"`
+	TfsecVersion  string                   `json:"tfsec_version"`
+	Results       []result.Result          `json:"results"`
+	Summary       Summary                  `json:"summary"`
+	Passed        []coverage.Record        `json:"passed,omitempty"`
+	Skipped       []coverage.Record        `json:"skipped,omitempty"`
+	Errors        []diagnostics.Diagnostic `json:"errors,omitempty"`
+}
+
+// withFingerprints returns a copy of the results with the fingerprint of each recorded, so external systems can
+// track findings across commits
+func withFingerprints(results []result.Result) []result.Result {
+	if results == nil {
+		return nil
+	}
+	fingerprinted := make([]result.Result, len(results))
+	for i, res := range results {
+		if res.FingerprintID == "" {
+			res.WithFingerprint()
+		}
+		fingerprinted[i] = res
+	}
+	return fingerprinted
+}
+
+// withRuleDocs embeds the documentation of the rule which raised each result. Results from rules which are no longer
+// registered, such as those loaded from a previous run, are left without documentation.
+func withRuleDocs(results []result.Result, options []FormatterOption) []result.Result {
+	if !hasOption(options, IncludeRuleDocs) {
+		return results
+	}
+	for i, res := range results {
+		r, err := scanner.GetRuleById(res.RuleID)
+		if err != nil {
+			continue
+		}
+		res.RuleDocs = &result.RuleDocs{
+			Summary:     r.Documentation.Summary,
+			Explanation: strings.TrimSpace(r.Documentation.Explanation),
+			Impact:      r.Documentation.Impact,
+			Resolution:  r.Documentation.Resolution,
+		}
+		results[i] = res
+	}
+	return results
+}
+
+func hasOption(options []FormatterOption, option FormatterOption) bool {
+	for _, o := range options {
+		if o == option {
+			return true
+		}
+	}
+	return false
 }
 
 func FormatJSON(w io.Writer, results []result.Result, _ string, options ...FormatterOption) error {
 	jsonWriter := json.NewEncoder(w)
 	jsonWriter.SetIndent("", "\t")
 
-	return jsonWriter.Encode(JSONOutput{results})
+	return jsonWriter.Encode(JSONOutput{
+		SchemaVersion: JSONSchemaVersion,
+		TfsecVersion:  version.Version,
+		Results:       withRuleDocs(withFingerprints(results), options),
+		Summary:       NewSummary(results),
+		Passed:        coverage.Records(coverage.Passed),
+		Skipped:       coverage.Records(coverage.NotApplicable),
+		Errors:        diagnostics.All(),
+	})
 }
@@ -0,0 +1,55 @@
+package formatters
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+type flusher interface {
+	Flush() error
+}
+
+// JSONLinesWriter writes results as JSON objects on their own lines one at a time, so they can be written while the
+// scan is still running
+type JSONLinesWriter struct {
+	w       io.Writer
+	encoder *json.Encoder
+	options []FormatterOption
+}
+
+// NewJSONLinesWriter creates a JSONLinesWriter which writes to w
+func NewJSONLinesWriter(w io.Writer, options ...FormatterOption) *JSONLinesWriter {
+	return &JSONLinesWriter{
+		w:       w,
+		encoder: json.NewEncoder(w),
+		options: options,
+	}
+}
+
+// Write encodes the result as a line, and flushes it to the underlying writer if that is buffered
+func (writer *JSONLinesWriter) Write(res result.Result) error {
+	for _, res := range withRuleDocs(withFingerprints([]result.Result{res}), writer.options) {
+		if err := writer.encoder.Encode(res); err != nil {
+			return err
+		}
+	}
+	if f, ok := writer.w.(flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// FormatJSONLines writes each result as a JSON object on its own line, so the output can be consumed line by line
+// rather than parsed as a whole. The CLI streams json-lines output through a JSONLinesWriter as the scan finds results,
+// and only uses FormatJSONLines when the results have to be complete before any are written.
+func FormatJSONLines(w io.Writer, results []result.Result, _ string, options ...FormatterOption) error {
+	writer := NewJSONLinesWriter(w, options...)
+	for _, res := range results {
+		if err := writer.Write(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
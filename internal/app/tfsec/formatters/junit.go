@@ -4,8 +4,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
-	"io/ioutil"
-	"strings"
+	"sort"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
 )
@@ -13,6 +12,15 @@ import (
 // see https://github.com/windyroad/JUnit-Schema/blob/master/JUnit.xsd
 // tested with CircleCI
 
+// JUnitTestSuites is the root element of the report and contains a test suite per service.
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Failures string           `xml:"failures,attr"`
+	Tests    string           `xml:"tests,attr"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+}
+
 // JUnitTestSuite is a single JUnit test suite which may contain many
 // testcases.
 type JUnitTestSuite struct {
@@ -41,21 +49,44 @@ type JUnitFailure struct {
 
 func FormatJUnit(w io.Writer, results []result.Result, _ string, options ...FormatterOption) error {
 
-	output := JUnitTestSuite{
+	output := JUnitTestSuites{
 		Name:     "tfsec",
 		Failures: fmt.Sprintf("%d", len(results)-countPassedResults(results)),
 		Tests:    fmt.Sprintf("%d", len(results)),
 	}
 
-	for _, result := range results {
-		output.TestCases = append(output.TestCases,
-			JUnitTestCase{
-				Classname: result.Range().Filename,
-				Name:      fmt.Sprintf("[%s][%s] - %s", result.RuleID, result.Severity, result.Description),
-				Time:      "0",
-				Failure:   buildFailure(result),
-			},
-		)
+	var services []string
+	grouped := make(map[string][]result.Result)
+	for _, res := range results {
+		service := res.RuleService
+		if service == "" {
+			service = "general"
+		}
+		if _, ok := grouped[service]; !ok {
+			services = append(services, service)
+		}
+		grouped[service] = append(grouped[service], res)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		serviceResults := grouped[service]
+		suite := JUnitTestSuite{
+			Name:     service,
+			Failures: fmt.Sprintf("%d", len(serviceResults)-countPassedResults(serviceResults)),
+			Tests:    fmt.Sprintf("%d", len(serviceResults)),
+		}
+		for _, res := range serviceResults {
+			suite.TestCases = append(suite.TestCases,
+				JUnitTestCase{
+					Classname: res.Range().Filename,
+					Name:      fmt.Sprintf("[%s][%s] - %s", res.RuleID, res.Severity, res.Description),
+					Time:      "0",
+					Failure:   buildFailure(res),
+				},
+			)
+		}
+		output.Suites = append(output.Suites, suite)
 	}
 
 	if _, err := w.Write([]byte(xml.Header)); err != nil {
@@ -69,36 +100,16 @@ func FormatJUnit(w io.Writer, results []result.Result, _ string, options ...Form
 }
 
 // highlight the lines of code which caused a problem, if available
-func highlightCodeJunit(result result.Result) string {
-
-	data, err := ioutil.ReadFile(result.Range().Filename)
-	if err != nil {
-		return ""
-	}
-
-	lines := append([]string{""}, strings.Split(string(data), "\n")...)
-
-	start := result.Range().StartLine - 3
-	if start <= 0 {
-		start = 1
-	}
-	end := result.Range().EndLine + 3
-	if end >= len(lines) {
-		end = len(lines) - 1
-	}
+func highlightCodeJunit(res result.Result) string {
 
 	output := ""
 
-	for lineNo := start; lineNo <= end; lineNo++ {
-		output += fmt.Sprintf("  % 6d | ", lineNo)
-		if lineNo >= result.Range().StartLine && lineNo <= result.Range().EndLine {
-			if lineNo == result.Range().StartLine && result.RangeAnnotation != "" {
-				output += fmt.Sprintf("%s    %s\n", lines[lineNo], result.RangeAnnotation)
-			} else {
-				output += fmt.Sprintf("%s\n", lines[lineNo])
-			}
+	for _, line := range codeLines(res) {
+		output += fmt.Sprintf("  % 6d | ", line.Number)
+		if line.Number == res.Range().StartLine && res.RangeAnnotation != "" {
+			output += fmt.Sprintf("%s    %s\n", line.Content, res.RangeAnnotation)
 		} else {
-			output += fmt.Sprintf("%s\n", lines[lineNo])
+			output += fmt.Sprintf("%s\n", line.Content)
 		}
 	}
 
@@ -117,6 +128,7 @@ func buildFailure(res result.Result) *JUnitFailure {
 
 	return &JUnitFailure{
 		Message: res.Description,
+		Type:    string(res.Severity),
 		Contents: fmt.Sprintf("%s\n%s\n%s",
 			res.Range().String(),
 			highlightCodeJunit(res),
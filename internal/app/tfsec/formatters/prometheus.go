@@ -0,0 +1,80 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+// The names of the metrics written by WritePrometheusMetrics. Dashboards and alerts are built on these, so they must
+// not be renamed.
+const (
+	MetricFindingsTotal       = "tfsec_findings_total"
+	MetricScanDurationSeconds = "tfsec_scan_duration_seconds"
+	MetricFilesScanned        = "tfsec_files_scanned"
+)
+
+type findingLabels struct {
+	provider string
+	service  string
+	severity string
+}
+
+// WritePrometheusMetrics writes metrics for the failed results of a scan, along with the size and duration of the
+// scan, in the Prometheus text exposition format read by the node exporter textfile collector
+func WritePrometheusMetrics(w io.Writer, results []result.Result) error {
+	findings := make(map[findingLabels]int)
+	for _, res := range results {
+		if res.Status == result.Passed || res.Status == result.Ignored {
+			continue
+		}
+		findings[findingLabels{
+			provider: strings.ToLower(string(res.RuleProvider)),
+			service:  strings.ToLower(res.RuleService),
+			severity: strings.ToLower(string(res.Severity)),
+		}]++
+	}
+
+	var labels []findingLabels
+	for l := range findings {
+		labels = append(labels, l)
+	}
+	sort.Slice(labels, func(i, j int) bool {
+		if labels[i].provider != labels[j].provider {
+			return labels[i].provider < labels[j].provider
+		}
+		if labels[i].service != labels[j].service {
+			return labels[i].service < labels[j].service
+		}
+		return labels[i].severity < labels[j].severity
+	})
+
+	var builder strings.Builder
+	writeMetricHeader(&builder, MetricFindingsTotal, "counter", "Number of findings, by provider, service and severity.")
+	for _, l := range labels {
+		_, _ = fmt.Fprintf(&builder, "%s{provider=\"%s\",service=\"%s\",severity=\"%s\"} %d\n",
+			MetricFindingsTotal, escapeLabelValue(l.provider), escapeLabelValue(l.service), escapeLabelValue(l.severity), findings[l])
+	}
+	writeMetricHeader(&builder, MetricScanDurationSeconds, "gauge", "Duration of the scan in seconds.")
+	_, _ = fmt.Fprintf(&builder, "%s %f\n", MetricScanDurationSeconds, metrics.TotalDuration().Seconds())
+	writeMetricHeader(&builder, MetricFilesScanned, "gauge", "Number of files scanned.")
+	_, _ = fmt.Fprintf(&builder, "%s %d\n", MetricFilesScanned, parser.CountFiles())
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
+
+func writeMetricHeader(builder *strings.Builder, name string, metricType string, help string) {
+	_, _ = fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+var labelValueReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueReplacer.Replace(value)
+}
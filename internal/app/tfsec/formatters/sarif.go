@@ -4,7 +4,9 @@ import (
 	"io"
 	"path/filepath"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/aquasecurity/tfsec/version"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
 
@@ -18,6 +20,9 @@ func FormatSarif(w io.Writer, results []result.Result, baseDir string, _ ...Form
 	}
 
 	run := sarif.NewRun("tfsec", "https://tfsec.dev")
+	if version.Version != "" {
+		run.Tool.Driver.WithVersion(version.Version)
+	}
 	report.AddRun(run)
 
 	for _, res := range results {
@@ -26,13 +31,13 @@ func FormatSarif(w io.Writer, results []result.Result, baseDir string, _ ...Form
 			continue
 		}
 
-		var link string
-		if len(res.Links) > 0 {
-			link = res.Links[0]
-		}
+		link := sarifHelpURI(res)
 		rule := run.AddRule(res.RuleID).
 			WithDescription(res.RuleSummary).
 			WithHelp(link)
+		if link != "" {
+			rule.WithHelpURI(link)
+		}
 
 		relativePath, err := filepath.Rel(baseDir, res.Range().Filename)
 		if err != nil {
@@ -40,12 +45,11 @@ func FormatSarif(w io.Writer, results []result.Result, baseDir string, _ ...Form
 		}
 
 		message := sarif.NewTextMessage(res.Description)
-		region := sarif.NewSimpleRegion(res.Range().StartLine, res.Range().EndLine)
 		var level string
 		switch res.Severity {
 		case severity.None:
 			level = "none"
-		case severity.Low:
+		case severity.Low, severity.Info:
 			level = "note"
 		case severity.Medium:
 			level = "warning"
@@ -54,15 +58,42 @@ func FormatSarif(w io.Writer, results []result.Result, baseDir string, _ ...Form
 		}
 
 		location := sarif.NewPhysicalLocation().
-			WithArtifactLocation(sarif.NewSimpleArtifactLocation(relativePath)).
-			WithRegion(region)
+			WithArtifactLocation(sarif.NewSimpleArtifactLocation(relativePath))
+
+		// SARIF regions must start at line 1 or later, so results without a range only point at the file
+		if res.Range().StartLine > 0 {
+			location.WithRegion(sarif.NewSimpleRegion(res.Range().StartLine, res.Range().EndLine))
+		}
 
 		ruleResult := run.AddResult(rule.ID)
 
+		fingerprint := res.FingerprintID
+		if fingerprint == "" {
+			fingerprint = res.Fingerprint()
+		}
+
 		ruleResult.WithMessage(message).
 			WithLevel(level).
-			WithLocation(sarif.NewLocation().WithPhysicalLocation(location))
+			WithLocation(sarif.NewLocation().WithPhysicalLocation(location)).
+			WithPartialFingerPrints(map[string]interface{}{
+				sarifFingerprintKey: fingerprint,
+			})
 	}
 
 	return report.PrettyWrite(w)
 }
+
+// sarifFingerprintKey names the tfsec fingerprint among the partialFingerprints of a result, versioned so the
+// algorithm can change without being confused with older fingerprints
+const sarifFingerprintKey = "tfsecFingerprint/v1"
+
+// sarifHelpURI prefers the documentation links of the registered rule, falling back to the result links
+func sarifHelpURI(res result.Result) string {
+	if r, err := scanner.GetRuleById(res.RuleID); err == nil && len(r.Documentation.Links) > 0 {
+		return r.Documentation.Links[0]
+	}
+	if len(res.Links) > 0 {
+		return res.Links[0]
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+// Summary aggregates the failed results of a scan, along with the size and duration of the scan
+type Summary struct {
+	Failed          int            `json:"failed"`
+	Passed          int            `json:"passed"`
+	Ignored         int            `json:"ignored"`
+	ChecksRun       int            `json:"checks_run"`
+	ChecksPassed    int            `json:"checks_passed"`
+	BySeverity      map[string]int `json:"by_severity"`
+	ByService       map[string]int `json:"by_service"`
+	ByProvider      map[string]int `json:"by_provider"`
+	FilesScanned    int            `json:"files_scanned"`
+	BlocksScanned   int            `json:"blocks_scanned"`
+	DurationSeconds float64        `json:"duration_seconds"`
+}
+
+// NewSummary builds a summary of the given results
+func NewSummary(results []result.Result) Summary {
+	counts := metrics.CountSummary()
+	summary := Summary{
+		ChecksRun:       counts[metrics.ChecksRun],
+		ChecksPassed:    counts[metrics.ChecksPassed],
+		BySeverity:      make(map[string]int),
+		ByService:       make(map[string]int),
+		ByProvider:      make(map[string]int),
+		FilesScanned:    parser.CountFiles(),
+		BlocksScanned:   counts[metrics.BlocksLoaded],
+		DurationSeconds: metrics.TotalDuration().Seconds(),
+	}
+	for _, res := range results {
+		switch res.Status {
+		case result.Passed:
+			summary.Passed++
+			continue
+		case result.Ignored:
+			summary.Ignored++
+			continue
+		}
+		summary.Failed++
+		summary.BySeverity[string(res.Severity)]++
+		if res.RuleService != "" {
+			summary.ByService[res.RuleService]++
+		}
+		if res.RuleProvider != "" {
+			summary.ByProvider[string(res.RuleProvider)]++
+		}
+	}
+	return summary
+}
+
+// PrintSummary writes a human-readable version of the summary
+func PrintSummary(w io.Writer, summary Summary) {
+	_, _ = fmt.Fprintf(w, "\n  summary\n  ------------------------------------------\n")
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "files scanned", summary.FilesScanned)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "blocks scanned", summary.BlocksScanned)
+	_, _ = fmt.Fprintf(w, "  %-20s %.3fs\n", "duration", summary.DurationSeconds)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "checks run", summary.ChecksRun)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "checks passed", summary.ChecksPassed)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "failed", summary.Failed)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "passed", summary.Passed)
+	_, _ = fmt.Fprintf(w, "  %-20s %d\n", "ignored", summary.Ignored)
+	printSummaryCounts(w, "by severity", summary.BySeverity)
+	printSummaryCounts(w, "by service", summary.ByService)
+	printSummaryCounts(w, "by provider", summary.ByProvider)
+	_, _ = fmt.Fprintln(w)
+}
+
+func printSummaryCounts(w io.Writer, heading string, counts map[string]int) {
+	if len(counts) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, "\n  %s\n  ------------------------------------------\n", heading)
+	var names []string
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-20s %d\n", strings.ToLower(name), counts[name])
+	}
+}
@@ -3,9 +3,9 @@ package formatters
 import (
 	"fmt"
 	"io"
-	"io/ioutil"
 	"strings"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/pkg/result"
 )
 
@@ -48,6 +48,13 @@ func FormatText(writer io.Writer, results []result.Result, _ string, options ...
 
 `, res.RuleID, sev, res.Description, res.Range().String())
 		outputCode(res, writer)
+		if res.SuggestedFix != "" {
+			fmt.Fprintf(writer, "  Suggested fix:\n")
+			for _, line := range strings.Split(res.SuggestedFix, "\n") {
+				fmt.Fprintf(writer, "    %s\n", line)
+			}
+			fmt.Fprintln(writer)
+		}
 		fmt.Fprintf(writer, "  %s\n\n", link)
 	}
 
@@ -57,7 +64,7 @@ func FormatText(writer io.Writer, results []result.Result, _ string, options ...
 
 // output the lines of code which caused a problem, if available
 func outputCode(result result.Result, writer io.Writer) {
-	data, err := ioutil.ReadFile(result.Range().Filename)
+	data, err := block.ReadSource(result.Range().Filename)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,98 @@
+package formatters
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/liamg/tml"
+)
+
+// Theme maps each severity to the tml styles it's shown with, such as "bold" and "red"
+type Theme map[severity.Severity][]string
+
+var themes = map[string]Theme{
+	"default": {
+		severity.Critical: {"bold", "red"},
+		severity.High:     {"red"},
+		severity.Medium:   {"yellow"},
+		severity.Low:      {"white"},
+		severity.Info:     {"darkgrey"},
+		severity.None:     {"white"},
+	},
+	// avoids distinguishing severities by red and green alone, and gives each one a distinct shade
+	"high-contrast": {
+		severity.Critical: {"bold", "bg-lightmagenta", "black"},
+		severity.High:     {"bold", "lightmagenta"},
+		severity.Medium:   {"bold", "lightyellow"},
+		severity.Low:      {"lightcyan"},
+		severity.Info:     {"lightgrey"},
+		severity.None:     {"white"},
+	},
+}
+
+var severityTheme = themes["default"]
+
+// ThemeNames lists the built in themes
+func ThemeNames() []string {
+	var names []string
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SetSeverityTheme selects the built in theme used to show severities in the default output. Overrides map severities
+// to space separated tml styles, e.g. "bold red", and take precedence over the theme.
+func SetSeverityTheme(name string, overrides map[string]string) error {
+	base, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("unknown colour theme '%s', should be one of %s", name, ThemeNames())
+	}
+	theme := make(Theme, len(base))
+	for sev, styles := range base {
+		theme[sev] = styles
+	}
+	for name, value := range overrides {
+		sev := severity.StringToSeverity(name)
+		if !sev.IsValid() {
+			return fmt.Errorf("invalid severity '%s' in colour overrides, should be one of %s", name, severity.ValidSeverity)
+		}
+		styles := strings.Fields(value)
+		for _, style := range styles {
+			if !isKnownStyle(style) {
+				return fmt.Errorf("invalid style '%s' for severity %s", style, sev)
+			}
+		}
+		theme[sev] = styles
+	}
+	severityTheme = theme
+	severityFormat = nil
+	return nil
+}
+
+func isKnownStyle(style string) bool {
+	if strings.ContainsAny(style, "<>/") {
+		return false
+	}
+	// tml leaves tags it doesn't recognise in the output
+	output, err := tml.Parse(fmt.Sprintf("<%s>", style))
+	return err == nil && !strings.Contains(output, "<")
+}
+
+func formatSeverity(sev severity.Severity) string {
+	label := string(sev)
+	if sev == severity.None {
+		label = "UNKNOWN"
+	}
+	styles := severityTheme[sev]
+	var open, closing string
+	for i, style := range styles {
+		open += fmt.Sprintf("<%s>", style)
+		closing += fmt.Sprintf("</%s>", styles[len(styles)-1-i])
+	}
+	formatted, _ := tml.Parse(open + label + closing)
+	return formatted
+}
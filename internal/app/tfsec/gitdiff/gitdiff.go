@@ -0,0 +1,62 @@
+package gitdiff
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ChangedFiles lists the files in the git working tree containing dir which differ from baseRef, including staged
+// and untracked files. The returned paths are absolute.
+func ChangedFiles(dir string, baseRef string) (map[string]struct{}, error) {
+	root, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, err
+	}
+	root = strings.TrimSpace(root)
+
+	diff, err := git(root, "diff", "--name-only", "--no-renames", baseRef, "--")
+	if err != nil {
+		return nil, err
+	}
+	untracked, err := git(root, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]struct{})
+	for _, name := range strings.Split(diff+"\n"+untracked, "\n") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		changed[filepath.Join(root, filepath.FromSlash(name))] = struct{}{}
+	}
+	return changed, nil
+}
+
+// Contains checks whether filename is one of the changed files
+func Contains(changed map[string]struct{}, filename string) bool {
+	if abs, err := filepath.Abs(filename); err == nil {
+		filename = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(filename); err == nil {
+		filename = resolved
+	}
+	_, ok := changed[filename]
+	return ok
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,50 @@
+package gitdiff
+
+import (
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	write := func(name string, content string) {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	}
+	run := func(args ...string) {
+		_, err := git(dir, args...)
+		require.NoError(t, err)
+	}
+
+	run("init", "-q")
+	write("modified.tf", `resource "aws_s3_bucket" "a" {}`)
+	write("unchanged.tf", `resource "aws_s3_bucket" "b" {}`)
+	run("add", ".")
+	run("-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "-q", "-m", "initial")
+
+	write("modified.tf", `resource "aws_s3_bucket" "a" { acl = "private" }`)
+	write("added.tf", `resource "aws_s3_bucket" "c" {}`)
+
+	changed, err := ChangedFiles(dir, "HEAD")
+	require.NoError(t, err)
+
+	assert.True(t, Contains(changed, filepath.Join(dir, "modified.tf")))
+	assert.True(t, Contains(changed, filepath.Join(dir, "added.tf")))
+	assert.False(t, Contains(changed, filepath.Join(dir, "unchanged.tf")))
+}
+
+func Test_ChangedFilesOutsideRepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+	_, err := ChangedFiles(t.TempDir(), "HEAD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package logbuckets
+
+import (
+	"path"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var namePatterns []string
+var requiredTags map[string]string
+
+// SetConvention sets how log destination buckets are recognised: by a bucket or resource name matching one of the
+// glob patterns, or by carrying all of the tags. A tag with an empty value only needs to be present.
+func SetConvention(patterns []string, tags map[string]string) {
+	namePatterns = patterns
+	requiredTags = tags
+}
+
+// NamePatterns returns the glob patterns which identify log destination buckets by name
+func NamePatterns() []string {
+	return namePatterns
+}
+
+// Tags returns the tags which identify log destination buckets
+func Tags() map[string]string {
+	return requiredTags
+}
+
+// IsLogBucket reports whether an aws_s3_bucket follows the configured convention for log destination buckets. It is
+// always false if no convention is configured.
+func IsLogBucket(bucketBlock block.Block) bool {
+	return matchesName(bucketBlock) || matchesTags(bucketBlock)
+}
+
+func matchesName(bucketBlock block.Block) bool {
+	names := []string{bucketBlock.NameLabel()}
+	for _, name := range []string{"bucket", "bucket_prefix"} {
+		if value, ok := bucketBlock.GetAttribute(name).AsStringValue(); ok {
+			names = append(names, value)
+		}
+	}
+	for _, pattern := range namePatterns {
+		for _, name := range names {
+			if matched, err := path.Match(pattern, name); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchesTags(bucketBlock block.Block) bool {
+	if len(requiredTags) == 0 {
+		return false
+	}
+	tags, ok := tagging.EffectiveTags(bucketBlock)
+	if !ok {
+		return false
+	}
+	for key, expected := range requiredTags {
+		value, ok := tags[key]
+		if !ok {
+			return false
+		}
+		if expected == "" {
+			continue
+		}
+		if !value.IsKnown() || value.IsNull() || value.Type() != cty.String || value.AsString() != expected {
+			return false
+		}
+	}
+	return true
+}
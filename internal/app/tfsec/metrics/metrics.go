@@ -1,13 +1,19 @@
 package metrics
 
 import (
+	"sync"
 	"time"
 
 	"github.com/aquasecurity/tfsec/pkg/severity"
 )
 
+// checks run concurrently, so all recorded metrics are guarded by lock
+var lock sync.Mutex
+
 var recordedTimes []*Timer
 
+var startedAt = time.Now()
+
 type Operation string
 
 const (
@@ -32,25 +38,41 @@ func Start(op Operation) *Timer {
 
 func (t *Timer) Stop() {
 	t.duration = time.Since(t.started)
+	lock.Lock()
+	defer lock.Unlock()
 	recordedTimes = append(recordedTimes, t)
 }
 
 type Count string
 
 const (
-	ModuleLoadCount Count = "modules"
-	BlocksLoaded    Count = "blocks"
-	FilesLoaded     Count = "files loaded"
-	IgnoredChecks   Count = "ignored checks"
+	ModuleLoadCount     Count = "modules"
+	ModuleDownloadCount Count = "modules downloaded"
+	BlocksLoaded        Count = "blocks"
+	FilesLoaded         Count = "files loaded"
+	IgnoredChecks       Count = "ignored checks"
+	ChecksRun           Count = "checks run"
+	ChecksPassed        Count = "checks passed"
 )
 
 var counts = map[Count]int{}
 
 func Add(c Count, delta int) {
+	lock.Lock()
+	defer lock.Unlock()
 	counts[c] += delta
 }
 
+// ResetCounts discards the recorded counts
+func ResetCounts() {
+	lock.Lock()
+	defer lock.Unlock()
+	counts = map[Count]int{}
+}
+
 func TimerSummary() map[Operation]time.Duration {
+	lock.Lock()
+	defer lock.Unlock()
 
 	times := make(map[Operation]time.Duration)
 	for _, recorded := range recordedTimes {
@@ -62,17 +84,37 @@ func TimerSummary() map[Operation]time.Duration {
 	return times
 }
 
+// StartTime is the time tfsec started
+func StartTime() time.Time {
+	return startedAt
+}
+
+// TotalDuration is the time elapsed since tfsec started
+func TotalDuration() time.Duration {
+	return time.Since(startedAt)
+}
+
 func CountSummary() map[Count]int {
-	return counts
+	lock.Lock()
+	defer lock.Unlock()
+	summary := make(map[Count]int, len(counts))
+	for c, count := range counts {
+		summary[c] = count
+	}
+	return summary
 }
 
 var severities = map[severity.Severity]int{}
 
 func AddResult(s severity.Severity) {
+	lock.Lock()
+	defer lock.Unlock()
 	severities[s]++
 }
 
 func CountSeverity(sev severity.Severity) int {
+	lock.Lock()
+	defer lock.Unlock()
 	val, ok := severities[sev]
 	if !ok {
 		return 0
@@ -0,0 +1,25 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
+	"github.com/hashicorp/hcl/v2"
+)
+
+// reportHCLError records a parse error as diagnostics. Unless the parse stops on the error, each diagnostic which
+// hasn't been seen before is also printed as a warning.
+func reportHCLError(err error, stopOnHCLError bool) {
+	var recorded []diagnostics.Diagnostic
+	if hclDiagnostics, ok := err.(hcl.Diagnostics); ok {
+		recorded = diagnostics.FromHCL(hclDiagnostics)
+	} else {
+		recorded = []diagnostics.Diagnostic{{Severity: diagnostics.Error, Summary: err.Error()}}
+	}
+	for _, diagnostic := range recorded {
+		if diagnostics.Add(diagnostic) && !stopOnHCLError {
+			_, _ = fmt.Fprintln(os.Stderr, diagnostic.Warning())
+		}
+	}
+}
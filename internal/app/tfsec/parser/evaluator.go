@@ -1,8 +1,10 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 
@@ -34,6 +36,9 @@ type Evaluator struct {
 	modulePath        string
 	workingDir        string
 	workspace         string
+	downloadModules   bool
+	skipModules       bool
+	scanContext       context.Context
 }
 
 func NewEvaluator(
@@ -46,6 +51,7 @@ func NewEvaluator(
 	visitedModules []*visitedModule,
 	stopOnHCLError bool,
 	workspace string,
+	downloadModules bool,
 ) *Evaluator {
 
 	ctx := block.NewContext(&hcl.EvalContext{
@@ -72,6 +78,7 @@ func NewEvaluator(
 		visitedModules:  visitedModules,
 		stopOnHCLError:  stopOnHCLError,
 		workspace:       workspace,
+		downloadModules: downloadModules,
 	}
 }
 
@@ -83,6 +90,8 @@ func (e *Evaluator) evaluateStep(i int) {
 	e.ctx.Set(e.getValuesByBlockType("variable"), "var")
 	e.ctx.Set(e.getValuesByBlockType("locals"), "local")
 	e.ctx.Set(e.getValuesByBlockType("provider"), "provider")
+	e.resolveProviders()
+	e.resolveDefaultTags()
 
 	resources := e.getValuesByBlockType("resource")
 	for key, resource := range resources.AsValueMap() {
@@ -100,6 +109,9 @@ func (e *Evaluator) evaluateStep(i int) {
 func (e *Evaluator) evaluateModules() {
 
 	for _, module := range e.moduleDefinitions {
+		if e.cancelled() != nil {
+			return
+		}
 		if visited := func(module *ModuleDefinition) bool {
 			for _, v := range e.visitedModules {
 				if v.name == module.Name && v.path == module.Path && module.Definition.Reference().String() == v.definitionReference {
@@ -116,7 +128,11 @@ func (e *Evaluator) evaluateModules() {
 
 		evalTime := metrics.Start(metrics.Evaluation)
 		vars := module.Definition.Values().AsValueMap()
-		moduleEvaluator := NewEvaluator(e.projectRootPath, module.Path, e.workingDir, module.Modules[0].GetBlocks(), vars, e.moduleMetadata, e.visitedModules, e.stopOnHCLError, e.workspace)
+		moduleEvaluator := NewEvaluator(e.projectRootPath, module.Path, e.workingDir, module.Modules[0].GetBlocks(), vars, e.moduleMetadata, e.visitedModules, e.stopOnHCLError, e.workspace, e.downloadModules)
+		moduleEvaluator.scanContext = e.scanContext
+		passed := module.Definition.GetAttribute("providers").TraversalsByKey()
+		moduleEvaluator.inheritProviders(e.ctx, passed)
+		moduleEvaluator.inheritDefaultTags(e.ctx, passed)
 		module.Modules, _ = moduleEvaluator.EvaluateAll()
 		// export module outputs
 		e.ctx.Set(moduleEvaluator.ExportOutputs(), "module", module.Name)
@@ -125,6 +141,97 @@ func (e *Evaluator) evaluateModules() {
 	}
 }
 
+// resolveProviders records the provider blocks and required_providers of the module, so checks can find the provider
+// configuration which governs a resource
+func (e *Evaluator) resolveProviders() {
+	for _, providerBlock := range e.blocks.OfType("provider") {
+		e.ctx.SetProvider(block.ProviderBlockRef(providerBlock), providerBlock)
+	}
+	for _, terraformBlock := range e.blocks.OfType("terraform") {
+		for localName, value := range terraformBlock.GetBlock("required_providers").Values().AsValueMap() {
+			e.ctx.SetRequiredProvider(localName, requiredProvider(value))
+		}
+	}
+}
+
+// requiredProvider reads an entry of required_providers, which is either an object with a source and version or, in
+// the legacy syntax, just a version constraint
+func requiredProvider(value cty.Value) block.RequiredProvider {
+	var requiredProvider block.RequiredProvider
+	if value.IsNull() || !value.IsKnown() {
+		return requiredProvider
+	}
+	if value.Type() == cty.String {
+		requiredProvider.Version = value.AsString()
+		return requiredProvider
+	}
+	if !value.Type().IsObjectType() && !value.Type().IsMapType() {
+		return requiredProvider
+	}
+	for key, attr := range value.AsValueMap() {
+		if attr.IsNull() || !attr.IsKnown() || attr.Type() != cty.String {
+			continue
+		}
+		switch key {
+		case "source":
+			requiredProvider.Source = attr.AsString()
+		case "version":
+			requiredProvider.Version = attr.AsString()
+		}
+	}
+	return requiredProvider
+}
+
+// inheritProviders copies the provider configurations which a parent module passes on to a child module
+func (e *Evaluator) inheritProviders(parent *block.Context, passed map[string]string) {
+	for childRef, parentRef := range passedProviders(parent.Providers(), passed) {
+		if _, ok := e.ctx.Provider(childRef); ok {
+			continue
+		}
+		if providerBlock, ok := parent.Provider(parentRef); ok {
+			e.ctx.SetProvider(childRef, providerBlock)
+		}
+	}
+}
+
+// passedProviders maps the provider references of a child module to the configurations of its parent which they stand
+// for. The providers argument of the module block passes configurations on under the names given to them in the child,
+// e.g. aws = aws.west makes aws.west the default aws configuration of the child. Without it, terraform passes on the
+// unaliased configurations implicitly.
+func passedProviders(parentRefs []string, passed map[string]string) map[string]string {
+	if len(passed) > 0 {
+		return passed
+	}
+	implicit := make(map[string]string)
+	for _, providerRef := range parentRefs {
+		if !strings.Contains(providerRef, ".") {
+			implicit[providerRef] = providerRef
+		}
+	}
+	return implicit
+}
+
+// resolveDefaultTags records the default_tags of each provider configuration, so tag checks can take them into account
+func (e *Evaluator) resolveDefaultTags() {
+	for _, providerBlock := range e.blocks.OfType("provider") {
+		tagsAttr := providerBlock.GetBlock("default_tags").GetAttribute("tags")
+		if tagsAttr.IsNil() {
+			continue
+		}
+		e.ctx.SetDefaultTags(block.ProviderBlockRef(providerBlock), tagsAttr.Value())
+	}
+}
+
+// inheritDefaultTags copies the default_tags of the provider configurations which a parent module passes on to a child
+// module
+func (e *Evaluator) inheritDefaultTags(parent *block.Context, passed map[string]string) {
+	for childRef, parentRef := range passedProviders(parent.DefaultTagProviders(), passed) {
+		if tags, ok := parent.DefaultTags(parentRef); ok {
+			e.ctx.SetDefaultTags(childRef, tags)
+		}
+	}
+}
+
 // export module outputs to a parent
 func (e *Evaluator) ExportOutputs() cty.Value {
 	data := make(map[string]cty.Value)
@@ -138,11 +245,24 @@ func (e *Evaluator) ExportOutputs() cty.Value {
 	return cty.ObjectVal(data)
 }
 
+// cancelled returns the error from the scan context once the scan has been cancelled or has timed out
+func (e *Evaluator) cancelled() error {
+	if e.scanContext == nil {
+		return nil
+	}
+	return e.scanContext.Err()
+}
+
+// EvaluateAll evaluates the blocks of the module and loads its child modules. If the scan is cancelled, the modules are
+// returned as far as they were evaluated, along with the context's error.
 func (e *Evaluator) EvaluateAll() ([]block.Module, error) {
 
 	var lastContext hcl.EvalContext
 
 	for i := 0; i < maxContextIterations; i++ {
+		if err := e.cancelled(); err != nil {
+			return e.evaluatedModules(), err
+		}
 
 		e.evaluateStep(i)
 
@@ -159,13 +279,18 @@ func (e *Evaluator) EvaluateAll() ([]block.Module, error) {
 		}
 	}
 
-	debug.Log("Loading modules...")
-	e.moduleDefinitions = e.loadModules(true)
+	if !e.skipModules {
+		debug.Log("Loading modules...")
+		e.moduleDefinitions = e.loadModules(true)
+	}
 
 	// expand out resources and modules via count
 	e.blocks = e.expandBlocks(e.blocks)
 
 	for i := 0; i < maxContextIterations; i++ {
+		if err := e.cancelled(); err != nil {
+			return e.evaluatedModules(), err
+		}
 
 		e.evaluateStep(i)
 
@@ -182,13 +307,18 @@ func (e *Evaluator) EvaluateAll() ([]block.Module, error) {
 		}
 	}
 
+	return e.evaluatedModules(), nil
+}
+
+// evaluatedModules returns the module and its child modules as far as they have been evaluated, which is all of them
+// unless the scan was cancelled part way through
+func (e *Evaluator) evaluatedModules() []block.Module {
 	var modules []block.Module
 	modules = append(modules, block.NewHCLModule(e.projectRootPath, e.modulePath, e.blocks))
 	for _, definition := range e.moduleDefinitions {
 		modules = append(modules, definition.Modules...)
 	}
-
-	return modules, nil
+	return modules
 }
 
 func (e *Evaluator) expandBlocks(blocks block.Blocks) block.Blocks {
@@ -237,18 +367,37 @@ func (e *Evaluator) expandBlockForEaches(blocks block.Blocks) block.Blocks {
 				ctx.SetByDot(key, "each.key")
 				ctx.SetByDot(val, "each.value")
 
-				ctx.Set(key, block.TypeLabel(), "key")
-				ctx.Set(val, block.TypeLabel(), "value")
+				iteratorName := block.TypeLabel()
+				if block.Type() == "dynamic" {
+					iteratorName = dynamicIteratorName(block)
+				}
+				ctx.Set(key, iteratorName, "key")
+				ctx.Set(val, iteratorName, "value")
 
 				debug.Log("Added %s from for_each", clone.Reference())
 				forEachFiltered = append(forEachFiltered, clone)
 			})
+		} else if block.Type() != "dynamic" {
+			// the collection can't be resolved, so check the template block rather than dropping it
+			debug.Log("Could not resolve for_each for %s, checking the unexpanded block", block.Reference())
+			forEachFiltered = append(forEachFiltered, block)
 		}
 	}
 
 	return forEachFiltered
 }
 
+// dynamicIteratorName returns the name used to reference the current element in the content of a dynamic block,
+// which defaults to the label of the dynamic block unless an iterator is set
+func dynamicIteratorName(b block.Block) string {
+	if iteratorAttr := b.GetAttribute("iterator"); iteratorAttr.IsNotNil() {
+		if ref, err := iteratorAttr.Reference(); err == nil && ref.TypeLabel() != "" {
+			return ref.TypeLabel()
+		}
+	}
+	return b.TypeLabel()
+}
+
 func (e *Evaluator) expandBlockCounts(blocks block.Blocks) block.Blocks {
 	var countFiltered block.Blocks
 	for _, block := range blocks {
@@ -320,9 +469,57 @@ func (e *Evaluator) evaluateVariable(b block.Block) (cty.Value, error) {
 		return def.Value(), nil
 	}
 
+	if typeAttr, exists := attributes["type"]; exists {
+		if varType, ok := typeAttr.AsTypeConstraint(); ok && varType != cty.DynamicPseudoType {
+			if varType == cty.Bool {
+				if val, ok := e.narrowByValidation(b, cty.True, cty.False); ok {
+					return val, nil
+				}
+			}
+			// the value is unknown, but rules can still reason about its type
+			return cty.UnknownVal(varType), nil
+		}
+	}
+
 	return cty.NilVal, fmt.Errorf("no value found")
 }
 
+// narrowByValidation returns the only candidate value which passes all of the variable's validation conditions, if
+// there is exactly one
+func (e *Evaluator) narrowByValidation(b block.Block, candidates ...cty.Value) (cty.Value, bool) {
+	validations := b.GetBlocks("validation")
+	if len(validations) == 0 {
+		return cty.NilVal, false
+	}
+
+	var allowed []cty.Value
+	for _, candidate := range candidates {
+		passes := true
+		for _, validation := range validations {
+			condition := validation.GetAttribute("condition")
+			if condition.IsNil() {
+				continue
+			}
+			// conditions can only refer to the variable itself, so it's safe to shadow var while evaluating them
+			ctx := validation.Context()
+			ctx.Set(candidate, "var", b.Label())
+			passes = condition.IsResolvable() && condition.IsTrue()
+			delete(ctx.Inner().Variables, "var")
+			if !passes {
+				break
+			}
+		}
+		if passes {
+			allowed = append(allowed, candidate)
+		}
+	}
+
+	if len(allowed) != 1 {
+		return cty.NilVal, false
+	}
+	return allowed[0], true
+}
+
 func (e *Evaluator) evaluateOutput(b block.Block) (cty.Value, error) {
 	if b.Label() == "" {
 		return cty.NilVal, fmt.Errorf("empty label - cannot resolve")
@@ -371,10 +568,10 @@ func (e *Evaluator) getValuesByBlockType(blockType string) cty.Value {
 				continue
 			}
 
-			blockMap, ok := values[b.Label()]
+			blockMap, ok := values[b.TypeLabel()]
 			if !ok {
-				values[b.Labels()[0]] = cty.ObjectVal(make(map[string]cty.Value))
-				blockMap = values[b.Labels()[0]]
+				values[b.TypeLabel()] = cty.ObjectVal(make(map[string]cty.Value))
+				blockMap = values[b.TypeLabel()]
 			}
 
 			valueMap := blockMap.AsValueMap()
@@ -382,8 +579,8 @@ func (e *Evaluator) getValuesByBlockType(blockType string) cty.Value {
 				valueMap = make(map[string]cty.Value)
 			}
 
-			valueMap[b.Labels()[1]] = b.Values()
-			values[b.Labels()[0]] = cty.ObjectVal(valueMap)
+			valueMap[b.NameLabel()] = withPolicyDocumentJSON(b, b.Values())
+			values[b.TypeLabel()] = cty.ObjectVal(valueMap)
 		}
 
 	}
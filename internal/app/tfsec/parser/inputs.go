@@ -0,0 +1,46 @@
+package parser
+
+import (
+	"sort"
+	"sync"
+)
+
+// inputs are the files and directories which have been read while parsing, so the results of a scan can be reused
+// by a later run for as long as none of them have changed
+var inputs = struct {
+	sync.Mutex
+	files map[string]struct{}
+	dirs  map[string]struct{}
+}{
+	files: make(map[string]struct{}),
+	dirs:  make(map[string]struct{}),
+}
+
+func recordInputFile(path string) {
+	inputs.Lock()
+	defer inputs.Unlock()
+	inputs.files[path] = struct{}{}
+}
+
+func recordInputDir(path string) {
+	inputs.Lock()
+	defer inputs.Unlock()
+	inputs.dirs[path] = struct{}{}
+}
+
+// Inputs returns the files which have been read and the directories which have been listed while parsing. Files
+// which were looked for but don't exist, such as a missing modules.json, are included, as creating them would change
+// the result of parsing.
+func Inputs() (files []string, dirs []string) {
+	inputs.Lock()
+	defer inputs.Unlock()
+	for path := range inputs.files {
+		files = append(files, path)
+	}
+	for path := range inputs.dirs {
+		dirs = append(dirs, path)
+	}
+	sort.Strings(files)
+	sort.Strings(dirs)
+	return files, dirs
+}
@@ -1,10 +1,9 @@
 package parser
 
 import (
-	"fmt"
 	"io/ioutil"
-	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
@@ -20,49 +19,72 @@ func CountFiles() int {
 	return len(knownFiles)
 }
 
+// KnownFiles returns the files which have been loaded
+func KnownFiles() []string {
+	files := make([]string, 0, len(knownFiles))
+	for path := range knownFiles {
+		files = append(files, path)
+	}
+	sort.Strings(files)
+	return files
+}
+
+// AddKnownFiles records files as loaded when their results are reused without loading them
+func AddKnownFiles(paths ...string) {
+	for _, path := range paths {
+		knownFiles[path] = struct{}{}
+	}
+}
+
 func LoadDirectory(fullPath string, stopOnHCLError bool) ([]*hcl.File, error) {
+	return loadDirectory(fullPath, stopOnHCLError, nil)
+}
+
+// loadDirectory parses the terraform files in a directory, skipping any files for which isExcluded returns true
+func loadDirectory(fullPath string, stopOnHCLError bool, isExcluded func(path string) bool) ([]*hcl.File, error) {
 
 	t := metrics.Start(metrics.DiskIO)
 	defer t.Stop()
 
 	hclParser := hclparse.NewParser()
 
+	recordInputDir(fullPath)
 	fileInfos, err := ioutil.ReadDir(fullPath)
 	if err != nil {
 		return nil, err
 	}
 
+	var files []*hcl.File
 	for _, info := range fileInfos {
 		if info.IsDir() {
 			continue
 		}
 
-		var parseFunc func(filename string) (*hcl.File, hcl.Diagnostics)
+		var parseFunc func(src []byte, filename string) (*hcl.File, hcl.Diagnostics)
 
 		switch true {
 		case strings.HasSuffix(info.Name(), ".tf"):
-			parseFunc = hclParser.ParseHCLFile
+			parseFunc = hclParser.ParseHCL
 		case strings.HasSuffix(info.Name(), ".tf.json"):
-			parseFunc = hclParser.ParseJSONFile
+			parseFunc = hclParser.ParseJSON
 		default:
 			continue
 		}
 
 		path := filepath.Join(fullPath, info.Name())
-		_, diag := parseFunc(path)
+		if isExcluded != nil && isExcluded(path) {
+			continue
+		}
+		file, diag := parseFileWithCache(path, info, parseFunc)
 		if diag != nil && diag.HasErrors() {
+			reportHCLError(diag, stopOnHCLError)
 			if stopOnHCLError {
 				return nil, diag
 			}
-			_, _ = fmt.Fprintf(os.Stderr, "WARNING: HCL error: %s\n", diag)
 			continue
 		}
 
 		knownFiles[path] = struct{}{}
-	}
-
-	var files []*hcl.File
-	for _, file := range hclParser.Files() {
 		files = append(files, file)
 	}
 
@@ -1,12 +1,14 @@
 package parser
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
 	"github.com/hashicorp/hcl/v2"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
@@ -35,9 +37,22 @@ func (e *Evaluator) loadModules(stopOnHCLError bool) []*ModuleDefinition {
 		if moduleBlock.Label() == "" {
 			continue
 		}
+		if e.cancelled() != nil {
+			break
+		}
 		moduleDefinition, err := e.loadModule(moduleBlock, stopOnHCLError)
 		if err != nil {
-			_, _ = fmt.Fprintf(os.Stderr, "WARNING: Failed to load module: %s\n", err)
+			moduleRange := moduleBlock.Range()
+			diagnostic := diagnostics.Diagnostic{
+				Severity: diagnostics.Warning,
+				Summary:  diagnostics.SummaryModuleLoadFailed,
+				Detail:   err.Error(),
+				Location: &moduleRange,
+			}
+			// modules can be loaded more than once, so the warning is only written the first time
+			if diagnostics.Add(diagnostic) {
+				_, _ = fmt.Fprintln(os.Stderr, diagnostic.Warning())
+			}
 			continue
 		}
 		moduleDefinitions = append(moduleDefinitions, moduleDefinition)
@@ -56,13 +71,20 @@ func (e *Evaluator) loadModule(b block.Block, stopOnHCLError bool) (*ModuleDefin
 	evalTime := metrics.Start(metrics.Evaluation)
 
 	var source string
+	var versionConstraint string
 	attrs := b.Attributes()
 	for _, attr := range attrs {
-		if attr.Name() == "source" {
+		switch attr.Name() {
+		case "source":
 			sourceVal := attr.Value()
 			if sourceVal.Type() == cty.String {
 				source = sourceVal.AsString()
 			}
+		case "version":
+			versionVal := attr.Value()
+			if versionVal.Type() == cty.String {
+				versionConstraint = versionVal.AsString()
+			}
 		}
 	}
 
@@ -83,11 +105,22 @@ func (e *Evaluator) loadModule(b block.Block, stopOnHCLError bool) (*ModuleDefin
 			}
 		}
 	}
+	if modulePath == "" && e.downloadModules && !isLocalModuleSource(source) {
+		ctx := e.scanContext
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		downloadedPath, err := downloadModule(ctx, source, versionConstraint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download module with source '%s': %w", source, err)
+		}
+		modulePath = downloadedPath
+	}
 	if modulePath == "" {
 		// if we have no metadata, we can only support modules available on the local filesystem
 		// users wanting this feature should run a `terraform init` before running tfsec to cache all modules locally
-		if !strings.HasPrefix(source, fmt.Sprintf(".%c", os.PathSeparator)) && !strings.HasPrefix(source, fmt.Sprintf("..%c", os.PathSeparator)) {
-			return nil, fmt.Errorf("missing module with source '%s' -  try to 'terraform init' first", source)
+		if !isLocalModuleSource(source) {
+			return nil, fmt.Errorf("missing module with source '%s' -  try to 'terraform init' or use --download-modules first", source)
 		}
 
 		// combine the current calling module with relative source of the module
@@ -110,6 +143,17 @@ func (e *Evaluator) loadModule(b block.Block, stopOnHCLError bool) (*ModuleDefin
 	}, nil
 }
 
+// isLocalModuleSource returns true if the source refers to a path on the local filesystem. Terraform always uses
+// forward slashes in module sources, but the native separator is accepted too.
+func isLocalModuleSource(source string) bool {
+	for _, prefix := range []string{"./", "../", fmt.Sprintf(".%c", os.PathSeparator), fmt.Sprintf("..%c", os.PathSeparator)} {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func getModuleBlocks(b block.Block, modulePath string, blocks *block.Blocks, stopOnHCLError bool) error {
 	moduleFiles, err := LoadDirectory(modulePath, stopOnHCLError)
 	if err != nil {
@@ -120,10 +164,10 @@ func getModuleBlocks(b block.Block, modulePath string, blocks *block.Blocks, sto
 	for _, file := range moduleFiles {
 		fileBlocks, err := LoadBlocksFromFile(file)
 		if err != nil {
+			reportHCLError(err, stopOnHCLError)
 			if stopOnHCLError {
 				return err
 			}
-			_, _ = fmt.Fprintf(os.Stderr, "WARNING: HCL error: %s\n", err)
 			continue
 		}
 		if len(fileBlocks) > 0 {
@@ -19,6 +19,7 @@ type ModuleMetadata struct {
 
 func LoadModuleMetadata(fullPath string) (*ModulesMetadata, error) {
 	metadataPath := filepath.Join(fullPath, ".terraform/modules/modules.json")
+	recordInputFile(metadataPath)
 	if _, err := os.Stat(metadataPath); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,203 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// see https://www.terraform.io/docs/internals/json-format.html
+type planFile struct {
+	PlannedValues struct {
+		RootModule planModule `json:"root_module"`
+	} `json:"planned_values"`
+}
+
+type planModule struct {
+	Address      string         `json:"address"`
+	Resources    []planResource `json:"resources"`
+	ChildModules []planModule   `json:"child_modules"`
+}
+
+type planResource struct {
+	Address string                     `json:"address"`
+	Mode    string                     `json:"mode"`
+	Type    string                     `json:"type"`
+	Name    string                     `json:"name"`
+	Values  map[string]json.RawMessage `json:"values"`
+}
+
+// LoadPlanFile reads the output of `terraform show -json` and converts the planned resources into blocks
+func LoadPlanFile(path string) ([]block.Module, error) {
+
+	t := metrics.Start(metrics.DiskIO)
+	data, err := ioutil.ReadFile(path)
+	t.Stop()
+	if err != nil {
+		return nil, err
+	}
+
+	parseTime := metrics.Start(metrics.HCLParse)
+	defer parseTime.Stop()
+
+	var plan planFile
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse plan file '%s': %w", path, err)
+	}
+
+	rng := hcl.Range{Filename: path}
+
+	var blocks block.Blocks
+	if err := loadPlanModule(plan.PlannedValues.RootModule, nil, rng, &blocks); err != nil {
+		return nil, err
+	}
+
+	metrics.Add(metrics.BlocksLoaded, len(blocks))
+	knownFiles[path] = struct{}{}
+
+	dir := filepath.Dir(path)
+	return []block.Module{block.NewHCLModule(dir, dir, blocks)}, nil
+}
+
+func loadPlanModule(module planModule, moduleBlock block.Block, rng hcl.Range, blocks *block.Blocks) error {
+	for _, resource := range module.Resources {
+		resourceBlock, err := newPlanResourceBlock(resource, module.Address, rng)
+		if err != nil {
+			return err
+		}
+		debug.Log("Added %s from plan", resource.Address)
+		*blocks = append(*blocks, block.NewHCLBlock(resourceBlock, nil, moduleBlock))
+	}
+
+	for _, child := range module.ChildModules {
+		// the parent module prefix is removed, leaving the label (and any index) of the child module
+		label := strings.TrimPrefix(strings.TrimPrefix(child.Address, module.Address+"."), "module.")
+		childBlock := block.NewHCLBlock((&hclsyntax.Block{
+			Type:   "module",
+			Labels: []string{label},
+			Body:   &hclsyntax.Body{SrcRange: rng, EndRange: rng},
+		}).AsHCLBlock(), nil, moduleBlock)
+		if err := loadPlanModule(child, childBlock, rng, blocks); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newPlanResourceBlock(resource planResource, moduleAddress string, rng hcl.Range) (*hcl.Block, error) {
+
+	blockType := "resource"
+	localAddress := strings.TrimPrefix(resource.Address, moduleAddress+".")
+	if resource.Mode == "data" {
+		blockType = "data"
+		localAddress = strings.TrimPrefix(localAddress, "data.")
+	}
+
+	// the name label keeps any count/for_each index from the address, e.g. bucket[0]
+	nameLabel := strings.TrimPrefix(localAddress, resource.Type+".")
+	if nameLabel == localAddress {
+		nameLabel = resource.Name
+	}
+
+	values := make(map[string]cty.Value)
+	for name, raw := range resource.Values {
+		impliedType, err := ctyjson.ImpliedType(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' of %s in plan: %w", name, resource.Address, err)
+		}
+		val, err := ctyjson.Unmarshal(raw, impliedType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s' of %s in plan: %w", name, resource.Address, err)
+		}
+		values[name] = val
+	}
+
+	return (&hclsyntax.Block{
+		Type:   blockType,
+		Labels: []string{resource.Type, nameLabel},
+		Body:   newPlanBody(values, rng),
+	}).AsHCLBlock(), nil
+}
+
+// newPlanBody converts plan values into a body. Lists of objects are the plan's representation of nested blocks,
+// so these are synthesised as child blocks. Null values and empty lists are treated as absent.
+func newPlanBody(values map[string]cty.Value, rng hcl.Range) *hclsyntax.Body {
+	body := &hclsyntax.Body{
+		Attributes: make(hclsyntax.Attributes),
+		SrcRange:   rng,
+		EndRange:   rng,
+	}
+
+	var names []string
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		val := values[name]
+		if val.IsNull() {
+			continue
+		}
+		if val.Type().IsTupleType() || val.Type().IsListType() {
+			elements := val.AsValueSlice()
+			if len(elements) == 0 {
+				continue
+			}
+			if allObjects(elements) {
+				for _, element := range elements {
+					body.Blocks = append(body.Blocks, &hclsyntax.Block{
+						Type: name,
+						Body: newPlanBody(element.AsValueMap(), rng),
+					})
+				}
+				continue
+			}
+		}
+		body.Attributes[name] = &hclsyntax.Attribute{
+			Name:     name,
+			Expr:     newPlanExpression(val, rng),
+			SrcRange: rng,
+		}
+	}
+
+	return body
+}
+
+func newPlanExpression(val cty.Value, rng hcl.Range) hclsyntax.Expression {
+	if val.Type().IsTupleType() || val.Type().IsListType() {
+		var exprs []hclsyntax.Expression
+		for _, element := range val.AsValueSlice() {
+			exprs = append(exprs, newPlanExpression(element, rng))
+		}
+		return &hclsyntax.TupleConsExpr{
+			Exprs:    exprs,
+			SrcRange: rng,
+		}
+	}
+	return &hclsyntax.LiteralValueExpr{
+		Val:      val,
+		SrcRange: rng,
+	}
+}
+
+func allObjects(values []cty.Value) bool {
+	for _, val := range values {
+		if !val.Type().IsObjectType() || val.IsNull() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,322 @@
+package parser
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/hashicorp/go-version"
+)
+
+const defaultRegistryHost = "registry.terraform.io"
+
+type gitSource struct {
+	repository string
+	ref        string
+	subdir     string
+}
+
+// downloadModule fetches a remote module into the module cache and returns the local path to it. Sources which have
+// already been downloaded (matched on source and ref) are not downloaded again.
+func downloadModule(ctx context.Context, source string, versionConstraint string) (string, error) {
+
+	if isRegistrySource(source) {
+		resolved, err := resolveRegistrySource(ctx, source, versionConstraint)
+		if err != nil {
+			return "", err
+		}
+		debug.Log("Resolved registry module '%s' to '%s'", source, resolved)
+		source = resolved
+	}
+
+	git, err := parseGitSource(source)
+	if err != nil {
+		return "", err
+	}
+
+	cacheDir, err := moduleCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(git.repository + "?ref=" + git.ref))
+	checkoutDir := filepath.Join(cacheDir, hex.EncodeToString(hash[:])[:16])
+
+	if _, err := os.Stat(checkoutDir); err != nil {
+		if err := cloneGitSource(ctx, git, checkoutDir); err != nil {
+			return "", err
+		}
+		metrics.Add(metrics.ModuleDownloadCount, 1)
+	} else {
+		debug.Log("Using cached module '%s' from %s", source, checkoutDir)
+	}
+
+	return moduleSubdir(checkoutDir, git.subdir)
+}
+
+// moduleSubdir returns the path to the //subdir of a checkout, which must not lead outside of it
+func moduleSubdir(checkoutDir string, subdir string) (string, error) {
+	path := filepath.Join(checkoutDir, subdir)
+	rel, err := filepath.Rel(checkoutDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("module subdirectory '%s' is outside of the module", subdir)
+	}
+	return path, nil
+}
+
+var cacheDir string
+
+// SetCacheDir changes where tfsec keeps its cache, which defaults to a tfsec directory in the user's cache directory
+func SetCacheDir(dir string) {
+	cacheDir = dir
+}
+
+// CacheDir returns the directory tfsec keeps its cache in
+func CacheDir() (string, error) {
+	if cacheDir != "" {
+		return cacheDir, nil
+	}
+	userCache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(userCache, "tfsec"), nil
+}
+
+func moduleCacheDir() (string, error) {
+	base, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(base, "modules")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// cloneGitSource clones into a temporary directory first, so a failed download never leaves a partial cache entry
+func cloneGitSource(ctx context.Context, git *gitSource, checkoutDir string) error {
+
+	t := metrics.Start(metrics.DiskIO)
+	defer t.Stop()
+
+	tmpDir, err := ioutil.TempDir(filepath.Dir(checkoutDir), ".download")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+
+	debug.Log("Cloning module repository '%s' (ref '%s')...", git.repository, git.ref)
+
+	// the repository and ref come from the module source, so they follow -- where git allows it, and parseGitSource
+	// rejects values which could otherwise be read as options
+	args := []string{"clone", "--quiet", "--depth", "1"}
+	if git.ref != "" {
+		args = append(args, "--branch", git.ref)
+	}
+	if err := runGit(ctx, "", append(args, "--", git.repository, tmpDir)...); err != nil {
+		if git.ref == "" {
+			return err
+		}
+		// a shallow clone can't check out a commit hash, so fall back to a full clone
+		_ = os.RemoveAll(tmpDir)
+		if err := runGit(ctx, "", "clone", "--quiet", "--", git.repository, tmpDir); err != nil {
+			return err
+		}
+		// anything after -- would be taken as a path, so here it ends the revision instead
+		if err := runGit(ctx, tmpDir, "checkout", "--quiet", git.ref, "--"); err != nil {
+			return err
+		}
+	}
+
+	return os.Rename(tmpDir, checkoutDir)
+}
+
+func runGit(ctx context.Context, dir string, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %s: %s", args[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// parseGitSource supports the git::<url>, git@<host>:<repo> and github.com/<org>/<repo> source forms, including ?ref= pinning and
+// //subdir paths, e.g. git::https://example.com/vpc.git//modules/subnet?ref=v1.2.0
+func parseGitSource(source string) (*gitSource, error) {
+
+	raw := strings.TrimPrefix(source, "git::")
+	switch {
+	case strings.HasPrefix(source, "git::"), strings.HasPrefix(source, "git@"):
+	case strings.HasPrefix(source, "github.com/"):
+		raw = "https://" + raw
+	default:
+		return nil, fmt.Errorf("unsupported module source '%s'", source)
+	}
+
+	var git gitSource
+
+	if parts := strings.SplitN(raw, "?", 2); len(parts) == 2 {
+		raw = parts[0]
+		query, err := url.ParseQuery(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid module source '%s': %w", source, err)
+		}
+		git.ref = query.Get("ref")
+	}
+
+	schemeEnd := strings.Index(raw, "://")
+	if schemeEnd >= 0 {
+		schemeEnd += 3
+	} else {
+		schemeEnd = 0
+	}
+	if subdirStart := strings.Index(raw[schemeEnd:], "//"); subdirStart >= 0 {
+		git.subdir = raw[schemeEnd+subdirStart+2:]
+		raw = raw[:schemeEnd+subdirStart]
+	}
+
+	git.repository = raw
+
+	// git would read these as options
+	if strings.HasPrefix(git.repository, "-") {
+		return nil, fmt.Errorf("invalid module source '%s': the repository must not start with '-'", source)
+	}
+	if strings.HasPrefix(git.ref, "-") {
+		return nil, fmt.Errorf("invalid module source '%s': the ref must not start with '-'", source)
+	}
+	return &git, nil
+}
+
+// isRegistrySource checks for the <namespace>/<name>/<provider> form, optionally prefixed with a registry hostname
+func isRegistrySource(source string) bool {
+	if isLocalModuleSource(source) || strings.Contains(source, "::") || strings.Contains(source, "://") || strings.HasPrefix(source, "github.com/") {
+		return false
+	}
+	parts := strings.Split(strings.SplitN(source, "//", 2)[0], "/")
+	return len(parts) == 3 || (len(parts) == 4 && strings.Contains(parts[0], "."))
+}
+
+// resolveRegistrySource uses the module registry protocol to find the real location of a registry module
+// see https://www.terraform.io/docs/internals/module-registry-protocol.html
+func resolveRegistrySource(ctx context.Context, source string, versionConstraint string) (string, error) {
+
+	var subdir string
+	if parts := strings.SplitN(source, "//", 2); len(parts) == 2 {
+		source = parts[0]
+		subdir = parts[1]
+	}
+
+	host := defaultRegistryHost
+	parts := strings.Split(source, "/")
+	if len(parts) == 4 {
+		host = parts[0]
+		parts = parts[1:]
+	}
+
+	module := strings.Join(parts, "/")
+	address := fmt.Sprintf("https://%s/v1/modules/%s/download", host, module)
+	if versionConstraint != "" {
+		moduleVersion, err := resolveRegistryVersion(ctx, host, module, versionConstraint)
+		if err != nil {
+			return "", err
+		}
+		debug.Log("Resolved version constraint '%s' for '%s' to %s", versionConstraint, source, moduleVersion)
+		address = fmt.Sprintf("https://%s/v1/modules/%s/%s/download", host, module, moduleVersion)
+	}
+
+	resp, err := registryGet(ctx, address)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("registry returned status %d for module '%s'", resp.StatusCode, source)
+	}
+
+	location := resp.Header.Get("X-Terraform-Get")
+	if location == "" {
+		return "", fmt.Errorf("registry did not return a download location for module '%s'", source)
+	}
+
+	if subdir != "" {
+		if parts := strings.SplitN(location, "?", 2); len(parts) == 2 {
+			location = fmt.Sprintf("%s//%s?%s", parts[0], subdir, parts[1])
+		} else {
+			location = fmt.Sprintf("%s//%s", location, subdir)
+		}
+	}
+
+	return location, nil
+}
+
+// registryClient makes the requests to module registries
+var registryClient = http.DefaultClient
+
+func registryGet(ctx context.Context, address string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil) // #nosec G107 - the registry address is built from the module source
+	if err != nil {
+		return nil, err
+	}
+	return registryClient.Do(req)
+}
+
+// resolveRegistryVersion finds the newest version of a registry module which meets a version constraint, in the same
+// way as terraform
+func resolveRegistryVersion(ctx context.Context, host string, module string, versionConstraint string) (string, error) {
+	constraints, err := version.NewConstraint(versionConstraint)
+	if err != nil {
+		return "", fmt.Errorf("invalid version constraint '%s' for module '%s': %w", versionConstraint, module, err)
+	}
+
+	resp, err := registryGet(ctx, fmt.Sprintf("https://%s/v1/modules/%s/versions", host, module))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 300 {
+		return "", fmt.Errorf("registry returned status %d listing the versions of module '%s'", resp.StatusCode, module)
+	}
+
+	var listing struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return "", fmt.Errorf("failed to read the versions of module '%s': %w", module, err)
+	}
+
+	var newest *version.Version
+	for _, m := range listing.Modules {
+		for _, v := range m.Versions {
+			candidate, err := version.NewVersion(v.Version)
+			if err != nil {
+				continue
+			}
+			if constraints.Check(candidate) && (newest == nil || candidate.GreaterThan(newest)) {
+				newest = candidate
+			}
+		}
+	}
+	if newest == nil {
+		return "", fmt.Errorf("no version of module '%s' matches the constraint '%s'", module, versionConstraint)
+	}
+	return newest.Original(), nil
+}
@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// ParseSource parses terraform which isn't read from a directory, such as a file piped to stdin. The filename is used
+// in the ranges of the resulting blocks. Modules are not loaded, as there is no directory to resolve their sources from.
+func (parser *Parser) ParseSource(src []byte, filename string) ([]block.Module, error) {
+
+	parseTime := metrics.Start(metrics.HCLParse)
+	var file *hcl.File
+	var diag hcl.Diagnostics
+	if isJSONSource(src, filename) {
+		file, diag = hclparse.NewParser().ParseJSON(src, filename)
+	} else {
+		file, diag = hclparse.NewParser().ParseHCL(src, filename)
+	}
+	parseTime.Stop()
+	if diag != nil && diag.HasErrors() {
+		return nil, diag
+	}
+	block.RegisterSource(filename, src)
+	knownFiles[filename] = struct{}{}
+
+	fileBlocks, err := LoadBlocksFromFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var blocks block.Blocks
+	for _, fileBlock := range fileBlocks {
+		blocks = append(blocks, block.NewHCLBlock(fileBlock, nil, nil))
+	}
+	metrics.Add(metrics.BlocksLoaded, len(blocks))
+
+	inputVars, err := LoadTFVars(parser.tfvarsPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	debug.Log("Evaluating expressions...")
+	workingDir, _ := os.Getwd()
+	evaluator := NewEvaluator(parser.initialPath, parser.initialPath, workingDir, blocks, inputVars, nil, nil, parser.stopOnHCLError, parser.workspaceName, false)
+	evaluator.skipModules = true
+	evaluator.scanContext = parser.ctx
+	return evaluator.EvaluateAll()
+}
+
+// isJSONSource reports whether source should be parsed as JSON syntax, either because of its filename or, where no
+// meaningful filename is available, because it is an object
+func isJSONSource(src []byte, filename string) bool {
+	if strings.HasSuffix(filename, ".tf.json") {
+		return true
+	}
+	if strings.HasSuffix(filename, ".tf") {
+		return false
+	}
+	return bytes.HasPrefix(bytes.TrimSpace(src), []byte("{"))
+}
@@ -3,11 +3,13 @@ package parser
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
 	"github.com/zclconf/go-cty/cty"
 
 	"github.com/hashicorp/hcl/v2"
@@ -40,6 +42,7 @@ func loadTFVars(filename string) (map[string]cty.Value, error) {
 	}
 
 	debug.Log("loading tfvars-file [%s]", filename)
+	recordInputFile(filename)
 	src, err := ioutil.ReadFile(filename)
 	if err != nil {
 		return nil, err
@@ -50,7 +53,15 @@ func loadTFVars(filename string) (map[string]cty.Value, error) {
 	hclParseTime := metrics.Start(metrics.HCLParse)
 	defer hclParseTime.Stop()
 
-	variableFile, _ := hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	var variableFile *hcl.File
+	if strings.HasSuffix(filename, ".json") {
+		variableFile, _ = hcljson.Parse(src, filename)
+	} else {
+		variableFile, _ = hclsyntax.ParseConfig(src, filename, hcl.Pos{Line: 1, Column: 1})
+	}
+	if variableFile == nil {
+		return nil, fmt.Errorf("could not parse tfvars file '%s'", filename)
+	}
 	attrs, _ := variableFile.Body.JustAttributes()
 
 	for _, attr := range attrs {
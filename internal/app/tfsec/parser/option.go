@@ -1,5 +1,7 @@
 package parser
 
+import "context"
+
 type Option func(p *Parser)
 
 func OptionDoNotSearchTfFiles() Option {
@@ -25,3 +27,28 @@ func OptionWithWorkspaceName(workspaceName string) Option {
 		p.workspaceName = workspaceName
 	}
 }
+
+func OptionDownloadModules() Option {
+	return func(p *Parser) {
+		p.downloadModules = true
+	}
+}
+
+func OptionWithExcludePaths(patterns []string) Option {
+	return func(p *Parser) {
+		p.excludePaths = patterns
+	}
+}
+
+func OptionScanDotTerraform() Option {
+	return func(p *Parser) {
+		p.scanDotTerraform = true
+	}
+}
+
+// OptionWithContext stops parsing, including module downloads, when ctx is cancelled or its deadline passes
+func OptionWithContext(ctx context.Context) Option {
+	return func(p *Parser) {
+		p.ctx = ctx
+	}
+}
@@ -0,0 +1,93 @@
+package parser
+
+import (
+	"crypto/sha256"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// parsed HCL can't be serialised, so parsed files are cached for the lifetime of the process. This saves parsing the
+// same files for every instance of a module, and for every scan when the parser is used by a long-running process.
+// Between runs, the scancache package reuses the results of scans instead.
+var parseCache = struct {
+	sync.Mutex
+	enabled bool
+	files   map[string]cachedFile
+}{
+	enabled: true,
+	files:   make(map[string]cachedFile),
+}
+
+type cachedFile struct {
+	modTime time.Time
+	size    int64
+	hash    [sha256.Size]byte
+	file    *hcl.File
+}
+
+// SetParseCacheEnabled controls whether parsed files are reused. When disabled, every file is read and parsed again
+// each time it is loaded.
+func SetParseCacheEnabled(enabled bool) {
+	parseCache.Lock()
+	defer parseCache.Unlock()
+	parseCache.enabled = enabled
+	parseCache.files = make(map[string]cachedFile)
+}
+
+// parseFileWithCache returns the cached parse of the file if its content is unchanged. The content is only hashed
+// when the modification time or size has changed, so unchanged files are never re-read.
+func parseFileWithCache(path string, info os.FileInfo, parse func(src []byte, filename string) (*hcl.File, hcl.Diagnostics)) (*hcl.File, hcl.Diagnostics) {
+	recordInputFile(path)
+
+	parseCache.Lock()
+	cached, found := parseCache.files[path]
+	enabled := parseCache.enabled
+	parseCache.Unlock()
+
+	if found && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.file, nil
+	}
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read file",
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	hash := sha256.Sum256(src)
+	if found && cached.hash == hash {
+		cached.modTime = info.ModTime()
+		cached.size = info.Size()
+		storeParsedFile(path, cached, enabled)
+		return cached.file, nil
+	}
+
+	file, diag := parse(src, path)
+	if diag == nil || !diag.HasErrors() {
+		storeParsedFile(path, cachedFile{
+			modTime: info.ModTime(),
+			size:    info.Size(),
+			hash:    hash,
+			file:    file,
+		}, enabled)
+	}
+	return file, diag
+}
+
+func storeParsedFile(path string, cached cachedFile, enabled bool) {
+	if !enabled {
+		return
+	}
+	parseCache.Lock()
+	defer parseCache.Unlock()
+	parseCache.files[path] = cached
+}
@@ -1,10 +1,11 @@
 package parser
 
 import (
-	"fmt"
+	"context"
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/bmatcuk/doublestar"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
@@ -16,11 +17,15 @@ import (
 
 // Parser is a tool for parsing terraform templates at a given file system location
 type Parser struct {
-	initialPath    string
-	tfvarsPaths    []string
-	stopOnFirstTf  bool
-	stopOnHCLError bool
-	workspaceName  string
+	initialPath      string
+	tfvarsPaths      []string
+	stopOnFirstTf    bool
+	stopOnHCLError   bool
+	workspaceName    string
+	downloadModules  bool
+	excludePaths     []string
+	scanDotTerraform bool
+	ctx              context.Context
 }
 
 // New creates a new Parser
@@ -29,6 +34,7 @@ func New(initialPath string, options ...Option) *Parser {
 		initialPath:   initialPath,
 		stopOnFirstTf: true,
 		workspaceName: "default",
+		ctx:           context.Background(),
 	}
 
 	for _, option := range options {
@@ -38,7 +44,8 @@ func New(initialPath string, options ...Option) *Parser {
 	return p
 }
 
-// ParseDirectory parses all terraform files within a given directory
+// ParseDirectory parses all terraform files within a given directory. If the parser's context is cancelled, the
+// modules parsed so far are returned along with the context's error.
 func (parser *Parser) ParseDirectory() ([]block.Module, error) {
 
 	debug.Log("Finding Terraform subdirectories...")
@@ -51,20 +58,29 @@ func (parser *Parser) ParseDirectory() ([]block.Module, error) {
 
 	var blocks block.Blocks
 
+	// once the scan is cancelled, the blocks loaded so far are still evaluated as far as possible and returned with
+	// the error, so they can be checked
+LOAD:
 	for _, dir := range subdirectories {
+		if parser.ctx.Err() != nil {
+			break
+		}
 		debug.Log("Beginning parse for directory '%s'...", dir)
-		files, err := LoadDirectory(dir, parser.stopOnHCLError)
+		files, err := loadDirectory(dir, parser.stopOnHCLError, parser.isExcluded)
 		if err != nil {
 			return nil, err
 		}
 
 		for _, file := range files {
+			if parser.ctx.Err() != nil {
+				break LOAD
+			}
 			fileBlocks, err := LoadBlocksFromFile(file)
 			if err != nil {
+				reportHCLError(err, parser.stopOnHCLError)
 				if parser.stopOnHCLError {
 					return nil, err
 				}
-				_, _ = fmt.Fprintf(os.Stderr, "WARNING: HCL error: %s\n", err)
 				continue
 			}
 			if len(fileBlocks) > 0 {
@@ -78,6 +94,10 @@ func (parser *Parser) ParseDirectory() ([]block.Module, error) {
 
 	metrics.Add(metrics.BlocksLoaded, len(blocks))
 
+	if err := parser.ctx.Err(); err != nil && len(blocks) == 0 {
+		return nil, err
+	}
+
 	if len(blocks) == 0 && parser.stopOnFirstTf {
 		return nil, nil
 	}
@@ -102,16 +122,13 @@ func (parser *Parser) ParseDirectory() ([]block.Module, error) {
 
 	debug.Log("Evaluating expressions...")
 	workingDir, _ := os.Getwd()
-	evaluator := NewEvaluator(tfPath, tfPath, workingDir, blocks, inputVars, modulesMetadata, nil, parser.stopOnHCLError, parser.workspaceName)
-	modules, err := evaluator.EvaluateAll()
-	if err != nil {
-		return nil, err
-	}
-	return modules, nil
-
+	evaluator := NewEvaluator(tfPath, tfPath, workingDir, blocks, inputVars, modulesMetadata, nil, parser.stopOnHCLError, parser.workspaceName, parser.downloadModules)
+	evaluator.scanContext = parser.ctx
+	return evaluator.EvaluateAll()
 }
 
 func (parser *Parser) getSubdirectories(path string) ([]string, error) {
+	recordInputDir(path)
 	entries, err := ioutil.ReadDir(path)
 	if err != nil {
 		return nil, err
@@ -120,16 +137,25 @@ func (parser *Parser) getSubdirectories(path string) ([]string, error) {
 	var results []string
 	for _, entry := range entries {
 		if !entry.IsDir() && (filepath.Ext(entry.Name()) == ".tf" || strings.HasSuffix(entry.Name(), ".tf.json")) {
+			if parser.isExcluded(filepath.Join(path, entry.Name())) {
+				continue
+			}
 			debug.Log("Found qualifying subdirectory containing .tf files: %s", path)
 			results = append(results, path)
 			if parser.stopOnFirstTf {
 				return results, nil
 			}
+			// the directory only needs to be listed once, however many files qualify it
+			break
 		}
 	}
 
 	for _, entry := range entries {
 		if entry.IsDir() {
+			if parser.isExcluded(filepath.Join(path, entry.Name())) {
+				debug.Log("Skipping excluded directory %s", filepath.Join(path, entry.Name()))
+				continue
+			}
 			dirs, err := parser.getSubdirectories(filepath.Join(path, entry.Name()))
 			if err != nil {
 				return nil, err
@@ -140,3 +166,31 @@ func (parser *Parser) getSubdirectories(path string) ([]string, error) {
 
 	return results, nil
 }
+
+// isExcluded checks a path against the --exclude-path patterns, which are matched relative to the initial path. A
+// pattern ending in /** also excludes the directory itself. .terraform directories are excluded unless requested.
+func (parser *Parser) isExcluded(path string) bool {
+	if !parser.scanDotTerraform && filepath.Base(path) == ".terraform" {
+		return true
+	}
+	if len(parser.excludePaths) == 0 {
+		return false
+	}
+	relative, err := filepath.Rel(parser.initialPath, path)
+	if err != nil {
+		return false
+	}
+	relative = filepath.ToSlash(relative)
+	for _, pattern := range parser.excludePaths {
+		pattern = strings.TrimPrefix(filepath.ToSlash(pattern), "./")
+		if matched, err := doublestar.Match(pattern, relative); err == nil && matched {
+			return true
+		}
+		if strings.HasSuffix(pattern, "/**") {
+			if matched, err := doublestar.Match(strings.TrimSuffix(pattern, "/**"), relative); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
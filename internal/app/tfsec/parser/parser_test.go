@@ -1,12 +1,18 @@
 package parser
 
 import (
+	"context"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
 
 	"github.com/zclconf/go-cty/cty"
@@ -64,6 +70,7 @@ data "cats_cat" "the-cats-mother" {
 	assert.Equal(t, "variable", variables[0].Type())
 	require.Len(t, variables[0].Labels(), 1)
 	assert.Equal(t, "cats_mother", variables[0].TypeLabel())
+	assert.Equal(t, "", variables[0].NameLabel())
 	defaultVal := variables[0].GetAttribute("default")
 	require.NotNil(t, defaultVal)
 	assert.Equal(t, cty.String, defaultVal.Value().Type())
@@ -108,6 +115,51 @@ data "cats_cat" "the-cats-mother" {
 	assert.Equal(t, "the-cats-mother", dataBlocks[0].NameLabel())
 
 	assert.Equal(t, "boots", dataBlocks[0].GetAttribute("name").Value().AsString())
+
+	// locals have no labels, and neither does a missing block
+	localsBlocks := blocks.OfType("locals")
+	require.Len(t, localsBlocks, 1)
+	assert.Empty(t, localsBlocks[0].Labels())
+	assert.Equal(t, "", localsBlocks[0].TypeLabel())
+	assert.Equal(t, "", localsBlocks[0].NameLabel())
+
+	missing := resourceBlocks[0].GetBlock("missing")
+	assert.Empty(t, missing.Labels())
+	assert.Equal(t, "", missing.TypeLabel())
+	assert.Equal(t, "", missing.NameLabel())
+}
+
+func Test_TFVarsOverrideDefaults(t *testing.T) {
+
+	path := createTestFile("test.tf", `
+variable "acl" {
+	default = "public-read"
+}
+
+variable "encrypted" {
+	default = false
+}
+
+resource "cats_bucket" "bucket" {
+	acl = var.acl
+	encrypted = var.encrypted
+}
+`)
+	dir := filepath.Dir(path)
+	hclVars := filepath.Join(dir, "first.tfvars")
+	jsonVars := filepath.Join(dir, "second.tfvars.json")
+	require.NoError(t, ioutil.WriteFile(hclVars, []byte(`acl = "authenticated-read"
+encrypted = true
+`), 0600))
+	require.NoError(t, ioutil.WriteFile(jsonVars, []byte(`{"acl": "private"}`), 0600))
+
+	modules, err := New(dir, OptionStopOnHCLError(), OptionWithTFVarsPaths([]string{hclVars, jsonVars})).ParseDirectory()
+	require.NoError(t, err)
+
+	resources := modules[0].GetResourcesByType("cats_bucket")
+	require.Len(t, resources, 1)
+	assert.Equal(t, "private", resources[0].GetAttribute("acl").Value().AsString())
+	assert.True(t, resources[0].GetAttribute("encrypted").IsTrue())
 }
 
 func Test_Modules(t *testing.T) {
@@ -229,6 +281,101 @@ output "mod_result" {
 	assert.Equal(t, "ok", childValAttr.Value().AsString())
 }
 
+func Test_IsLocalModuleSource(t *testing.T) {
+	assert.True(t, isLocalModuleSource("./modules/bucket"))
+	assert.True(t, isLocalModuleSource("../shared"))
+	assert.False(t, isLocalModuleSource("terraform-aws-modules/vpc/aws"))
+	assert.False(t, isLocalModuleSource("git::https://example.com/vpc.git"))
+	assert.False(t, isLocalModuleSource(".modules"))
+}
+
+func Test_ParseGitSource(t *testing.T) {
+	git, err := parseGitSource("git::https://example.com/vpc.git//modules/subnet?ref=v1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/vpc.git", git.repository)
+	assert.Equal(t, "modules/subnet", git.subdir)
+	assert.Equal(t, "v1.2.0", git.ref)
+
+	git, err = parseGitSource("github.com/hashicorp/example")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/hashicorp/example", git.repository)
+	assert.Equal(t, "", git.subdir)
+	assert.Equal(t, "", git.ref)
+
+	git, err = parseGitSource("git@github.com:hashicorp/example.git?ref=main")
+	require.NoError(t, err)
+	assert.Equal(t, "git@github.com:hashicorp/example.git", git.repository)
+	assert.Equal(t, "main", git.ref)
+
+	_, err = parseGitSource("s3::https://s3-eu-west-1.amazonaws.com/bucket/vpc.zip")
+	assert.Error(t, err)
+
+	_, err = parseGitSource("git::--upload-pack=touch /tmp/pwned")
+	assert.Error(t, err)
+
+	_, err = parseGitSource("git::https://example.com/vpc.git?ref=--upload-pack=touch /tmp/pwned")
+	assert.Error(t, err)
+}
+
+func Test_ModuleSubdirMustBeInsideCheckout(t *testing.T) {
+	checkoutDir := filepath.Join(os.TempDir(), "modules", "checkout")
+
+	path, err := moduleSubdir(checkoutDir, "modules/subnet")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(checkoutDir, "modules", "subnet"), path)
+
+	path, err = moduleSubdir(checkoutDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, checkoutDir, path)
+
+	_, err = moduleSubdir(checkoutDir, "../../..")
+	assert.Error(t, err)
+
+	_, err = moduleSubdir(checkoutDir, "modules/../../other")
+	assert.Error(t, err)
+}
+
+func Test_RegistryVersionConstraintIsHonoured(t *testing.T) {
+	var downloaded string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/modules/example/vpc/aws/versions":
+			_, _ = w.Write([]byte(`{"modules":[{"versions":[{"version":"1.0.0"},{"version":"1.2.3"},{"version":"1.3.0-beta"},{"version":"2.0.0"}]}]}`))
+		case "/v1/modules/example/vpc/aws/1.2.3/download":
+			downloaded = "1.2.3"
+			w.Header().Set("X-Terraform-Get", "git::https://example.com/vpc.git?ref=v1.2.3")
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	defer func(client *http.Client) { registryClient = client }(registryClient)
+	registryClient = server.Client()
+	source := strings.TrimPrefix(server.URL, "https://") + "/example/vpc/aws"
+
+	location, err := resolveRegistrySource(context.Background(), source, "~> 1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "git::https://example.com/vpc.git?ref=v1.2.3", location)
+	assert.Equal(t, "1.2.3", downloaded)
+
+	_, err = resolveRegistrySource(context.Background(), source, ">= 3.0")
+	assert.Error(t, err)
+
+	_, err = resolveRegistrySource(context.Background(), source, "not a constraint")
+	assert.Error(t, err)
+}
+
+func Test_IsRegistrySource(t *testing.T) {
+	assert.True(t, isRegistrySource("terraform-aws-modules/vpc/aws"))
+	assert.True(t, isRegistrySource("app.terraform.io/example-corp/k8s-cluster/azurerm"))
+	assert.True(t, isRegistrySource("terraform-aws-modules/iam/aws//modules/iam-user"))
+	assert.False(t, isRegistrySource("github.com/hashicorp/example"))
+	assert.False(t, isRegistrySource("git::https://example.com/vpc.git"))
+	assert.False(t, isRegistrySource("./modules/bucket"))
+}
+
 func createTestFile(filename, contents string) string {
 	dir, err := ioutil.TempDir(os.TempDir(), "tfsec")
 	if err != nil {
@@ -273,3 +420,278 @@ func createTestFileWithModule(contents string, moduleContents string, moduleName
 
 	return rootPath
 }
+
+func Test_ParseCacheReusesUnchangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "main.tf")
+	require.NoError(t, ioutil.WriteFile(path, []byte(`resource "aws_s3_bucket" "a" {}`), 0600))
+
+	first, err := LoadDirectory(dir, true)
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	second, err := LoadDirectory(dir, true)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Same(t, first[0], second[0])
+
+	// touching the file without changing it keeps the cached parse, as the content hash still matches
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	touched, err := LoadDirectory(dir, true)
+	require.NoError(t, err)
+	assert.Same(t, first[0], touched[0])
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(`resource "aws_s3_bucket" "b" {}`), 0600))
+	evenLater := later.Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, evenLater, evenLater))
+	changed, err := LoadDirectory(dir, true)
+	require.NoError(t, err)
+	require.Len(t, changed, 1)
+	assert.NotSame(t, first[0], changed[0])
+	assert.Contains(t, string(changed[0].Bytes), `"b"`)
+}
+
+func Test_ParseSource(t *testing.T) {
+	src := []byte(`
+variable "acl" {
+	default = "private"
+}
+
+module "missing" {
+	source = "../does-not-exist"
+}
+
+resource "aws_s3_bucket" "my-bucket" {
+	acl = var.acl
+}
+`)
+	modules, err := New(".", OptionStopOnHCLError()).ParseSource(src, "<stdin>")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	buckets := modules[0].GetBlocks().OfType("resource")
+	require.Len(t, buckets, 1)
+	assert.Equal(t, "<stdin>", buckets[0].Range().Filename)
+	assert.Equal(t, "private", buckets[0].GetAttribute("acl").Value().AsString())
+
+	lines, _, err := buckets[0].Range().ReadLines(false)
+	require.NoError(t, err)
+	assert.Contains(t, lines[0], `resource "aws_s3_bucket" "my-bucket"`)
+}
+
+func Test_ParseSourceJSON(t *testing.T) {
+	src := []byte(`{
+  "variable": {
+    "acl": {
+      "default": "private"
+    }
+  },
+  "resource": {
+    "aws_s3_bucket": {
+      "my-bucket": {
+        "acl": "${var.acl}"
+      }
+    }
+  }
+}
+`)
+	modules, err := New(".", OptionStopOnHCLError()).ParseSource(src, "<stdin>")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	buckets := modules[0].GetResourcesByType("aws_s3_bucket")
+	require.Len(t, buckets, 1)
+	assert.Equal(t, "private", buckets[0].GetAttribute("acl").Value().AsString())
+	assert.Equal(t, 9, buckets[0].Range().StartLine)
+	assert.Equal(t, 11, buckets[0].Range().EndLine)
+}
+
+func Test_ExcludePaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, path := range []string{
+		"main/main.tf",
+		"main/override.tf",
+		"examples/basic/main.tf",
+		"vendored/nested/deep/main.tf",
+		".terraform/modules/thing/main.tf",
+	} {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0700))
+		require.NoError(t, ioutil.WriteFile(full, []byte(`resource "aws_s3_bucket" "bucket" {}`), 0600))
+	}
+
+	var files []string
+	collect := func(modules []block.Module) {
+		files = nil
+		for _, module := range modules {
+			for _, b := range module.GetBlocks() {
+				relative, err := filepath.Rel(dir, b.Range().Filename)
+				require.NoError(t, err)
+				files = append(files, filepath.ToSlash(relative))
+			}
+		}
+		sort.Strings(files)
+	}
+
+	modules, err := New(dir, OptionDoNotSearchTfFiles(), OptionWithExcludePaths([]string{"examples/**", "**/deep", "main/override.tf"})).ParseDirectory()
+	require.NoError(t, err)
+	collect(modules)
+	assert.Equal(t, []string{"main/main.tf"}, files)
+
+	modules, err = New(dir, OptionDoNotSearchTfFiles(), OptionScanDotTerraform()).ParseDirectory()
+	require.NoError(t, err)
+	collect(modules)
+	assert.Contains(t, files, ".terraform/modules/thing/main.tf")
+	assert.Len(t, files, 5)
+}
+
+func Test_PolicyDocumentJSON(t *testing.T) {
+	src := []byte(`
+variable "bucket" {
+	default = "my-bucket"
+}
+
+data "aws_iam_policy_document" "policy" {
+	statement {
+		sid       = "Read"
+		actions   = ["s3:GetObject"]
+		resources = ["arn:aws:s3:::${var.bucket}/*"]
+
+		principals {
+			type        = "AWS"
+			identifiers = ["*"]
+		}
+	}
+
+	statement {
+		effect    = "Deny"
+		actions   = ["s3:DeleteObject", "s3:PutObject"]
+		resources = ["*"]
+	}
+}
+
+data "aws_iam_policy_document" "unknown" {
+	statement {
+		actions   = [var.not_declared]
+		resources = ["*"]
+	}
+}
+
+resource "aws_iam_policy" "known" {
+	policy = data.aws_iam_policy_document.policy.json
+}
+
+resource "aws_iam_policy" "unknown" {
+	policy = data.aws_iam_policy_document.unknown.json
+}
+`)
+	modules, err := New(".", OptionStopOnHCLError()).ParseSource(src, "<stdin>")
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	policies := modules[0].GetResourcesByType("aws_iam_policy")
+	require.Len(t, policies, 2)
+
+	known := policies[0].GetAttribute("policy")
+	require.True(t, known.IsString())
+	assert.JSONEq(t, `{
+	"Version": "2012-10-17",
+	"Statement": [
+		{
+			"Sid": "Read",
+			"Effect": "Allow",
+			"Action": "s3:GetObject",
+			"Resource": "arn:aws:s3:::my-bucket/*",
+			"Principal": {"AWS": "*"}
+		},
+		{
+			"Effect": "Deny",
+			"Action": ["s3:DeleteObject", "s3:PutObject"],
+			"Resource": "*"
+		}
+	]
+}`, known.Value().AsString())
+
+	assert.False(t, policies[1].GetAttribute("policy").IsString())
+}
+
+func Test_ProviderConfig(t *testing.T) {
+
+	path := createTestFileWithModule(`
+terraform {
+	required_providers {
+		aws = {
+			source  = "hashicorp/aws"
+			version = "~> 3.0"
+		}
+		google = "~> 4.0"
+	}
+}
+
+provider "aws" {
+	region = "eu-west-1"
+}
+
+provider "aws" {
+	alias  = "west"
+	region = "us-west-2"
+}
+
+resource "aws_s3_bucket" "default" {
+}
+
+resource "aws_s3_bucket" "west" {
+	provider = aws.west
+}
+
+resource "google_storage_bucket" "unconfigured" {
+}
+
+module "child" {
+	source = "../module"
+}
+`,
+		`
+resource "aws_s3_bucket" "child" {
+}
+`,
+		"module",
+	)
+
+	modules, err := New(path, OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+	require.Len(t, modules, 2)
+
+	region := func(module block.Module, name string) string {
+		for _, b := range module.GetBlocks() {
+			if b.FullName() != name {
+				continue
+			}
+			providerBlock, ok := block.ProviderConfig(b)
+			require.True(t, ok)
+			region, ok := providerBlock.GetAttribute("region").AsStringValue()
+			require.True(t, ok)
+			return region
+		}
+		t.Fatalf("block %s not found", name)
+		return ""
+	}
+
+	assert.Equal(t, "eu-west-1", region(modules[0], "aws_s3_bucket.default"))
+	assert.Equal(t, "us-west-2", region(modules[0], "aws_s3_bucket.west"))
+	assert.Equal(t, "eu-west-1", region(modules[1], "module.child:aws_s3_bucket.child"))
+
+	unconfigured := modules[0].GetResourcesByType("google_storage_bucket")
+	require.Len(t, unconfigured, 1)
+	_, ok := block.ProviderConfig(unconfigured[0])
+	assert.False(t, ok)
+
+	ctx := modules[0].GetResourcesByType("aws_s3_bucket")[0].Context()
+	awsProvider, ok := ctx.RequiredProvider("aws")
+	require.True(t, ok)
+	assert.Equal(t, block.RequiredProvider{Source: "hashicorp/aws", Version: "~> 3.0"}, awsProvider)
+	googleProvider, ok := ctx.RequiredProvider("google")
+	require.True(t, ok)
+	assert.Equal(t, block.RequiredProvider{Version: "~> 4.0"}, googleProvider)
+}
@@ -0,0 +1,202 @@
+package parser
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const policyDocumentType = "aws_iam_policy_document"
+
+type synthesisedPolicyDocument struct {
+	Version   string                       `json:"Version"`
+	Statement []synthesisedPolicyStatement `json:"Statement"`
+}
+
+type synthesisedPolicyStatement struct {
+	Sid          string                            `json:"Sid,omitempty"`
+	Effect       string                            `json:"Effect"`
+	Action       interface{}                       `json:"Action,omitempty"`
+	NotAction    interface{}                       `json:"NotAction,omitempty"`
+	Resource     interface{}                       `json:"Resource,omitempty"`
+	NotResource  interface{}                       `json:"NotResource,omitempty"`
+	Principal    interface{}                       `json:"Principal,omitempty"`
+	NotPrincipal interface{}                       `json:"NotPrincipal,omitempty"`
+	Condition    map[string]map[string]interface{} `json:"Condition,omitempty"`
+}
+
+// withPolicyDocumentJSON adds the rendered json attribute to the values of an aws_iam_policy_document data block, so
+// references such as data.aws_iam_policy_document.x.json resolve to a policy the checks can decode. The values are
+// returned unchanged if the document can't be rendered from what is known at scan time.
+func withPolicyDocumentJSON(b block.Block, values cty.Value) cty.Value {
+	if b.Type() != "data" || b.TypeLabel() != policyDocumentType {
+		return values
+	}
+	rendered, ok := renderPolicyDocument(b)
+	if !ok {
+		return values
+	}
+	valueMap := values.AsValueMap()
+	if valueMap == nil {
+		valueMap = make(map[string]cty.Value)
+	}
+	valueMap["json"] = cty.StringVal(rendered)
+	return cty.ObjectVal(valueMap)
+}
+
+func renderPolicyDocument(b block.Block) (string, bool) {
+	// merging with other documents is done by terraform at plan time, so we can't render these reliably
+	for _, name := range []string{"source_json", "override_json", "source_policy_documents", "override_policy_documents"} {
+		if b.HasChild(name) {
+			return "", false
+		}
+	}
+
+	document := synthesisedPolicyDocument{
+		Version: "2012-10-17",
+	}
+	if versionAttr := b.GetAttribute("version"); versionAttr.IsNotNil() {
+		version, ok := versionAttr.AsStringValue()
+		if !ok {
+			return "", false
+		}
+		document.Version = version
+	}
+
+	for _, statementBlock := range b.GetBlocks("statement") {
+		statement, ok := renderPolicyStatement(statementBlock)
+		if !ok {
+			return "", false
+		}
+		document.Statement = append(document.Statement, statement)
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func renderPolicyStatement(b block.Block) (synthesisedPolicyStatement, bool) {
+	statement := synthesisedPolicyStatement{
+		Effect: "Allow",
+	}
+
+	var ok bool
+	if sidAttr := b.GetAttribute("sid"); sidAttr.IsNotNil() {
+		if statement.Sid, ok = sidAttr.AsStringValue(); !ok {
+			return statement, false
+		}
+	}
+	if effectAttr := b.GetAttribute("effect"); effectAttr.IsNotNil() {
+		if statement.Effect, ok = effectAttr.AsStringValue(); !ok {
+			return statement, false
+		}
+	}
+
+	for name, field := range map[string]*interface{}{
+		"actions":       &statement.Action,
+		"not_actions":   &statement.NotAction,
+		"resources":     &statement.Resource,
+		"not_resources": &statement.NotResource,
+	} {
+		values, ok := attributeStrings(b.GetAttribute(name))
+		if !ok {
+			return statement, false
+		}
+		*field = compactStrings(values)
+	}
+
+	if statement.Principal, ok = renderPrincipals(b.GetBlocks("principals")); !ok {
+		return statement, false
+	}
+	if statement.NotPrincipal, ok = renderPrincipals(b.GetBlocks("not_principals")); !ok {
+		return statement, false
+	}
+
+	for _, conditionBlock := range b.GetBlocks("condition") {
+		test, testOK := conditionBlock.GetAttribute("test").AsStringValue()
+		variable, variableOK := conditionBlock.GetAttribute("variable").AsStringValue()
+		values, valuesOK := attributeStrings(conditionBlock.GetAttribute("values"))
+		if !testOK || !variableOK || !valuesOK {
+			return statement, false
+		}
+		if statement.Condition == nil {
+			statement.Condition = make(map[string]map[string]interface{})
+		}
+		if statement.Condition[test] == nil {
+			statement.Condition[test] = make(map[string]interface{})
+		}
+		statement.Condition[test][variable] = compactStrings(values)
+	}
+
+	return statement, true
+}
+
+func renderPrincipals(principalBlocks block.Blocks) (interface{}, bool) {
+	if len(principalBlocks) == 0 {
+		return nil, true
+	}
+	identifiersByType := make(map[string][]string)
+	for _, principalBlock := range principalBlocks {
+		principalType, ok := principalBlock.GetAttribute("type").AsStringValue()
+		if !ok {
+			return nil, false
+		}
+		identifiers, ok := attributeStrings(principalBlock.GetAttribute("identifiers"))
+		if !ok {
+			return nil, false
+		}
+		identifiersByType[principalType] = append(identifiersByType[principalType], identifiers...)
+	}
+	// anonymous access is rendered as a bare wildcard rather than as a map
+	if identifiers, ok := identifiersByType["*"]; ok && len(identifiersByType) == 1 && len(identifiers) == 1 && identifiers[0] == "*" {
+		return "*", true
+	}
+	principals := make(map[string]interface{}, len(identifiersByType))
+	for principalType, identifiers := range identifiersByType {
+		sort.Strings(identifiers)
+		principals[principalType] = compactStrings(identifiers)
+	}
+	return principals, true
+}
+
+// attributeStrings returns the string values of an attribute, and false if any of them are not yet known
+func attributeStrings(attr block.Attribute) ([]string, bool) {
+	if attr.IsNil() {
+		return nil, true
+	}
+	val := attr.Value()
+	if val == cty.NilVal || val.IsNull() || !val.IsWhollyKnown() {
+		return nil, false
+	}
+	if val.Type() == cty.String {
+		return []string{val.AsString()}, true
+	}
+	if !val.CanIterateElements() {
+		return nil, false
+	}
+	var results []string
+	for _, element := range val.AsValueSlice() {
+		if element.IsNull() || element.Type() != cty.String {
+			return nil, false
+		}
+		results = append(results, element.AsString())
+	}
+	return results, true
+}
+
+// compactStrings renders single values as a string as terraform does, and anything else as a list
+func compactStrings(values []string) interface{} {
+	switch len(values) {
+	case 0:
+		return nil
+	case 1:
+		return values[0]
+	default:
+		return values
+	}
+}
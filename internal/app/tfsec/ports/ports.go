@@ -0,0 +1,179 @@
+package ports
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// DefaultSensitivePorts are the management and database ports which should never be reachable from the internet:
+// SSH, RDP, MSSQL, Oracle, MySQL, PostgreSQL, Redis and MongoDB
+var DefaultSensitivePorts = []int{22, 3389, 1433, 1521, 3306, 5432, 6379, 27017}
+
+var sensitivePorts = DefaultSensitivePorts
+
+// SetSensitivePorts sets the ports which are reported when open to the internet, restoring the defaults if none are
+// given
+func SetSensitivePorts(ports []int) {
+	if len(ports) == 0 {
+		sensitivePorts = DefaultSensitivePorts
+		return
+	}
+	sensitivePorts = ports
+}
+
+// SensitivePorts returns the ports which are reported when open to the internet
+func SensitivePorts() []int {
+	return sensitivePorts
+}
+
+// Range is an inclusive range of ports
+type Range struct {
+	From int
+	To   int
+}
+
+// AllPorts covers every port, as allowed by rules which don't restrict the port or protocol
+var AllPorts = Range{From: 0, To: 65535}
+
+func (r Range) contains(port int) bool {
+	return port >= r.From && port <= r.To
+}
+
+// ParseRange reads a port range as written in firewall rules, e.g. 22, 1000-2000 or * for all ports
+func ParseRange(s string) (Range, bool) {
+	s = strings.TrimSpace(s)
+	if s == "*" {
+		return AllPorts, true
+	}
+	if parts := strings.SplitN(s, "-", 2); len(parts) == 2 {
+		fromPort, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return Range{}, false
+		}
+		toPort, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return Range{}, false
+		}
+		return Range{From: fromPort, To: toPort}, true
+	}
+	port, err := strconv.Atoi(s)
+	if err != nil {
+		return Range{}, false
+	}
+	return Range{From: port, To: port}, true
+}
+
+// RangesFromAttribute reads the port ranges of an attribute holding either a single range or a list of them. Values
+// which aren't known are skipped.
+func RangesFromAttribute(attr block.Attribute) []Range {
+	if attr.IsNil() {
+		return nil
+	}
+	val := attr.Value()
+	if val == cty.NilVal || val.IsNull() || !val.IsKnown() {
+		return nil
+	}
+	values := []cty.Value{val}
+	if val.CanIterateElements() && !val.Type().IsMapType() && !val.Type().IsObjectType() {
+		values = val.AsValueSlice()
+	}
+
+	var ranges []Range
+	for _, value := range values {
+		if value.IsNull() || !value.IsKnown() {
+			continue
+		}
+		switch value.Type() {
+		case cty.String:
+			if r, ok := ParseRange(value.AsString()); ok {
+				ranges = append(ranges, r)
+			}
+		case cty.Number:
+			if port, accuracy := value.AsBigFloat().Int64(); accuracy == 0 {
+				ranges = append(ranges, Range{From: int(port), To: int(port)})
+			}
+		}
+	}
+	return ranges
+}
+
+// SensitivePortsIn returns the sensitive ports covered by any of the ranges, in ascending order
+func SensitivePortsIn(ranges ...Range) []int {
+	var exposed []int
+	for _, port := range sensitivePorts {
+		for _, r := range ranges {
+			if r.contains(port) {
+				exposed = append(exposed, port)
+				break
+			}
+		}
+	}
+	sort.Ints(exposed)
+	return exposed
+}
+
+// SecurityGroupIngress returns the ports allowed by an ingress block of an aws_security_group, or by an
+// aws_security_group_rule, along with the attributes which allow them. A protocol of -1 or all allows every port, and
+// ICMP allows none.
+func SecurityGroupIngress(ingressBlock block.Block) (Range, []block.Attribute, bool) {
+	protocolAttr := ingressBlock.GetAttribute("protocol")
+	if protocolAttr.IsAny("-1", "all") || protocolAttr.Equals(-1) {
+		return AllPorts, []block.Attribute{protocolAttr}, true
+	}
+	if protocolAttr.IsAny("icmp", "icmpv6", "1", "58") {
+		return Range{}, nil, false
+	}
+	fromPortAttr := ingressBlock.GetAttribute("from_port")
+	toPortAttr := ingressBlock.GetAttribute("to_port")
+	fromPort, fromOK := fromPortAttr.AsIntValue()
+	toPort, toOK := toPortAttr.AsIntValue()
+	if !fromOK || !toOK {
+		return Range{}, nil, false
+	}
+	return Range{From: fromPort, To: toPort}, []block.Attribute{fromPortAttr, toPortAttr}, true
+}
+
+// ExposureAnnotation describes the sensitive ports open to the internet, and where the port attributes which open them
+// are, for the range annotation of a result
+func ExposureAnnotation(exposed []int, portAttrs ...block.Attribute) string {
+	annotation := fmt.Sprintf("port(s) %s are open to the internet", joinPorts(exposed))
+	var locations []string
+	for _, attr := range portAttrs {
+		if attr.IsNil() {
+			continue
+		}
+		locations = append(locations, fmt.Sprintf("%s on line %d", attr.Name(), attr.Range().StartLine))
+	}
+	if len(locations) > 0 {
+		annotation += fmt.Sprintf(" by %s", strings.Join(locations, ", "))
+	}
+	return annotation
+}
+
+// ReportExposure adds a result for a firewall rule which allows traffic from the internet, through sourceAttr, to any
+// of the sensitive ports in ranges, which are set by portAttrs. Nothing is reported if none of the ranges cover a
+// sensitive port.
+func ReportExposure(set result.Set, resourceBlock block.Block, sourceAttr block.Attribute, ranges []Range, portAttrs ...block.Attribute) {
+	exposed := SensitivePortsIn(ranges...)
+	if len(exposed) == 0 {
+		return
+	}
+	set.AddResult().
+		WithDescription("Resource '%s' exposes sensitive port(s) %s to the internet.", resourceBlock.FullName(), joinPorts(exposed)).
+		WithAttribute(sourceAttr).
+		WithRangeAnnotation(ExposureAnnotation(exposed, portAttrs...))
+}
+
+func joinPorts(exposed []int) string {
+	var portNames []string
+	for _, port := range exposed {
+		portNames = append(portNames, strconv.Itoa(port))
+	}
+	return strings.Join(portNames, ", ")
+}
@@ -0,0 +1,48 @@
+package ports_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseRange(t *testing.T) {
+	var tests = []struct {
+		input    string
+		expected ports.Range
+		valid    bool
+	}{
+		{input: "22", expected: ports.Range{From: 22, To: 22}, valid: true},
+		{input: "1000-2000", expected: ports.Range{From: 1000, To: 2000}, valid: true},
+		{input: " 80 - 90 ", expected: ports.Range{From: 80, To: 90}, valid: true},
+		{input: "*", expected: ports.AllPorts, valid: true},
+		{input: "ssh"},
+		{input: "22-"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			r, ok := ports.ParseRange(test.input)
+			assert.Equal(t, test.valid, ok)
+			assert.Equal(t, test.expected, r)
+		})
+	}
+}
+
+func Test_SensitivePortsIn(t *testing.T) {
+	assert.Equal(t, []int{22}, ports.SensitivePortsIn(ports.Range{From: 22, To: 22}, ports.Range{From: 443, To: 443}))
+	assert.Equal(t, []int{1433, 1521, 3306, 3389}, ports.SensitivePortsIn(ports.Range{From: 1000, To: 4000}))
+	assert.Empty(t, ports.SensitivePortsIn(ports.Range{From: 80, To: 443}))
+	assert.Len(t, ports.SensitivePortsIn(ports.AllPorts), len(ports.DefaultSensitivePorts))
+}
+
+func Test_SetSensitivePorts(t *testing.T) {
+	defer ports.SetSensitivePorts(nil)
+
+	ports.SetSensitivePorts([]int{8080})
+	assert.Equal(t, []int{8080}, ports.SensitivePortsIn(ports.Range{From: 22, To: 9000}))
+
+	ports.SetSensitivePorts(nil)
+	assert.Equal(t, ports.DefaultSensitivePorts, ports.SensitivePorts())
+}
@@ -0,0 +1,35 @@
+package protection
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+)
+
+// DefaultProtectedTypes are the resources holding data which can't be recreated once they are destroyed
+var DefaultProtectedTypes = []string{"aws_db_instance", "aws_dynamodb_table", "aws_rds_cluster"}
+
+var protectedTypes = DefaultProtectedTypes
+
+// SetProtectedTypes sets the resource types which should be protected from deletion, restoring the defaults if none
+// are given
+func SetProtectedTypes(types []string) {
+	if len(types) == 0 {
+		protectedTypes = DefaultProtectedTypes
+		return
+	}
+	protectedTypes = types
+}
+
+// ProtectedTypes returns the resource types which should be protected from deletion
+func ProtectedTypes() []string {
+	return protectedTypes
+}
+
+// IsProtected reports whether a resource is one of the types which should be protected from deletion
+func IsProtected(resourceBlock block.Block) bool {
+	for _, protectedType := range protectedTypes {
+		if resourceBlock.TypeLabel() == protectedType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package publicip
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var allowedTags []string
+var allowedLabels map[string]string
+
+// SetAllowed sets how instances which are allowed a public IP are recognised: by carrying one of the network tags, or
+// one of the labels. A label with an empty value only needs to be present.
+func SetAllowed(tags []string, labels map[string]string) {
+	allowedTags = tags
+	allowedLabels = labels
+}
+
+// AllowedTags returns the network tags which allow an instance a public IP
+func AllowedTags() []string {
+	return allowedTags
+}
+
+// AllowedLabels returns the labels which allow an instance a public IP
+func AllowedLabels() map[string]string {
+	return allowedLabels
+}
+
+// IsAllowed reports whether a google_compute_instance is allowed a public IP by its network tags or labels. It is
+// always false if nothing is configured.
+func IsAllowed(instanceBlock block.Block) bool {
+	return hasAllowedTag(instanceBlock) || hasAllowedLabel(instanceBlock)
+}
+
+func hasAllowedTag(instanceBlock block.Block) bool {
+	if len(allowedTags) == 0 {
+		return false
+	}
+	tagsAttr := instanceBlock.GetAttribute("tags")
+	if tagsAttr.IsNil() {
+		return false
+	}
+	tags := tagsAttr.Value()
+	if tags.IsNull() || !tags.IsKnown() || !tags.CanIterateElements() {
+		return false
+	}
+	for it := tags.ElementIterator(); it.Next(); {
+		_, tag := it.Element()
+		if !tag.IsKnown() || tag.IsNull() || tag.Type() != cty.String {
+			continue
+		}
+		for _, allowed := range allowedTags {
+			if tag.AsString() == allowed {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasAllowedLabel(instanceBlock block.Block) bool {
+	if len(allowedLabels) == 0 {
+		return false
+	}
+	labelsAttr := instanceBlock.GetAttribute("labels")
+	if labelsAttr.IsNil() {
+		return false
+	}
+	labels := labelsAttr.MapValue()
+	for key, expected := range allowedLabels {
+		value, ok := labels[key]
+		if !ok {
+			continue
+		}
+		if expected == "" {
+			return true
+		}
+		if value.IsKnown() && !value.IsNull() && value.Type() == cty.String && value.AsString() == expected {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package retention
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+)
+
+// Check is a rule requiring a resource to keep its data for a minimum number of days
+type Check struct {
+	// Rule holds everything but the CheckFunc, which is built from the retention requirement
+	Rule rule.Rule
+	// Attribute is the path to the retention attribute, using dots to reach into nested blocks
+	Attribute string
+	// MinimumDays is the retention required unless the config overrides it for this rule
+	MinimumDays int
+	// ZeroMeansForever treats an unset attribute, or a value of 0, as never expiring the data
+	ZeroMeansForever bool
+	// Skip returns true for resources the requirement doesn't apply to
+	Skip func(resourceBlock block.Block) bool
+}
+
+var defaultMinimums = map[string]int{}
+var minimumOverrides map[string]int
+
+// SetMinimums overrides the minimum retention in days of rules registered with RegisterCheck, keyed by rule ID
+func SetMinimums(minimums map[string]int) {
+	minimumOverrides = minimums
+}
+
+// Minimum returns the minimum retention in days which the rule with the given ID requires
+func Minimum(ruleID string) int {
+	if days, ok := minimumOverrides[ruleID]; ok {
+		return days
+	}
+	return defaultMinimums[ruleID]
+}
+
+// Minimums returns the minimum retention in days of every rule registered with RegisterCheck, keyed by rule ID
+func Minimums() map[string]int {
+	minimums := make(map[string]int, len(defaultMinimums))
+	for ruleID := range defaultMinimums {
+		minimums[ruleID] = Minimum(ruleID)
+	}
+	return minimums
+}
+
+// RegisterCheck registers a rule reporting resources which set the retention attribute below the minimum
+func RegisterCheck(check Check) {
+	r := check.Rule
+	ruleID := r.ID()
+	defaultMinimums[ruleID] = check.MinimumDays
+	r.CheckFunc = func(set result.Set, resourceBlock block.Block, _ block.Module) {
+		if check.Skip != nil && check.Skip(resourceBlock) {
+			return
+		}
+
+		minimumDays := Minimum(ruleID)
+		retentionAttr := resourceBlock.GetNestedAttribute(check.Attribute)
+		if retentionAttr.IsNil() {
+			if !check.ZeroMeansForever && minimumDays > 0 {
+				set.AddResult().
+					WithDescription("Resource '%s' does not set %s, retention should be at least %d days.", resourceBlock.FullName(), check.Attribute, minimumDays).
+					WithBlock(resourceBlock)
+			}
+			return
+		}
+
+		if check.ZeroMeansForever && retentionAttr.IsNumber() && retentionAttr.Equals(0) {
+			return
+		}
+
+		if retentionAttr.LessThan(minimumDays) {
+			set.AddResult().
+				WithDescription("Resource '%s' sets %s below the minimum of %d days.", resourceBlock.FullName(), check.Attribute, minimumDays).
+				WithAttribute(retentionAttr)
+		}
+	}
+	scanner.RegisterCheckRule(r)
+}
@@ -90,6 +90,15 @@ resource "aws_cloudfront_distribution" "good_example" {
 
   web_acl_id = "waf_id"
 }
+`, `
+resource "aws_wafv2_web_acl" "example" {
+  name  = "example"
+  scope = "CLOUDFRONT"
+}
+
+resource "aws_cloudfront_distribution" "good_example" {
+  web_acl_id = aws_wafv2_web_acl.example.arn
+}
 `},
 			Links: []string{
 				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/cloudfront_distribution#web_acl_id",
@@ -102,10 +111,18 @@ resource "aws_cloudfront_distribution" "good_example" {
 		DefaultSeverity: severity.High,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, context block.Module) {
 
-			wafAclIdBlock := resourceBlock.GetAttribute("web_acl_id")
-			if wafAclIdBlock.IsNil() {
+			// the web acl is usually a reference to an aws_wafv2_web_acl, so only its presence is checked
+			wafAclIdAttr := resourceBlock.GetAttribute("web_acl_id")
+			if wafAclIdAttr.IsNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not have a WAF in front of it.", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
+				return
+			}
+			if wafAclIdAttr.IsEmpty() {
 				set.AddResult().
-					WithDescription("Resource '%s' does not have a WAF in front of it.", resourceBlock.FullName())
+					WithDescription("Resource '%s' does not have a WAF in front of it.", resourceBlock.FullName()).
+					WithAttribute(wafAclIdAttr)
 			}
 		},
 	})
@@ -83,6 +83,38 @@ resource "aws_cloudfront_distribution" "s3_distribution" {
   }
 
   web_acl_id = "waf_id"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check an empty waf web_acl_id for aws_cloudfront_distribution",
+			source: `
+resource "aws_cloudfront_distribution" "s3_distribution" {
+  web_acl_id = ""
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check a waf web_acl_id referencing a web acl for aws_cloudfront_distribution",
+			source: `
+resource "aws_wafv2_web_acl" "example" {
+  name  = "example"
+  scope = "CLOUDFRONT"
+}
+
+resource "aws_cloudfront_distribution" "s3_distribution" {
+  web_acl_id = aws_wafv2_web_acl.example.arn
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check a waf web_acl_id from an unknown variable for aws_cloudfront_distribution",
+			source: `
+variable "web_acl_id" {
+}
+
+resource "aws_cloudfront_distribution" "s3_distribution" {
+  web_acl_id = var.web_acl_id
 }`,
 			mustExcludeResultCode: expectedCode,
 		},
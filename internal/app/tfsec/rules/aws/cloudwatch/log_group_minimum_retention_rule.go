@@ -0,0 +1,48 @@
+package cloudwatch
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	retention.RegisterCheck(retention.Check{
+		Rule: rule.Rule{
+			Provider:  provider.AWSProvider,
+			Service:   "cloudwatch",
+			ShortCode: "log-group-minimum-retention",
+			Documentation: rule.RuleDocumentation{
+				Summary: "CloudWatch log groups should retain logs for at least 30 days",
+				Explanation: `Logs are needed to investigate an incident, which often happens some time after it started. Log groups which expire their events after a few days may no longer hold the events which show what happened.
+
+Log groups which don't set retention_in_days, or set it to 0, keep their events forever and pass this check. The minimum can be changed with minimum_retention_days in the config file.`,
+				Impact:     "Logs may have expired by the time they are needed to investigate an incident",
+				Resolution: "Set retention_in_days to at least 30",
+				BadExample: []string{`
+resource "aws_cloudwatch_log_group" "bad_example" {
+  name              = "bad_example"
+  retention_in_days = 7
+}
+`},
+				GoodExample: []string{`
+resource "aws_cloudwatch_log_group" "good_example" {
+  name              = "good_example"
+  retention_in_days = 90
+}
+`},
+				Links: []string{
+					"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/cloudwatch_log_group#retention_in_days",
+					"https://docs.aws.amazon.com/AmazonCloudWatch/latest/logs/Working-with-log-groups-and-streams.html#SettingLogRetention",
+				},
+			},
+			RequiredTypes:   []string{"resource"},
+			RequiredLabels:  []string{"aws_cloudwatch_log_group"},
+			DefaultSeverity: severity.Low,
+		},
+		Attribute:        "retention_in_days",
+		MinimumDays:      30,
+		ZeroMeansForever: true,
+	})
+}
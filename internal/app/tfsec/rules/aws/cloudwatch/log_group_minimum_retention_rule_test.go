@@ -0,0 +1,110 @@
+package cloudwatch
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSCloudWatchLogGroupMinimumRetention(t *testing.T) {
+	expectedCode := "aws-cloudwatch-log-group-minimum-retention"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "retention below 30 days fails check",
+			source: `
+resource "aws_cloudwatch_log_group" "bad_example" {
+	name              = "bad_example"
+	retention_in_days = 7
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "retention of 30 days passes check",
+			source: `
+resource "aws_cloudwatch_log_group" "good_example" {
+	name              = "good_example"
+	retention_in_days = 30
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "retention from a variable below 30 days fails check",
+			source: `
+variable "retention" {
+	default = 14
+}
+
+resource "aws_cloudwatch_log_group" "bad_example" {
+	name              = "bad_example"
+	retention_in_days = var.retention
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "retention of 0 never expires and passes check",
+			source: `
+resource "aws_cloudwatch_log_group" "good_example" {
+	name              = "good_example"
+	retention_in_days = 0
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "unset retention never expires and passes check",
+			source: `
+resource "aws_cloudwatch_log_group" "good_example" {
+	name = "good_example"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "unresolvable retention passes check",
+			source: `
+variable "retention" {
+}
+
+resource "aws_cloudwatch_log_group" "good_example" {
+	name              = "good_example"
+	retention_in_days = var.retention
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSCloudWatchLogGroupMinimumRetentionCanBeOverridden(t *testing.T) {
+	expectedCode := "aws-cloudwatch-log-group-minimum-retention"
+
+	source := `
+resource "aws_cloudwatch_log_group" "example" {
+	name              = "example"
+	retention_in_days = 90
+}
+`
+
+	retention.SetMinimums(map[string]int{expectedCode: 365})
+	defer retention.SetMinimums(nil)
+
+	testutil.AssertCheckCode(t, expectedCode, "", testutil.ScanHCL(source, t))
+}
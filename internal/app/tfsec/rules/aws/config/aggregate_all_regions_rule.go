@@ -59,7 +59,7 @@ resource "aws_config_configuration_aggregator" "good_example" {
 		DefaultSeverity: severity.High,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 
-			aggBlock := resourceBlock.GetFirstMatchingBlock("account_aggregation_source", "organization_aggregation_source")
+			aggBlock := resourceBlock.GetFirstBlockOfTypes("account_aggregation_source", "organization_aggregation_source")
 			if aggBlock.IsNil() {
 				set.AddResult().
 					WithDescription("Resource '%s' should have account aggregation sources set", resourceBlock.FullName())
@@ -0,0 +1,76 @@
+package dynamodb
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "dynamodb",
+		ShortCode: "enable-deletion-protection",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "DynamoDB tables should have deletion protection enabled",
+			Explanation: `Deletion protection stops a table from being deleted, whether by a mistaken change to the Terraform configuration, a terraform destroy or a request to the AWS API. It has to be turned off before the table can be deleted.
+
+The check applies if aws_dynamodb_table is listed in protected_resource_types in the config file, as it is by default.`,
+			Impact:     "The table and its data could be deleted by accident",
+			Resolution: "Set deletion_protection_enabled to true",
+			BadExample: []string{`
+resource "aws_dynamodb_table" "bad_example" {
+  name     = "example"
+  hash_key = "TestTableHashKey"
+
+  attribute {
+    name = "TestTableHashKey"
+    type = "S"
+  }
+}
+`},
+			GoodExample: []string{`
+resource "aws_dynamodb_table" "good_example" {
+  name                        = "example"
+  hash_key                    = "TestTableHashKey"
+  deletion_protection_enabled = true
+
+  attribute {
+    name = "TestTableHashKey"
+    type = "S"
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/dynamodb_table#deletion_protection_enabled",
+				"https://docs.aws.amazon.com/amazondynamodb/latest/developerguide/WorkingWithTables.Basics.html#WorkingWithTables.Basics.DeletionProtection",
+			},
+		},
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"aws_dynamodb_table"},
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if !protection.IsProtected(resourceBlock) {
+				return
+			}
+
+			deletionProtectionAttr := resourceBlock.GetAttribute("deletion_protection_enabled")
+			if deletionProtectionAttr.IsNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not have deletion protection enabled.", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
+				return
+			}
+
+			if deletionProtectionAttr.IsFalse() {
+				set.AddResult().
+					WithDescription("Resource '%s' has deletion protection disabled.", resourceBlock.FullName()).
+					WithAttribute(deletionProtectionAttr)
+			}
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package dynamodb
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSDynamoDBEnableDeletionProtection(t *testing.T) {
+	expectedCode := "aws-dynamodb-enable-deletion-protection"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "table without deletion protection fails check",
+			source: `
+resource "aws_dynamodb_table" "bad_example" {
+	name     = "example"
+	hash_key = "TestTableHashKey"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "table with deletion protection disabled fails check",
+			source: `
+resource "aws_dynamodb_table" "bad_example" {
+	name                        = "example"
+	hash_key                    = "TestTableHashKey"
+	deletion_protection_enabled = false
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "table with deletion protection enabled passes check",
+			source: `
+resource "aws_dynamodb_table" "good_example" {
+	name                        = "example"
+	hash_key                    = "TestTableHashKey"
+	deletion_protection_enabled = true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
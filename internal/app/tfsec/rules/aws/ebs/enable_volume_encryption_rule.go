@@ -6,6 +6,9 @@ package ebs
 
 // generator-locked
 import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 	"github.com/aquasecurity/tfsec/pkg/provider"
@@ -67,5 +70,9 @@ resource "aws_ebs_volume" "good_example" {
 					WithAttribute(encryptedAttr)
 			}
 		},
+		FixFunc: func(body *hclwrite.Body) bool {
+			body.SetAttributeValue("encrypted", cty.True)
+			return true
+		},
 	})
 }
@@ -0,0 +1,143 @@
+package ec2
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "ec2",
+		ShortCode: "enable-at-rest-encryption",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Instances should not use unencrypted EBS volumes",
+			Explanation: `Every volume an instance uses should be encrypted, whether it is the root device, a block device defined with the instance or a separate volume attached to it. Data on an unencrypted volume, and any snapshots taken of it, can be read by anyone who gains access to the underlying storage.`,
+			Impact:      "Data stored on the instance's volumes could be read if the storage is compromised",
+			Resolution:  "Enable encryption for every volume used by the instance, or enable EBS encryption by default",
+			BadExample: []string{`
+resource "aws_instance" "bad_example" {
+  ami           = "ami-005e54dee72cc1d00"
+  instance_type = "t2.micro"
+
+  root_block_device {
+    encrypted = false
+  }
+}
+`, `
+resource "aws_instance" "bad_example" {
+  ami           = "ami-005e54dee72cc1d00"
+  instance_type = "t2.micro"
+
+  root_block_device {
+    encrypted = true
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.bad_example.id
+}
+`},
+			GoodExample: []string{`
+resource "aws_instance" "good_example" {
+  ami           = "ami-005e54dee72cc1d00"
+  instance_type = "t2.micro"
+
+  root_block_device {
+    encrypted = true
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdg"
+    encrypted   = true
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+  encrypted         = true
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.good_example.id
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/instance#ebs-ephemeral-and-root-block-devices",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/volume_attachment",
+				"https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/EBSEncryption.html",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"aws_instance",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+
+			encryptionByDefault := false
+			for _, defaultEncryptionBlock := range module.GetResourcesByType("aws_ebs_encryption_by_default") {
+				if defaultEncryptionBlock.GetAttribute("enabled").IsTrue() {
+					encryptionByDefault = true
+				}
+			}
+
+			if rootDeviceBlock := resourceBlock.GetBlock("root_block_device"); rootDeviceBlock.IsNil() {
+				if !encryptionByDefault {
+					set.AddResult().
+						WithDescription("Resource '%s' uses an unencrypted root block device", resourceBlock.FullName())
+				}
+			} else {
+				checkVolumeEncryption(set, resourceBlock, rootDeviceBlock, encryptionByDefault, "root block device")
+			}
+
+			for _, deviceBlock := range resourceBlock.GetBlocks("ebs_block_device") {
+				checkVolumeEncryption(set, resourceBlock, deviceBlock, encryptionByDefault, "EBS block device")
+			}
+
+			attachments, err := module.GetReferencingResources(resourceBlock, "aws_volume_attachment", "instance_id")
+			if err != nil {
+				return
+			}
+			for _, attachment := range attachments {
+				volumeBlock, err := module.GetReferencedBlock(attachment.GetAttribute("volume_id"))
+				if err != nil || volumeBlock.TypeLabel() != "aws_ebs_volume" {
+					continue
+				}
+				checkVolumeEncryption(set, resourceBlock, volumeBlock, encryptionByDefault, "attached volume '"+volumeBlock.FullName()+"'")
+			}
+		},
+	})
+}
+
+// checkVolumeEncryption raises a result against the definition of a volume which isn't encrypted
+func checkVolumeEncryption(set result.Set, instanceBlock block.Block, volumeBlock block.Block, encryptionByDefault bool, volume string) {
+	encryptedAttr := volumeBlock.GetAttribute("encrypted")
+	switch {
+	case encryptedAttr.IsNil() && !encryptionByDefault:
+		set.AddResult().
+			WithDescription("Resource '%s' uses an unencrypted %s", instanceBlock.FullName(), volume).
+			WithBlock(volumeBlock).
+			WithRangeAnnotation("encrypted is not set")
+	case encryptedAttr.IsFalse():
+		set.AddResult().
+			WithDescription("Resource '%s' uses an unencrypted %s", instanceBlock.FullName(), volume).
+			WithAttribute(encryptedAttr)
+	}
+}
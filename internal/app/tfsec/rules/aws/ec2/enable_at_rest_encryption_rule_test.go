@@ -0,0 +1,174 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AWSInstanceAtRestEncryption(t *testing.T) {
+	expectedCode := "aws-ec2-enable-at-rest-encryption"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "should fire when the root block device is not defined",
+			source: `
+resource "aws_instance" "example" {
+  ami           = "ami-005e54dee72cc1d00"
+  instance_type = "t2.micro"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "should fire when the root block device is not encrypted",
+			source: `
+resource "aws_instance" "example" {
+  root_block_device {
+    encrypted = false
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "should fire when an ebs block device does not set encryption",
+			source: `
+resource "aws_instance" "example" {
+  root_block_device {
+    encrypted = true
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdg"
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "should fire when an attached volume is not encrypted",
+			source: `
+resource "aws_instance" "example" {
+  root_block_device {
+    encrypted = true
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+  encrypted         = false
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.example.id
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "should not fire when all volumes are encrypted",
+			source: `
+resource "aws_instance" "example" {
+  root_block_device {
+    encrypted = true
+  }
+
+  ebs_block_device {
+    device_name = "/dev/sdg"
+    encrypted   = true
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+  encrypted         = true
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.example.id
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "should not fire when encryption is enabled by default",
+			source: `
+resource "aws_ebs_encryption_by_default" "default" {
+  enabled = true
+}
+
+resource "aws_instance" "example" {
+  ebs_block_device {
+    device_name = "/dev/sdg"
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.example.id
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSInstanceAtRestEncryptionReportsVolumeDefinition(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_instance" "example" {
+  root_block_device {
+    encrypted = true
+  }
+}
+
+resource "aws_ebs_volume" "data" {
+  availability_zone = "us-west-2a"
+  size              = 40
+}
+
+resource "aws_volume_attachment" "data" {
+  device_name = "/dev/sdh"
+  volume_id   = aws_ebs_volume.data.id
+  instance_id = aws_instance.example.id
+}
+`, t)
+
+	var found bool
+	for _, res := range results {
+		if res.RuleID != "aws-ec2-enable-at-rest-encryption" {
+			continue
+		}
+		found = true
+		assert.Equal(t, 8, res.Range().StartLine)
+		assert.Equal(t, 8, res.Location.StartLine)
+		assert.Equal(t, "encrypted is not set", res.RangeAnnotation)
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,138 @@
+package ec2
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+
+	"github.com/aquasecurity/tfsec/pkg/provider"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+
+	"github.com/aquasecurity/tfsec/pkg/rule"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Service:   "ec2",
+		ShortCode: "no-public-ingress-sensitive-ports",
+		Documentation: rule.RuleDocumentation{
+			Summary:    "An instance is reachable from the internet on a sensitive port through its security groups.",
+			Impact:     "Administration and database ports on the instance can be attacked from the internet",
+			Resolution: "Restrict the ingress CIDR ranges of the instance's security groups for sensitive ports",
+			Explanation: `
+Security groups which allow ingress from anywhere are sometimes intended for a service such as a load balancer, but once attached to an instance they also expose any sensitive ports they allow, such as SSH or RDP.
+
+This check follows the security groups attached to each instance, including security group rules defined as separate resources, and reports ingress from /0 to ports used for remote administration or by data stores. The ports which are checked can be changed with sensitive_ports in the config file.
+`,
+			BadExample: []string{`
+resource "aws_security_group" "bad_example" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "bad_example" {
+	ami                    = "ami-12345678"
+	instance_type          = "t3.micro"
+	vpc_security_group_ids = [aws_security_group.bad_example.id]
+}
+`},
+			GoodExample: []string{`
+resource "aws_security_group" "good_example" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["10.0.0.0/16"]
+	}
+}
+
+resource "aws_instance" "good_example" {
+	ami                    = "ami-12345678"
+	instance_type          = "t3.micro"
+	vpc_security_group_ids = [aws_security_group.good_example.id]
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/instance#vpc_security_group_ids",
+				"https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/security-group-rules-reference.html",
+			},
+		},
+		Provider:        provider.AWSProvider,
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"aws_instance"},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			for _, attrName := range []string{"vpc_security_group_ids", "security_groups"} {
+				sgAttr := resourceBlock.GetAttribute(attrName)
+				if sgAttr.IsNil() {
+					continue
+				}
+				securityGroups := module.GetReferencedBlocks(sgAttr)
+				if len(securityGroups) == 0 {
+					// most likely the groups come from a module or data source which can't be followed
+					debug.Log("No security groups could be resolved for '%s' in %s", resourceBlock.FullName(), attrName)
+					continue
+				}
+				for _, securityGroup := range securityGroups {
+					if !securityGroup.IsResourceType("aws_security_group") {
+						continue
+					}
+					for _, ingress := range securityGroup.GetBlocks("ingress") {
+						checkPublicSensitiveIngress(set, resourceBlock, securityGroup, ingress)
+					}
+					sgRules, err := module.GetReferencingResources(securityGroup, "aws_security_group_rule", "security_group_id")
+					if err != nil {
+						debug.Log(err.Error())
+						continue
+					}
+					for _, sgRule := range sgRules {
+						if sgRule.GetAttribute("type").Equals("ingress") {
+							checkPublicSensitiveIngress(set, resourceBlock, securityGroup, sgRule)
+						}
+					}
+				}
+			}
+		},
+	})
+}
+
+func checkPublicSensitiveIngress(set result.Set, instance block.Block, securityGroup block.Block, ingress block.Block) {
+	portRange, portAttrs, ok := ports.SecurityGroupIngress(ingress)
+	if !ok {
+		return
+	}
+	exposed := ports.SensitivePortsIn(portRange)
+	if len(exposed) == 0 {
+		return
+	}
+	for _, cidrAttrName := range []string{"cidr_blocks", "ipv6_cidr_blocks"} {
+		cidrAttr := ingress.GetAttribute(cidrAttrName)
+		if cidrAttr.IsNil() || !cidr.IsAttributeOpen(cidrAttr) {
+			continue
+		}
+		var portNames []string
+		for _, port := range exposed {
+			portNames = append(portNames, strconv.Itoa(port))
+		}
+		// the result is raised against the instance, so the security group and its ingress are attached after it
+		set.AddResult().
+			WithDescription("Resource '%s' (%s) is exposed to the internet on sensitive port(s) %s by '%s'", instance.FullName(), instance.Range().String(), strings.Join(portNames, ", "), securityGroup.FullName()).
+			WithBlock(securityGroup).
+			WithBlock(ingress).
+			WithAttribute(cidrAttr).
+			WithRangeAnnotation(ports.ExposureAnnotation(exposed, portAttrs...))
+		return
+	}
+}
@@ -0,0 +1,185 @@
+package ec2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSNoPublicIngressSensitivePorts(t *testing.T) {
+	expectedCode := "aws-ec2-no-public-ingress-sensitive-ports"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "instance with inline ingress open to the internet on ssh",
+			source: `
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with separate security group rule open to the internet on rdp",
+			source: `
+resource "aws_security_group" "sg" {
+}
+
+resource "aws_security_group_rule" "rdp" {
+	type              = "ingress"
+	security_group_id = aws_security_group.sg.id
+	from_port         = 3000
+	to_port           = 4000
+	protocol          = "tcp"
+	cidr_blocks       = ["0.0.0.0/0"]
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with all ports open to ipv6 internet",
+			source: `
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port        = 0
+		to_port          = 0
+		protocol         = "-1"
+		ipv6_cidr_blocks = ["::/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with https open to the internet",
+			source: `
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port   = 443
+		to_port     = 443
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with ssh open to a private range",
+			source: `
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["10.0.0.0/16"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "open security group which isn't attached to an instance",
+			source: `
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "security group from an unresolved module",
+			source: `
+module "sg" {
+	source = "./does-not-exist"
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [module.sg.id]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSNoPublicIngressSensitivePortsAttachesInstanceAndIngress(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group" "sg" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+}
+`, t)
+
+	var found bool
+	for _, res := range results {
+		if res.RuleID != "aws-ec2-no-public-ingress-sensitive-ports" {
+			continue
+		}
+		found = true
+		blocks := res.Blocks()
+		require.Len(t, blocks, 3)
+		assert.Equal(t, "aws_instance.web", blocks[0].FullName())
+		assert.Equal(t, "aws_security_group.sg", blocks[1].FullName())
+		assert.Equal(t, "ingress", blocks[2].Type())
+		assert.Equal(t, "aws_instance.web", res.Resource)
+		assert.Equal(t, 7, res.Range().StartLine)
+	}
+	assert.True(t, found)
+}
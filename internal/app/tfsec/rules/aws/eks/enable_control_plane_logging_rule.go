@@ -14,6 +14,11 @@ import (
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
+// requiredControlPlaneLogTypes record who did what to the cluster, so are needed to investigate an incident
+var requiredControlPlaneLogTypes = []string{"api", "audit", "authenticator"}
+
+var recommendedControlPlaneLogTypes = []string{"controllerManager", "scheduler"}
+
 func init() {
 	scanner.RegisterCheckRule(rule.Rule{
 		LegacyID:  "AWS067",
@@ -25,6 +30,8 @@ func init() {
 			Resolution: "Enable logging for the EKS control plane",
 			Explanation: `
 By default cluster control plane logging is not turned on. Logging is available for audit, api, authenticator, controllerManager and scheduler. All logging should be turned on for cluster control plane.
+
+At least the api, audit and authenticator logs should be enabled, as without them there is no record of the requests made to the cluster or of who made them. Missing controllerManager and scheduler logs are reported with a low severity.
 `,
 			BadExample: []string{`
 resource "aws_eks_cluster" "bad_example" {
@@ -41,6 +48,16 @@ resource "aws_eks_cluster" "bad_example" {
         endpoint_public_access = false
     }
 }
+`, `
+resource "aws_eks_cluster" "bad_example" {
+    enabled_cluster_log_types = ["api", "authenticator"]
+
+    name = "bad_example_cluster"
+    role_arn = var.cluster_arn
+    vpc_config {
+        endpoint_public_access = false
+    }
+}
 `},
 			GoodExample: []string{`
 resource "aws_eks_cluster" "good_example" {
@@ -51,7 +68,7 @@ resource "aws_eks_cluster" "good_example" {
         }
     }
 
-	enabled_cluster_log_types = ["api", "authenticator", "audit", "scheduler", "controllerManager"]
+    enabled_cluster_log_types = ["api", "authenticator", "audit", "scheduler", "controllerManager"]
 
     name = "good_example_cluster"
     role_arn = var.cluster_arn
@@ -71,22 +88,32 @@ resource "aws_eks_cluster" "good_example" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 
-			controlPlaneLogging := []string{"api", "audit", "authenticator", "controllerManager", "scheduler"}
-
 			if resourceBlock.MissingChild("enabled_cluster_log_types") {
 				set.AddResult().
-					WithDescription("Resource '%s' missing the enabled_cluster_log_types attribute to enable control plane logging", resourceBlock.FullName())
+					WithDescription("Resource '%s' missing the enabled_cluster_log_types attribute to enable control plane logging", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
 				return
 			}
 
 			configuredLoggingAttr := resourceBlock.GetAttribute("enabled_cluster_log_types")
-			for _, logType := range controlPlaneLogging {
+			if configuredLoggingAttr.IsNotResolvable() {
+				return
+			}
+			for _, logType := range requiredControlPlaneLogTypes {
 				if !configuredLoggingAttr.Contains(logType) {
 					set.AddResult().
 						WithDescription("Resource '%s' is missing the control plane log type '%s'", resourceBlock.FullName(), logType).
 						WithAttribute(configuredLoggingAttr)
 				}
 			}
+			for _, logType := range recommendedControlPlaneLogTypes {
+				if !configuredLoggingAttr.Contains(logType) {
+					set.AddResult().
+						WithDescription("Resource '%s' is missing the recommended control plane log type '%s'", resourceBlock.FullName(), logType).
+						WithAttribute(configuredLoggingAttr).
+						WithSeverity(severity.Low)
+				}
+			}
 		},
 	})
 }
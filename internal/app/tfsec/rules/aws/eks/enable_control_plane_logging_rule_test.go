@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_AWSEKSHasControlPlaneLoggingEnabled(t *testing.T) {
@@ -91,3 +93,52 @@ resource "aws_eks_cluster" "good_example" {
 	}
 
 }
+
+func Test_AWSEKSControlPlaneLoggingSeverity(t *testing.T) {
+	expectedCode := "aws-eks-enable-control-plane-logging"
+
+	var tests = []struct {
+		name       string
+		source     string
+		severities []severity.Severity
+	}{
+		{
+			name: "missing audit logging is medium severity",
+			source: `
+resource "aws_eks_cluster" "my-cluster" {
+	enabled_cluster_log_types = ["api", "authenticator", "scheduler", "controllerManager"]
+}`,
+			severities: []severity.Severity{severity.Medium},
+		},
+		{
+			name: "missing scheduler and controller manager logging is low severity",
+			source: `
+resource "aws_eks_cluster" "my-cluster" {
+	enabled_cluster_log_types = ["api", "audit", "authenticator"]
+}`,
+			severities: []severity.Severity{severity.Low, severity.Low},
+		},
+		{
+			name: "log types which can't be resolved are not reported",
+			source: `
+variable "log_types" {
+}
+
+resource "aws_eks_cluster" "my-cluster" {
+	enabled_cluster_log_types = var.log_types
+}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var severities []severity.Severity
+			for _, res := range testutil.ScanHCL(test.source, t) {
+				if res.RuleID == expectedCode {
+					severities = append(severities, res.Severity)
+				}
+			}
+			assert.Equal(t, test.severities, severities)
+		})
+	}
+}
@@ -24,7 +24,7 @@ func init() {
 			Impact:     "EKS secrets could be read if compromised",
 			Resolution: "Enable encryption of EKS secrets",
 			Explanation: `
-EKS cluster resources should have the encryption_config block set with protection of the secrets resource.
+EKS cluster resources should have the encryption_config block set with protection of the secrets resource, using the KMS key given by key_arn in its provider block. Without it, Kubernetes secrets are only protected by the default encryption of the etcd volumes, and can be read by anyone with access to etcd.
 `,
 			BadExample: []string{`
 resource "aws_eks_cluster" "bad_example" {
@@ -35,6 +35,21 @@ resource "aws_eks_cluster" "bad_example" {
         endpoint_public_access = false
     }
 }
+`, `
+resource "aws_eks_cluster" "bad_example" {
+    encryption_config {
+        resources = [ "secrets" ]
+        provider {
+            key_arn = ""
+        }
+    }
+
+    name = "bad_example_cluster"
+    role_arn = var.cluster_arn
+    vpc_config {
+        endpoint_public_access = false
+    }
+}
 `},
 			GoodExample: []string{`
 resource "aws_eks_cluster" "good_example" {
@@ -65,14 +80,15 @@ resource "aws_eks_cluster" "good_example" {
 
 			if resourceBlock.MissingChild("encryption_config") {
 				set.AddResult().
-					WithDescription("Resource '%s' has no encryptionConfigBlock block", resourceBlock.FullName())
+					WithDescription("Resource '%s' has no encryption_config block", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
 				return
 			}
 
 			encryptionConfigBlock := resourceBlock.GetBlock("encryption_config")
 			if encryptionConfigBlock.MissingChild("resources") {
 				set.AddResult().
-					WithDescription("Resource '%s' has encryptionConfigBlock block with no resourcesAttr attribute specified", resourceBlock.FullName()).
+					WithDescription("Resource '%s' has an encryption_config block with no resources specified", resourceBlock.FullName()).
 					WithBlock(encryptionConfigBlock)
 				return
 			}
@@ -81,26 +97,29 @@ resource "aws_eks_cluster" "good_example" {
 			if !resourcesAttr.Contains("secrets") {
 				set.AddResult().
 					WithDescription("Resource '%s' does not include secrets in encrypted resources", resourceBlock.FullName()).
-					WithAttribute(resourcesAttr)
+					WithAttribute(resourcesAttr).
+					WithRangeAnnotation("secrets is not in the encrypted resources")
 			}
 
 			if encryptionConfigBlock.MissingChild("provider") {
 				set.AddResult().
-					WithDescription("Resource '%s' has encryptionConfigBlock block with no provider block specified", resourceBlock.FullName())
+					WithDescription("Resource '%s' has an encryption_config block with no provider block specified", resourceBlock.FullName()).
+					WithBlock(encryptionConfigBlock)
 				return
 			}
 
 			providerBlock := encryptionConfigBlock.GetBlock("provider")
 			if providerBlock.MissingChild("key_arn") {
 				set.AddResult().
-					WithDescription("Resource '%s' has encryptionConfigBlock block with provider block specified missing key arn", resourceBlock.FullName())
+					WithDescription("Resource '%s' has an encryption_config provider block with no key_arn specified", resourceBlock.FullName()).
+					WithBlock(providerBlock)
 				return
 			}
 
 			keyArnAttr := providerBlock.GetAttribute("key_arn")
 			if keyArnAttr.IsEmpty() {
 				set.AddResult().
-					WithDescription("Resource '%s' has encryptionConfigBlock block with provider block specified but key_arn is empty", resourceBlock.FullName()).
+					WithDescription("Resource '%s' has an encryption_config provider block with an empty key_arn", resourceBlock.FullName()).
 					WithAttribute(keyArnAttr)
 			}
 
@@ -103,6 +103,23 @@ resource "aws_eks_cluster" "bad_example" {
         endpoint_public_access = false
     }
 }
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "Test eks cluster with an empty key_arn causes check to fail",
+			source: `
+resource "aws_eks_cluster" "bad_example" {
+    encryption_config {
+        resources = [ "secrets" ]
+        provider {
+            key_arn = ""
+        }
+    }
+
+    name = "bad_example_cluster"
+    role_arn = var.cluster_arn
+}
 `,
 			mustIncludeResultCode: expectedCode,
 		},
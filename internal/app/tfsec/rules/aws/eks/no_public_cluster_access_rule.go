@@ -2,6 +2,9 @@ package eks
 
 // generator-locked
 import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/aquasecurity/tfsec/pkg/result"
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
@@ -81,5 +84,14 @@ resource "aws_eks_cluster" "good_example" {
 					WithAttribute(publicAccessEnabledAttr)
 			}
 		},
+		FixFunc: func(body *hclwrite.Body) bool {
+			// a vpc_config block can't be added without knowing the subnets the cluster should use
+			vpcConfig := body.FirstMatchingBlock("vpc_config", nil)
+			if vpcConfig == nil {
+				return false
+			}
+			vpcConfig.Body().SetAttributeValue("endpoint_public_access", cty.False)
+			return true
+		},
 	})
 }
@@ -76,12 +76,14 @@ resource "aws_eks_cluster" "good_example" {
 			if publicAccessCidrsAttr.IsNil() {
 				set.AddResult().
 					WithDescription("Resource '%s' uses the default public access cidr of 0.0.0.0/0", resourceBlock.FullName()).
-					WithBlock(vpcConfig)
+					WithBlock(vpcConfig).
+					WithSuggestedFix(`public_access_cidrs = ["<your-cidr>"]`)
 
 			} else if cidr.IsAttributeOpen(publicAccessCidrsAttr) {
 				set.AddResult().
 					WithDescription("Resource '%s' has public access cidr explicitly set to wide open", resourceBlock.FullName()).
-					WithAttribute(publicAccessCidrsAttr)
+					WithAttribute(publicAccessCidrsAttr).
+					WithSuggestedFix(`public_access_cidrs = ["<your-cidr>"]`)
 			}
 		},
 	})
@@ -4,6 +4,8 @@ package eks
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 )
 
@@ -98,3 +100,24 @@ resource "aws_eks_cluster" "good_example" {
 	}
 
 }
+
+func Test_AWSEKSClusterNotOpenPubliclySuggestsFix(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_eks_cluster" "bad_example" {
+    name = "bad_example_cluster"
+    role_arn = var.cluster_arn
+    vpc_config {
+        endpoint_public_access = true
+    }
+}
+`, t)
+
+	var found bool
+	for _, res := range results {
+		if res.RuleID == "aws-eks-no-public-cluster-access-to-cidr" {
+			found = true
+			assert.Equal(t, `public_access_cidrs = ["<your-cidr>"]`, res.SuggestedFix)
+		}
+	}
+	assert.True(t, found)
+}
@@ -58,8 +58,7 @@ resource "aws_alb_listener" "good_example" {
 			protocolAttr := resourceBlock.GetAttribute("protocol")
 
 			if protocolAttr.IsNotNil() {
-				if protocolAttr.IsResolvable() && (protocolAttr.Equals("HTTPS", block.IgnoreCase) ||
-					protocolAttr.Equals("TLS", block.IgnoreCase)) {
+				if protocolAttr.IsOneOfIgnoreCase("HTTPS", "TLS") {
 					return
 				}
 				if protocolAttr.IsResolvable() && protocolAttr.Equals("HTTP") {
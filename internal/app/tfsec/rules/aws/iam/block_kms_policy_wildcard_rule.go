@@ -25,6 +25,7 @@ type PolicyDocument struct {
 }
 
 type awsIAMPolicyDocumentStatement struct {
+	Sid       string                    `json:"Sid,omitempty"`
 	Effect    string                    `json:"Effect"`
 	Action    awsIAMPolicyDocumentValue `json:"Action"`
 	Resource  awsIAMPolicyDocumentValue `json:"Resource,omitempty"`
@@ -58,11 +59,13 @@ func (value *awsIAMPolicyPrincipal) UnmarshalJSON(b []byte) error {
 				} else {
 					value.AWS = append(value.AWS, raw)
 				}
-			case []string:
-				if key == "Service" {
-					value.Service = append(value.Service, raw...)
-				} else {
-					value.AWS = append(value.AWS, raw...)
+			case []interface{}:
+				for _, item := range raw {
+					if key == "Service" {
+						value.Service = append(value.Service, fmt.Sprintf("%v", item))
+					} else {
+						value.AWS = append(value.AWS, fmt.Sprintf("%v", item))
+					}
 				}
 			}
 		}
@@ -0,0 +1,164 @@
+package iam
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "iam",
+		ShortCode: "no-full-admin-policies",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "IAM policies should not grant all actions on all resources",
+			Explanation: `A statement which allows the action "*" on the resource "*" grants full administrative access to the account. Anyone holding the policy can change any resource, including granting themselves further access.`,
+			Impact:      "Holders of the policy have full administrative access to the account",
+			Resolution:  "Grant only the actions and resources which are required",
+			BadExample: []string{`
+resource "aws_iam_policy" "bad_example" {
+  name = "admin"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "*",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+`, `
+data "aws_iam_policy_document" "admin" {
+  statement {
+    actions   = ["*"]
+    resources = ["*"]
+  }
+}
+
+resource "aws_iam_policy" "bad_example" {
+  name   = "admin"
+  policy = data.aws_iam_policy_document.admin.json
+}
+`},
+			GoodExample: []string{`
+resource "aws_iam_policy" "good_example" {
+  name = "read-bucket"
+
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::my-bucket/*"
+    }
+  ]
+}
+EOF
+}
+`, `
+data "aws_iam_policy_document" "read_bucket" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::my-bucket/*"]
+  }
+}
+
+resource "aws_iam_policy" "good_example" {
+  name   = "read-bucket"
+  policy = data.aws_iam_policy_document.read_bucket.json
+}
+`},
+			Links: []string{
+				"https://docs.aws.amazon.com/IAM/latest/UserGuide/best-practices.html#grant-least-privilege",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/iam_policy#policy",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"aws_iam_policy",
+			"aws_iam_user_policy",
+			"aws_iam_group_policy",
+			"aws_iam_role_policy",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			policyAttr := resourceBlock.GetAttribute("policy")
+			if policyAttr.IsNil() {
+				return
+			}
+
+			policyDocumentBlock, err := module.GetReferencedBlock(policyAttr)
+			if err != nil || policyDocumentBlock.Type() != "data" || policyDocumentBlock.TypeLabel() != "aws_iam_policy_document" {
+				if policyAttr.IsString() {
+					checkFullAdminPolicyJSON(set, resourceBlock, policyAttr)
+				}
+				return
+			}
+
+			for _, statementBlock := range policyDocumentBlock.GetBlocks("statement") {
+				// statements allow by default
+				if statementBlock.GetAttribute("effect").Equals("deny", block.IgnoreCase) {
+					continue
+				}
+				if statementBlock.GetAttribute("actions").Contains("*") && statementBlock.GetAttribute("resources").Contains("*") {
+					set.AddResult().
+						WithDescription("Resource '%s' grants all actions on all resources through '%s'", resourceBlock.FullName(), policyDocumentBlock.FullName()).
+						WithBlock(policyDocumentBlock).
+						WithBlock(statementBlock)
+				}
+			}
+		},
+	})
+}
+
+func checkFullAdminPolicyJSON(set result.Set, resourceBlock block.Block, policyAttr block.Attribute) {
+	var document PolicyDocument
+	if err := json.Unmarshal([]byte(policyAttr.Value().AsString()), &document); err != nil {
+		debug.Log("Error decoding IAM policy JSON at %s: %s", policyAttr.Range(), err)
+		return
+	}
+
+	for i, statement := range document.Statements {
+		if !strings.EqualFold(statement.Effect, "allow") {
+			continue
+		}
+		if !containsWildcard(statement.Action) || !containsWildcard(statement.Resource) {
+			continue
+		}
+		annotation := fmt.Sprintf("statement %d allows all actions on all resources", i+1)
+		if statement.Sid != "" {
+			annotation = fmt.Sprintf("statement '%s' allows all actions on all resources", statement.Sid)
+		}
+		set.AddResult().
+			WithDescription("Resource '%s' grants all actions on all resources", resourceBlock.FullName()).
+			WithAttribute(policyAttr).
+			WithRangeAnnotation(annotation)
+	}
+}
+
+func containsWildcard(values []string) bool {
+	for _, value := range values {
+		if value == "*" {
+			return true
+		}
+	}
+	return false
+}
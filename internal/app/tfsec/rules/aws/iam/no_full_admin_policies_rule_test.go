@@ -0,0 +1,140 @@
+package iam
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSNoFullAdminPolicies(t *testing.T) {
+	expectedCode := "aws-iam-no-full-admin-policies"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "json policy allowing all actions on all resources fails check",
+			source: `
+resource "aws_iam_policy" "example" {
+  policy = <<EOF
+{
+  "Statement": [
+    {
+      "Sid": "ReadBucket",
+      "Effect": "Allow",
+      "Action": "s3:GetObject",
+      "Resource": "arn:aws:s3:::my-bucket/*"
+    },
+    {
+      "Sid": "Admin",
+      "Effect": "Allow",
+      "Action": ["*"],
+      "Resource": ["*"]
+    }
+  ]
+}
+EOF
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "jsonencoded policy allowing all actions on all resources fails check",
+			source: `
+resource "aws_iam_role_policy" "example" {
+  policy = jsonencode({
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = "*"
+        Resource = "*"
+      },
+    ]
+  })
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "policy document allowing all actions on all resources fails check",
+			source: `
+data "aws_iam_policy_document" "example" {
+  statement {
+    actions   = ["*"]
+    resources = ["*"]
+  }
+}
+
+resource "aws_iam_policy" "example" {
+  policy = data.aws_iam_policy_document.example.json
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "json policy denying all actions on all resources passes check",
+			source: `
+resource "aws_iam_policy" "example" {
+  policy = <<EOF
+{
+  "Statement": [
+    {
+      "Effect": "Deny",
+      "Action": "*",
+      "Resource": "*"
+    }
+  ]
+}
+EOF
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "json policy allowing all actions on a single resource passes check",
+			source: `
+resource "aws_iam_policy" "example" {
+  policy = <<EOF
+{
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Action": "*",
+      "Resource": "arn:aws:s3:::my-bucket"
+    }
+  ]
+}
+EOF
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "policy document denying all actions on all resources passes check",
+			source: `
+data "aws_iam_policy_document" "example" {
+  statement {
+    effect    = "Deny"
+    actions   = ["*"]
+    resources = ["*"]
+  }
+}
+
+resource "aws_iam_policy" "example" {
+  policy = data.aws_iam_policy_document.example.json
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
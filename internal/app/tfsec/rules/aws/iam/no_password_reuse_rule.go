@@ -11,8 +11,6 @@ import (
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
-	"github.com/zclconf/go-cty/cty"
-
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
@@ -57,12 +55,10 @@ resource "aws_iam_account_password_policy" "good_example" {
 			if attr := resourceBlock.GetAttribute("password_reuse_prevention"); attr.IsNil() {
 				set.AddResult().
 					WithDescription("Resource '%s' does not have a password reuse prevention count set.", resourceBlock.FullName())
-			} else if attr.Value().Type() == cty.Number {
-				value, _ := attr.Value().AsBigFloat().Float64()
-				if value < 5 {
-					set.AddResult().
-						WithDescription("Resource '%s' has a password reuse count less than 5.", resourceBlock.FullName())
-				}
+			} else if attr.LessThan(5) {
+				set.AddResult().
+					WithDescription("Resource '%s' has a password reuse count less than 5.", resourceBlock.FullName()).
+					WithAttribute(attr)
 			}
 		},
 	})
@@ -113,22 +113,17 @@ data "aws_iam_policy_document" "s3_policy" {
 				return
 			}
 
-			if policyAttr.IsString() {
-				checkAWS099PolicyJSON(set, resourceBlock, policyAttr)
-				return
-			}
-
-			policyDocumentBlock, err := module.GetReferencedBlock(policyAttr)
-			if err != nil {
+			// check referenced documents directly so results point at the offending statement
+			if policyDocumentBlock, err := module.GetReferencedBlock(policyAttr); err == nil &&
+				policyDocumentBlock.Type() == "data" && policyDocumentBlock.TypeLabel() == "aws_iam_policy_document" {
+				checkAWS099PolicyDocumentBlock(set, policyDocumentBlock)
 				return
 			}
 
-			if policyDocumentBlock.Type() != "data" || policyDocumentBlock.TypeLabel() != "aws_iam_policy_document" {
-				return
+			if policyAttr.IsString() {
+				checkAWS099PolicyJSON(set, resourceBlock, policyAttr)
 			}
 
-			checkAWS099PolicyDocumentBlock(set, policyDocumentBlock)
-
 		},
 	})
 }
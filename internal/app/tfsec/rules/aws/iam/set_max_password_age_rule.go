@@ -11,8 +11,6 @@ import (
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
-	"github.com/zclconf/go-cty/cty"
-
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
@@ -53,13 +51,10 @@ resource "aws_iam_account_password_policy" "good_example" {
 			if attr := resourceBlock.GetAttribute("max_password_age"); attr.IsNil() {
 				set.AddResult().
 					WithDescription("Resource '%s' does not have a max password age set.", resourceBlock.FullName())
-			} else if attr.Value().Type() == cty.Number {
-				value, _ := attr.Value().AsBigFloat().Float64()
-				if value > 90 {
-					set.AddResult().
-						WithDescription("Resource '%s' has high password age.", resourceBlock.FullName()).
-						WithAttribute(attr)
-				}
+			} else if attr.GreaterThan(90) {
+				set.AddResult().
+					WithDescription("Resource '%s' has high password age.", resourceBlock.FullName()).
+					WithAttribute(attr)
 			}
 		},
 	})
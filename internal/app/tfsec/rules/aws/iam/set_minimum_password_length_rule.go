@@ -11,8 +11,6 @@ import (
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
-	"github.com/zclconf/go-cty/cty"
-
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
@@ -55,12 +53,10 @@ resource "aws_iam_account_password_policy" "good_example" {
 			if attr := resourceBlock.GetAttribute("minimum_password_length"); attr.IsNil() {
 				set.AddResult().
 					WithDescription("Resource '%s' does not have a minimum password length set.", resourceBlock.FullName())
-			} else if attr.Value().Type() == cty.Number {
-				value, _ := attr.Value().AsBigFloat().Float64()
-				if value < 14 {
-					set.AddResult().
-						WithDescription("Resource '%s' has a minimum password length which is less than 14 characters.", resourceBlock.FullName())
-				}
+			} else if attr.LessThan(14) {
+				set.AddResult().
+					WithDescription("Resource '%s' has a minimum password length which is less than 14 characters.", resourceBlock.FullName()).
+					WithAttribute(attr)
 			}
 		},
 	})
@@ -21,9 +21,11 @@ func init() {
 		ShortCode: "enable-tracing",
 		Documentation: rule.RuleDocumentation{
 			Summary:     "Lambda functions should have X-Ray tracing enabled",
-			Explanation: `X-Ray tracing enables end-to-end debugging and analysis of all function activity. This will allow for identifying bottlenecks, slow downs and timeouts.`,
+			Explanation: `X-Ray tracing enables end-to-end debugging and analysis of all function activity. This will allow for identifying bottlenecks, slow downs and timeouts.
+
+Tracing is enabled by adding a tracing_config block with mode set to Active, which samples and traces incoming requests, or PassThrough, which only traces requests which are already traced upstream.`,
 			Impact:      "Without full tracing enabled, it is difficult to trace the flow of logs",
-			Resolution:  "Enable tracing",
+			Resolution:  "Enable tracing by setting tracing_config.mode to Active or PassThrough",
 			BadExample: []string{`
 resource "aws_iam_role" "iam_for_lambda" {
   name = "iam_for_lambda"
@@ -108,7 +110,7 @@ resource "aws_lambda_function" "good_example" {
     }
   }
   tracing_config {
-    mode = "something"
+    mode = "Active"
   }
 }
 `},
@@ -124,12 +126,27 @@ resource "aws_lambda_function" "good_example" {
 		},
 		DefaultSeverity: severity.Low,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
-			if modeAttr := resourceBlock.GetBlock("tracing_config").GetAttribute("mode"); modeAttr.IsNil() { // alert on use of default value
+			if resourceBlock.MissingChild("tracing_config") {
+				set.AddResult().
+					WithDescription("Resource '%s' does not have tracing_config configured", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
+				return
+			}
+			tracingBlock := resourceBlock.GetBlock("tracing_config")
+			modeAttr := tracingBlock.GetAttribute("mode")
+			if modeAttr.IsNil() { // alert on use of default value
 				set.AddResult().
-					WithDescription("Resource '%s' uses default value for tracing_config.mode", resourceBlock.FullName())
-			} else if modeAttr.IsEmpty() {
+					WithDescription("Resource '%s' uses default value for tracing_config.mode", resourceBlock.FullName()).
+					WithBlock(tracingBlock)
+				return
+			}
+			// the mode may come from a variable of a module which couldn't be resolved
+			if modeAttr.IsNotResolvable() {
+				return
+			}
+			if modeAttr.IsNoneOf("Active", "PassThrough") {
 				set.AddResult().
-					WithDescription("Resource '%s' has tracing_config.mode set to ", resourceBlock.FullName()).
+					WithDescription("Resource '%s' has tracing_config.mode set to an invalid value", resourceBlock.FullName()).
 					WithAttribute(modeAttr)
 			}
 		},
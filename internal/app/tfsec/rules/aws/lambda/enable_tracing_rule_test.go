@@ -52,3 +52,103 @@ func Test_AWSEnableTracing_SuccessExamples(t *testing.T) {
 		testutil.AssertCheckCode(t, "", rule.ID(), results)
 	}
 }
+
+func Test_AWSEnableTracing(t *testing.T) {
+	expectedCode := "aws-lambda-enable-tracing"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check fails when tracing_config is missing",
+			source: `
+resource "aws_lambda_function" "bad_example" {
+  function_name = "lambda_function_name"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when tracing_config.mode is missing",
+			source: `
+resource "aws_lambda_function" "bad_example" {
+  function_name = "lambda_function_name"
+  tracing_config {
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when tracing_config.mode is not a supported value",
+			source: `
+resource "aws_lambda_function" "bad_example" {
+  function_name = "lambda_function_name"
+  tracing_config {
+    mode = "Disabled"
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when tracing_config.mode is Active",
+			source: `
+resource "aws_lambda_function" "good_example" {
+  function_name = "lambda_function_name"
+  tracing_config {
+    mode = "Active"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when tracing_config.mode is PassThrough",
+			source: `
+resource "aws_lambda_function" "good_example" {
+  function_name = "lambda_function_name"
+  tracing_config {
+    mode = "PassThrough"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when tracing_config.mode can't be resolved",
+			source: `
+variable "tracing_mode" {
+}
+
+resource "aws_lambda_function" "good_example" {
+  function_name = "lambda_function_name"
+  tracing_config {
+    mode = var.tracing_mode
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when the module defining the function can't be loaded",
+			source: `
+module "function" {
+  source       = "./function"
+  tracing_mode = data.external.settings.result.mode
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
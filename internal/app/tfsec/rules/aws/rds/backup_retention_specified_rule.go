@@ -2,31 +2,32 @@ package rds
 
 // generator-locked
 import (
-	"github.com/aquasecurity/tfsec/pkg/result"
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/aquasecurity/tfsec/pkg/provider"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
-
-	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
 func init() {
-	scanner.RegisterCheckRule(rule.Rule{
-		LegacyID:  "AWS091",
-		Service:   "rds",
-		ShortCode: "backup-retention-specified",
-		Documentation: rule.RuleDocumentation{
-			Summary: "RDS Cluster and RDS instance should have backup retention longer than default 1 day",
-			Explanation: `
+	retention.RegisterCheck(retention.Check{
+		Rule: rule.Rule{
+			LegacyID:  "AWS091",
+			Service:   "rds",
+			ShortCode: "backup-retention-specified",
+			Documentation: rule.RuleDocumentation{
+				Summary: "RDS Cluster and RDS instance should retain backups for at least 7 days",
+				Explanation: `
 RDS backup retention for clusters defaults to 1 day, this may not be enough to identify and respond to an issue. Backup retention periods should be set to a period that is a balance on cost and limiting risk.
+
+Backups should be kept for at least 7 days. The minimum can be changed for this check with minimum_retention_days in the config file.
 `,
-			Impact:     "Potential loss of data and short opportunity for recovery",
-			Resolution: "Explicitly set the retention period to greater than the default",
-			BadExample: []string{`
+				Impact:     "Potential loss of data and short opportunity for recovery",
+				Resolution: "Explicitly set the retention period to at least 7 days",
+				BadExample: []string{`
 resource "aws_db_instance" "bad_example" {
 	allocated_storage    = 10
 	engine               = "mysql"
@@ -50,7 +51,7 @@ resource "aws_rds_cluster" "bad_example" {
 	preferred_backup_window = "07:00-09:00"
   }
 `},
-			GoodExample: []string{`
+				GoodExample: []string{`
 resource "aws_rds_cluster" "good_example" {
 	cluster_identifier      = "aurora-cluster-demo"
 	engine                  = "aurora-mysql"
@@ -59,7 +60,7 @@ resource "aws_rds_cluster" "good_example" {
 	database_name           = "mydb"
 	master_username         = "foo"
 	master_password         = "bar"
-	backup_retention_period = 5
+	backup_retention_period = 7
 	preferred_backup_window = "07:00-09:00"
   }
 
@@ -72,38 +73,25 @@ resource "aws_rds_cluster" "good_example" {
 	username             = "foo"
 	password             = "foobarbaz"
 	parameter_group_name = "default.mysql5.7"
-	backup_retention_period = 5
+	backup_retention_period = 7
 	skip_final_snapshot  = true
 }
 `},
-			Links: []string{
-				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/rds_cluster#backup_retention_period",
-				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/db_instance#backup_retention_period",
-				"https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_WorkingWithAutomatedBackups.html#USER_WorkingWithAutomatedBackups.BackupRetention",
+				Links: []string{
+					"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/rds_cluster#backup_retention_period",
+					"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/db_instance#backup_retention_period",
+					"https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_WorkingWithAutomatedBackups.html#USER_WorkingWithAutomatedBackups.BackupRetention",
+				},
 			},
+			Provider:        provider.AWSProvider,
+			RequiredTypes:   []string{"resource"},
+			RequiredLabels:  []string{"aws_rds_cluster", "aws_db_instance"},
+			DefaultSeverity: severity.Medium,
 		},
-		Provider:        provider.AWSProvider,
-		RequiredTypes:   []string{"resource"},
-		RequiredLabels:  []string{"aws_rds_cluster", "aws_db_instance"},
-		DefaultSeverity: severity.Medium,
-		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
-			if resourceBlock.HasChild("replicate_source_db") {
-				return
-			}
-
-			if resourceBlock.MissingChild("backup_retention_period") {
-				set.AddResult().
-					WithDescription("Resource '%s' does not have backup retention explicitly set", resourceBlock.FullName())
-				return
-			}
-
-			retentionAttr := resourceBlock.GetAttribute("backup_retention_period")
-			if retentionAttr.LessThanOrEqualTo(1) {
-				set.AddResult().
-					WithDescription("Resource '%s' has backup retention period set to a low value", resourceBlock.FullName()).
-					WithAttribute(retentionAttr)
-			}
-
+		Attribute:   "backup_retention_period",
+		MinimumDays: 7,
+		Skip: func(resourceBlock block.Block) bool {
+			return resourceBlock.HasChild("replicate_source_db")
 		},
 	})
 }
@@ -4,6 +4,7 @@ package rds
 import (
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/retention"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 )
 
@@ -85,7 +86,25 @@ func Test_AWSRDSRetentionPeriod(t *testing.T) {
 			mustIncludeResultCode: expectedCode,
 		},
 		{
-			name: "rds cluster with retention greater than default passes check",
+			name: "db instance with retention below 7 days fails check",
+			source: `
+			resource "aws_db_instance" "bad_example" {
+				allocated_storage       = 10
+				engine                  = "mysql"
+				engine_version          = "5.7"
+				instance_class          = "db.t3.micro"
+				name                    = "mydb"
+				username                = "foo"
+				password                = "foobarbaz"
+				parameter_group_name    = "default.mysql5.7"
+				backup_retention_period = 5
+				skip_final_snapshot     = true
+			}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "rds cluster with retention of 7 days passes check",
 			source: `
 			resource "aws_rds_cluster" "good_example" {
 				cluster_identifier      = "aurora-cluster-demo"
@@ -95,14 +114,14 @@ func Test_AWSRDSRetentionPeriod(t *testing.T) {
 				database_name           = "mydb"
 				master_username         = "foo"
 				master_password         = "bar"
-				backup_retention_period = 5
+				backup_retention_period = 7
 				preferred_backup_window = "07:00-09:00"
 			}
 `,
 			mustExcludeResultCode: expectedCode,
 		},
 		{
-			name: "db instance with retention greater than default passes check",
+			name: "db instance with retention of 7 days passes check",
 			source: `
 	        resource "aws_db_instance" "good_example" {
 				allocated_storage       = 10
@@ -113,7 +132,7 @@ func Test_AWSRDSRetentionPeriod(t *testing.T) {
 				username                = "foo"
 				password                = "foobarbaz"
 				parameter_group_name    = "default.mysql5.7"
-				backup_retention_period = 5
+				backup_retention_period = 7
 				skip_final_snapshot     = true
 			}
 `,
@@ -131,7 +150,7 @@ func Test_AWSRDSRetentionPeriod(t *testing.T) {
 				username                = "foo"
 				password                = "foobarbaz"
 				parameter_group_name    = "default.mysql5.7"
-				backup_retention_period = 5
+				backup_retention_period = 7
 				skip_final_snapshot     = true
 			}
 
@@ -162,3 +181,22 @@ func Test_AWSRDSRetentionPeriod(t *testing.T) {
 	}
 
 }
+
+func Test_AWSRDSRetentionPeriodMinimumCanBeOverridden(t *testing.T) {
+	expectedCode := "aws-rds-backup-retention-specified"
+
+	source := `
+resource "aws_rds_cluster" "example" {
+	cluster_identifier      = "aurora-cluster-demo"
+	engine                  = "aurora-mysql"
+	backup_retention_period = 7
+}
+`
+
+	retention.SetMinimums(map[string]int{expectedCode: 14})
+	defer retention.SetMinimums(nil)
+	testutil.AssertCheckCode(t, expectedCode, "", testutil.ScanHCL(source, t))
+
+	retention.SetMinimums(map[string]int{expectedCode: 3})
+	testutil.AssertCheckCode(t, "", expectedCode, testutil.ScanHCL(source, t))
+}
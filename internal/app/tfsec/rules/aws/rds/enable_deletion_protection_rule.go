@@ -0,0 +1,81 @@
+package rds
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "rds",
+		ShortCode: "enable-deletion-protection",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "RDS clusters and instances should have deletion protection enabled",
+			Explanation: `Deletion protection stops a database from being deleted, whether by a mistaken change to the Terraform configuration, a terraform destroy or a request to the AWS API. It has to be turned off before the database can be deleted.
+
+The check applies to the resource types listed in protected_resource_types in the config file, which includes aws_db_instance and aws_rds_cluster by default.`,
+			Impact:     "The database and its data could be deleted by accident",
+			Resolution: "Set deletion_protection to true",
+			BadExample: []string{`
+resource "aws_rds_cluster" "bad_example" {
+  cluster_identifier  = "aurora-cluster-demo"
+  engine              = "aurora-mysql"
+  deletion_protection = false
+}
+`, `
+resource "aws_db_instance" "bad_example" {
+  allocated_storage = 10
+  engine            = "mysql"
+  instance_class    = "db.t3.micro"
+}
+`},
+			GoodExample: []string{`
+resource "aws_rds_cluster" "good_example" {
+  cluster_identifier  = "aurora-cluster-demo"
+  engine              = "aurora-mysql"
+  deletion_protection = true
+}
+`, `
+resource "aws_db_instance" "good_example" {
+  allocated_storage   = 10
+  engine              = "mysql"
+  instance_class      = "db.t3.micro"
+  deletion_protection = true
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/rds_cluster#deletion_protection",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/db_instance#deletion_protection",
+				"https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_DeleteInstance.html#USER_DeletionProtection",
+			},
+		},
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"aws_rds_cluster", "aws_db_instance"},
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if !protection.IsProtected(resourceBlock) {
+				return
+			}
+
+			deletionProtectionAttr := resourceBlock.GetAttribute("deletion_protection")
+			if deletionProtectionAttr.IsNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not have deletion protection enabled.", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
+				return
+			}
+
+			if deletionProtectionAttr.IsFalse() {
+				set.AddResult().
+					WithDescription("Resource '%s' has deletion protection disabled.", resourceBlock.FullName()).
+					WithAttribute(deletionProtectionAttr)
+			}
+		},
+	})
+}
@@ -0,0 +1,91 @@
+package rds
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSRDSEnableDeletionProtection(t *testing.T) {
+	expectedCode := "aws-rds-enable-deletion-protection"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "cluster without deletion protection fails check",
+			source: `
+resource "aws_rds_cluster" "bad_example" {
+	cluster_identifier = "aurora-cluster-demo"
+	engine             = "aurora-mysql"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with deletion protection disabled fails check",
+			source: `
+resource "aws_db_instance" "bad_example" {
+	allocated_storage   = 10
+	engine              = "mysql"
+	instance_class      = "db.t3.micro"
+	deletion_protection = false
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with deletion protection enabled passes check",
+			source: `
+resource "aws_db_instance" "good_example" {
+	allocated_storage   = 10
+	engine              = "mysql"
+	instance_class      = "db.t3.micro"
+	deletion_protection = true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "cluster with deletion protection from a variable passes check",
+			source: `
+variable "protect" {
+	default = true
+}
+
+resource "aws_rds_cluster" "good_example" {
+	cluster_identifier  = "aurora-cluster-demo"
+	engine              = "aurora-mysql"
+	deletion_protection = var.protect
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSRDSEnableDeletionProtectionOnlyChecksProtectedTypes(t *testing.T) {
+	protection.SetProtectedTypes([]string{"aws_rds_cluster"})
+	defer protection.SetProtectedTypes(nil)
+
+	results := testutil.ScanHCL(`
+resource "aws_db_instance" "example" {
+	allocated_storage = 10
+	engine            = "mysql"
+	instance_class    = "db.t3.micro"
+}
+`, t)
+	testutil.AssertCheckCode(t, "", "aws-rds-enable-deletion-protection", results)
+}
@@ -25,19 +25,32 @@ func init() {
 			Resolution: "Set the database to not be publicly accessible",
 			Explanation: `
 Database resources should not publicly available. You should limit all access to the minimum that is required for your application to function. 
+
+A publicly accessible database is given a public IP address, so anything which can reach it through its security groups can attempt to connect from the internet. Databases are not publicly accessible unless <code>publicly_accessible</code> is set to true.
 `,
 			BadExample: []string{`
 resource "aws_db_instance" "bad_example" {
 	publicly_accessible = true
 }
+`, `
+resource "aws_rds_cluster_instance" "bad_example" {
+	cluster_identifier  = "my-cluster"
+	publicly_accessible = true
+}
 `},
 			GoodExample: []string{`
 resource "aws_db_instance" "good_example" {
 	publicly_accessible = false
 }
+`, `
+resource "aws_rds_cluster_instance" "good_example" {
+	cluster_identifier = "my-cluster"
+}
 `},
 			Links: []string{
 				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/db_instance",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/rds_cluster_instance#publicly_accessible",
+				"https://docs.aws.amazon.com/AmazonRDS/latest/UserGuide/USER_VPC.WorkingWithRDSInstanceinaVPC.html#USER_VPC.Hiding",
 			},
 		},
 		Provider:        provider.AWSProvider,
@@ -48,6 +48,38 @@ resource "aws_redshift_cluster" "my-resource" {
 }`,
 			mustIncludeResultCode: expectedCode,
 		},
+		{
+			name: "check aws_db_instance when publicly exposed through a variable",
+			source: `
+variable "public" {
+	default = true
+}
+
+resource "aws_db_instance" "my-resource" {
+	publicly_accessible = var.public
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check aws_db_instance when not publicly exposed through a variable",
+			source: `
+variable "public" {
+	default = false
+}
+
+resource "aws_db_instance" "my-resource" {
+	publicly_accessible = var.public
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check aws_rds_cluster_instance when publicly_accessible is not set",
+			source: `
+resource "aws_rds_cluster_instance" "my-resource" {
+	cluster_identifier = "my-cluster"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
 		{
 			name: "check aws_redshift_cluster when not publicly exposed",
 			source: `
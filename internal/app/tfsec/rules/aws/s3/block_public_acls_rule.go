@@ -2,6 +2,9 @@ package s3
 
 // generator-locked
 import (
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/aquasecurity/tfsec/pkg/result"
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
@@ -67,5 +70,9 @@ resource "aws_s3_bucket_public_access_block" "good_example" {
 					WithAttribute(publicAclAttr)
 			}
 		},
+		FixFunc: func(body *hclwrite.Body) bool {
+			body.SetAttributeValue("block_public_acls", cty.True)
+			return true
+		},
 	})
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/aquasecurity/tfsec/pkg/provider"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/logbuckets"
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
@@ -23,6 +24,8 @@ func init() {
 			Summary: "S3 Bucket does not have logging enabled.",
 			Explanation: `
 Buckets should have logging enabled so that access can be audited. 
+
+Logging can be enabled with a logging block on the bucket, or with a separate aws_s3_bucket_logging resource. Buckets which receive access logs are not expected to log themselves; a bucket is treated as a log destination when another bucket sends its logs to it, when it has the log-delivery-write ACL, or when it matches the log_bucket_patterns or log_bucket_tags set in the config file.
 `,
 			Impact:     "There is no way to determine the access to this bucket",
 			Resolution: "Add a logging block to the resource to enable access logging",
@@ -40,6 +43,7 @@ resource "aws_s3_bucket" "good_example" {
 `},
 			Links: []string{
 				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_logging",
 				"https://docs.aws.amazon.com/AmazonS3/latest/dev/ServerLogs.html",
 			},
 		},
@@ -47,15 +51,36 @@ resource "aws_s3_bucket" "good_example" {
 		RequiredTypes:   []string{"resource"},
 		RequiredLabels:  []string{"aws_s3_bucket"},
 		DefaultSeverity: severity.Medium,
-		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
-
-			if resourceBlock.MissingChild("logging") {
-				if resourceBlock.GetAttribute("acl").IsNotNil() && resourceBlock.GetAttribute("acl").Equals("log-delivery-write") {
-					return
-				}
-				set.AddResult().
-					WithDescription("Resource '%s' does not have logging enabled.", resourceBlock.FullName())
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+
+			if resourceBlock.HasChild("logging") {
+				return
+			}
+			if resourceBlock.GetAttribute("acl").IsNotNil() && resourceBlock.GetAttribute("acl").Equals("log-delivery-write") {
+				return
+			}
+			if loggingBlocks, err := module.GetReferencingResources(resourceBlock, "aws_s3_bucket_logging", "bucket"); err == nil && len(loggingBlocks) > 0 {
+				return
+			}
+			if isLogTargetBucket(resourceBlock, module) || logbuckets.IsLogBucket(resourceBlock) {
+				return
 			}
+			set.AddResult().
+				WithDescription("Resource '%s' does not have logging enabled.", resourceBlock.FullName()).
+				WithBlock(resourceBlock)
 		},
 	})
 }
+
+// isLogTargetBucket checks whether another bucket in the module sends its access logs to this one
+func isLogTargetBucket(bucketBlock block.Block, module block.Module) bool {
+	if loggingBlocks, err := module.GetReferencingResources(bucketBlock, "aws_s3_bucket_logging", "target_bucket"); err == nil && len(loggingBlocks) > 0 {
+		return true
+	}
+	for _, otherBucket := range module.GetResourcesByType("aws_s3_bucket") {
+		if otherBucket.GetBlock("logging").GetAttribute("target_bucket").ReferencesBlock(bucketBlock) {
+			return true
+		}
+	}
+	return false
+}
@@ -4,6 +4,7 @@ package s3
 import (
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/logbuckets"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 )
 
@@ -42,6 +43,60 @@ resource "aws_s3_bucket" "my-bucket" {
 }`,
 			mustExcludeResultCode: expectedCode,
 		},
+		{
+			name: "check bucket with logging enabled by a separate resource",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	bucket = "my-bucket"
+}
+
+resource "aws_s3_bucket" "log-bucket" {
+	acl = "log-delivery-write"
+}
+
+resource "aws_s3_bucket_logging" "my-bucket" {
+	bucket        = aws_s3_bucket.my-bucket.id
+	target_bucket = aws_s3_bucket.log-bucket.id
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check bucket used as a logging target by a separate resource",
+			source: `
+resource "aws_s3_bucket" "log-bucket" {
+}
+
+resource "aws_s3_bucket_logging" "other" {
+	bucket        = "other-bucket"
+	target_bucket = aws_s3_bucket.log-bucket.id
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check bucket used as a logging target by another bucket",
+			source: `
+resource "aws_s3_bucket" "log-bucket" {
+}
+
+resource "aws_s3_bucket" "my-bucket" {
+	logging {
+		target_bucket = aws_s3_bucket.log-bucket.id
+	}
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check logging resource for another bucket does not count",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+}
+
+resource "aws_s3_bucket_logging" "other" {
+	bucket        = "other-bucket"
+	target_bucket = "log-bucket"
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
 	}
 
 	for _, test := range tests {
@@ -53,3 +108,55 @@ resource "aws_s3_bucket" "my-bucket" {
 	}
 
 }
+
+func Test_AWSBucketLoggingConvention(t *testing.T) {
+	expectedCode := "aws-s3-enable-bucket-logging"
+
+	logbuckets.SetConvention([]string{"*-access-logs"}, map[string]string{"Purpose": "logging"})
+	defer logbuckets.SetConvention(nil, nil)
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check bucket name matching the log bucket pattern",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	bucket = "example-access-logs"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check bucket with the log bucket tags",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	tags = {
+		Purpose = "logging"
+	}
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check bucket with a different tag value",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	bucket = "example-data"
+	tags = {
+		Purpose = "data"
+	}
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package s3
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// writeActionPrefixes are the lower case prefixes of the S3 actions which modify or delete buckets and objects
+var writeActionPrefixes = []string{
+	"s3:abortmultipartupload",
+	"s3:create",
+	"s3:delete",
+	"s3:put",
+	"s3:replicate",
+	"s3:restoreobject",
+}
+
+type bucketPolicyDocument struct {
+	Statements []bucketPolicyStatement `json:"Statement"`
+}
+
+type bucketPolicyStatement struct {
+	Sid       string                 `json:"Sid,omitempty"`
+	Effect    string                 `json:"Effect"`
+	Action    interface{}            `json:"Action,omitempty"`
+	Principal interface{}            `json:"Principal,omitempty"`
+	Condition map[string]interface{} `json:"Condition,omitempty"`
+}
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "s3",
+		ShortCode: "no-public-write-policy",
+		Documentation: rule.RuleDocumentation{
+			Summary: "S3 bucket policies should not allow anyone to write to or delete from the bucket",
+			Explanation: `
+A bucket policy statement with a principal of "*" applies to everyone, including anonymous users. Granting write or delete actions this way, without a condition restricting who can use them, allows anyone to modify or remove the objects in the bucket.
+`,
+			Impact:     "Anyone can modify or delete the contents of the bucket",
+			Resolution: "Restrict the principals of the statement, or add a condition limiting its use",
+			BadExample: []string{`
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.bad_example.json
+}
+
+data "aws_iam_policy_document" "bad_example" {
+  statement {
+    actions   = ["s3:PutObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+  }
+}
+`, `
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = ["s3:DeleteObject"]
+        Resource  = "arn:aws:s3:::example/*"
+      }
+    ]
+  })
+}
+`},
+			GoodExample: []string{`
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.good_example.json
+}
+
+data "aws_iam_policy_document" "good_example" {
+  statement {
+    actions   = ["s3:PutObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["arn:aws:iam::123456789012:role/uploader"]
+    }
+  }
+}
+`, `
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = ["s3:PutObject"]
+        Resource  = "arn:aws:s3:::example/*"
+        Condition = {
+          StringEquals = {
+            "aws:SourceVpce" = "vpce-1a2b3c4d"
+          }
+        }
+      }
+    ]
+  })
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_policy",
+				"https://docs.aws.amazon.com/AmazonS3/latest/userguide/example-bucket-policies.html",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"aws_s3_bucket_policy",
+			"aws_s3_bucket",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			policyAttr := resourceBlock.GetAttribute("policy")
+			if policyAttr.IsNil() {
+				return
+			}
+
+			// check referenced documents directly so results point at the offending statement
+			policyDocumentBlock, err := module.GetReferencedBlock(policyAttr)
+			if err != nil || policyDocumentBlock.Type() != "data" || policyDocumentBlock.TypeLabel() != "aws_iam_policy_document" {
+				if policyAttr.IsString() {
+					checkPublicWritePolicyJSON(set, resourceBlock, policyAttr)
+				}
+				return
+			}
+
+			for _, statementBlock := range policyDocumentBlock.GetBlocks("statement") {
+				// statements allow by default
+				if statementBlock.GetAttribute("effect").Equals("deny", block.IgnoreCase) {
+					continue
+				}
+				if statementBlock.HasChild("condition") || !isPublicPrincipalBlock(statementBlock) {
+					continue
+				}
+				if actionsAttr := statementBlock.GetAttribute("actions"); actionsAttr.IsNotNil() && containsWriteAction(actionsAttr.ValueAsStrings()) {
+					set.AddResult().
+						WithDescription("Resource '%s' allows anyone to write to the bucket through '%s'", resourceBlock.FullName(), policyDocumentBlock.FullName()).
+						WithBlock(policyDocumentBlock).
+						WithBlock(statementBlock)
+				}
+			}
+		},
+	})
+}
+
+func isPublicPrincipalBlock(statementBlock block.Block) bool {
+	for _, principalsBlock := range statementBlock.GetBlocks("principals") {
+		if !principalsBlock.GetAttribute("type").IsAny("*", "AWS") {
+			continue
+		}
+		if principalsBlock.GetAttribute("identifiers").Contains("*") {
+			return true
+		}
+	}
+	return false
+}
+
+func checkPublicWritePolicyJSON(set result.Set, resourceBlock block.Block, policyAttr block.Attribute) {
+	var document bucketPolicyDocument
+	if err := json.Unmarshal([]byte(policyAttr.Value().AsString()), &document); err != nil {
+		debug.Log("Error decoding S3 bucket policy JSON at %s: %s", policyAttr.Range(), err)
+		return
+	}
+
+	for i, statement := range document.Statements {
+		if !strings.EqualFold(statement.Effect, "allow") || len(statement.Condition) > 0 {
+			continue
+		}
+		if !isPublicPrincipal(statement.Principal) || !containsWriteAction(policyValues(statement.Action)) {
+			continue
+		}
+		// statements share the location of the policy attribute, so the description names the statement to keep the
+		// results for each of them apart
+		statementName := fmt.Sprintf("statement %d", i+1)
+		if statement.Sid != "" {
+			statementName = fmt.Sprintf("statement '%s'", statement.Sid)
+		}
+		set.AddResult().
+			WithDescription("Resource '%s' allows anyone to write to the bucket through %s", resourceBlock.FullName(), statementName).
+			WithAttribute(policyAttr).
+			WithRangeAnnotation(fmt.Sprintf("%s allows anyone to write to the bucket", statementName))
+	}
+}
+
+// isPublicPrincipal returns true for a principal of "*", or an AWS principal which includes "*"
+func isPublicPrincipal(principal interface{}) bool {
+	switch p := principal.(type) {
+	case string:
+		return p == "*"
+	case map[string]interface{}:
+		for _, identifier := range policyValues(p["AWS"]) {
+			if identifier == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// policyValues converts a policy value, which can be a string or a list of strings, to a list of strings
+func policyValues(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var values []string
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values
+	}
+	return nil
+}
+
+func containsWriteAction(actions []string) bool {
+	for _, action := range actions {
+		action = strings.ToLower(action)
+		for _, prefix := range writeActionPrefixes {
+			if strings.HasPrefix(action, prefix) {
+				return true
+			}
+			// wildcards such as "*", "s3:*" and "s3:Put*" also match write actions
+			if strings.HasSuffix(action, "*") && strings.HasPrefix(prefix, strings.TrimSuffix(action, "*")) {
+				return true
+			}
+		}
+	}
+	return false
+}
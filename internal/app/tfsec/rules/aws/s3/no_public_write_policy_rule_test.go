@@ -0,0 +1,262 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AWSNoPublicWritePolicy(t *testing.T) {
+	expectedCode := "aws-s3-no-public-write-policy"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check fails when a referenced policy document allows anyone to put objects",
+			source: `
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.bad_example.json
+}
+
+data "aws_iam_policy_document" "bad_example" {
+  statement {
+    actions   = ["s3:PutObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when a referenced policy document allows any AWS principal all s3 actions",
+			source: `
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.bad_example.json
+}
+
+data "aws_iam_policy_document" "bad_example" {
+  statement {
+    actions   = ["s3:*"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["*"]
+    }
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when a referenced policy document only allows anyone to read objects",
+			source: `
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.good_example.json
+}
+
+data "aws_iam_policy_document" "good_example" {
+  statement {
+    actions   = ["s3:GetObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when a public write statement has a condition",
+			source: `
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.good_example.json
+}
+
+data "aws_iam_policy_document" "good_example" {
+  statement {
+    actions   = ["s3:PutObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+
+    condition {
+      test     = "StringEquals"
+      variable = "aws:SourceVpce"
+      values   = ["vpce-1a2b3c4d"]
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when a public write statement denies access",
+			source: `
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = data.aws_iam_policy_document.good_example.json
+}
+
+data "aws_iam_policy_document" "good_example" {
+  statement {
+    effect    = "Deny"
+    actions   = ["s3:DeleteObject"]
+    resources = ["arn:aws:s3:::example/*"]
+
+    principals {
+      type        = "*"
+      identifiers = ["*"]
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when a json policy allows anyone to delete objects",
+			source: `
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = { AWS = "*" }
+        Action    = "s3:Delete*"
+        Resource  = "arn:aws:s3:::example/*"
+      }
+    ]
+  })
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when an inline bucket policy allows anyone all actions",
+			source: `
+resource "aws_s3_bucket" "bad_example" {
+  bucket = "example"
+  policy = <<POLICY
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": "*",
+      "Resource": "arn:aws:s3:::example/*"
+    }
+  ]
+}
+POLICY
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when a json policy only allows a specific principal to write",
+			source: `
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = { AWS = "arn:aws:iam::123456789012:root" }
+        Action    = "s3:PutObject"
+        Resource  = "arn:aws:s3:::example/*"
+      }
+    ]
+  })
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when the policy can't be decoded",
+			source: `
+variable "policy" {
+}
+
+resource "aws_s3_bucket_policy" "good_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = var.policy
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSNoPublicWritePolicyReportsEachStatement(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_s3_bucket_policy" "bad_example" {
+  bucket = aws_s3_bucket.example.id
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicUpload"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:PutObject"
+        Resource  = "arn:aws:s3:::example/*"
+      },
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3:::example/*"
+      },
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = ["s3:DeleteObject"]
+        Resource  = "arn:aws:s3:::example/*"
+      }
+    ]
+  })
+}
+`, t)
+
+	var annotations []string
+	for _, res := range results {
+		if res.RuleID == "aws-s3-no-public-write-policy" {
+			annotations = append(annotations, res.RangeAnnotation)
+		}
+	}
+	require.Len(t, annotations, 2)
+	assert.Contains(t, annotations, "statement 'PublicUpload' allows anyone to write to the bucket")
+	assert.Contains(t, annotations, "statement 3 allows anyone to write to the bucket")
+}
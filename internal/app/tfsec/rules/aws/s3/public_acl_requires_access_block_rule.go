@@ -0,0 +1,101 @@
+package s3
+
+import (
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+
+	"github.com/aquasecurity/tfsec/pkg/provider"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+
+	"github.com/aquasecurity/tfsec/pkg/rule"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Service:   "s3",
+		ShortCode: "public-acl-requires-access-block",
+		Documentation: rule.RuleDocumentation{
+			Summary:    "S3 Bucket with a public ACL is not protected by a public access block.",
+			Impact:     "The public ACL takes effect, so the contents of the bucket can be accessed publicly",
+			Resolution: "Add an aws_s3_bucket_public_access_block for the bucket which sets block_public_acls and ignore_public_acls to true",
+			Explanation: `
+A public ACL on a bucket is only prevented from taking effect by a public access block which ignores public ACLs. <code>block_public_acls</code> only rejects requests which set a public ACL, so an ACL which is already in place stays in effect unless <code>ignore_public_acls</code> is also enabled. A bucket which has a public ACL and no associated <code>aws_s3_bucket_public_access_block</code> with both enabled is publicly accessible.
+`,
+			BadExample: []string{`
+resource "aws_s3_bucket" "bad_example" {
+	acl = "public-read"
+}
+`, `
+resource "aws_s3_bucket" "bad_example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "bad_example" {
+	bucket = aws_s3_bucket.bad_example.id
+
+	block_public_acls = false
+}
+`, `
+resource "aws_s3_bucket" "bad_example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "bad_example" {
+	bucket = aws_s3_bucket.bad_example.id
+
+	block_public_acls = true
+}
+`},
+			GoodExample: []string{`
+resource "aws_s3_bucket" "good_example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "good_example" {
+	bucket = aws_s3_bucket.good_example.id
+
+	block_public_acls  = true
+	ignore_public_acls = true
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_public_access_block#block_public_acls",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/s3_bucket_public_access_block#ignore_public_acls",
+				"https://docs.aws.amazon.com/AmazonS3/latest/userguide/access-control-block-public-access.html",
+			},
+		},
+		Provider:        provider.AWSProvider,
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"aws_s3_bucket"},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			aclAttr := resourceBlock.GetAttribute("acl")
+			if aclAttr.IsNil() || !aclAttr.IsAny("public-read", "public-read-write", "website") {
+				return
+			}
+
+			accessBlocks, err := module.GetReferencingResources(resourceBlock, "aws_s3_bucket_public_access_block", "bucket")
+			if err != nil || len(accessBlocks) == 0 {
+				set.AddResult().
+					WithDescription("Resource '%s' has a public ACL and no aws_s3_bucket_public_access_block.", resourceBlock.FullName()).
+					WithAttribute(aclAttr)
+				return
+			}
+
+			for _, accessBlock := range accessBlocks {
+				if accessBlock.GetAttribute("block_public_acls").IsTrue() && accessBlock.GetAttribute("ignore_public_acls").IsTrue() {
+					return
+				}
+			}
+
+			accessBlock := accessBlocks[0]
+			set.AddResult().
+				WithDescription("Resource '%s' has a public ACL which is not blocked by '%s' (%s).", resourceBlock.FullName(), accessBlock.FullName(), accessBlock.Range().String()).
+				WithBlock(accessBlock).
+				WithAttribute(aclAttr)
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package s3
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSS3PublicACLRequiresAccessBlock(t *testing.T) {
+	expectedCode := "aws-s3-public-acl-requires-access-block"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "public bucket without a public access block",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public bucket with a public access block which doesn't block public acls",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read-write"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+	bucket              = aws_s3_bucket.example.id
+	block_public_policy = true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public bucket with a public access block which explicitly allows public acls",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+	bucket            = aws_s3_bucket.example.id
+	block_public_acls = false
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public access block for a different bucket",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket" "other" {
+}
+
+resource "aws_s3_bucket_public_access_block" "other" {
+	bucket            = aws_s3_bucket.other.id
+	block_public_acls = true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public bucket with a public access block which blocks but doesn't ignore public acls",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+	bucket            = aws_s3_bucket.example.id
+	block_public_acls = true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public bucket with a public access block which ignores but doesn't block public acls",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+	bucket             = aws_s3_bucket.example.id
+	ignore_public_acls = true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public bucket with a public access block which blocks and ignores public acls",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "public-read"
+}
+
+resource "aws_s3_bucket_public_access_block" "example" {
+	bucket             = aws_s3_bucket.example.id
+	block_public_acls  = true
+	ignore_public_acls = true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "private bucket without a public access block",
+			source: `
+resource "aws_s3_bucket" "example" {
+	acl = "private"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -11,8 +11,6 @@ import (
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
-	"github.com/zclconf/go-cty/cty"
-
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 )
 
@@ -54,7 +52,7 @@ resource "aws_sns_topic" "good_example" {
 				set.AddResult().
 					WithDescription("Resource '%s' defines an unencrypted SNS topic.", resourceBlock.FullName())
 				return
-			} else if kmsKeyIDAttr.Type() == cty.String && kmsKeyIDAttr.Value().AsString() == "" {
+			} else if kmsKeyIDAttr.IsEmpty() {
 				set.AddResult().
 					WithDescription("Resource '%s' defines an unencrypted SNS topic.", resourceBlock.FullName()).
 					WithAttribute(kmsKeyIDAttr)
@@ -0,0 +1,110 @@
+package tagging
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+// taggableTypes are commonly used resources which support tags, and are checked even when they don't declare any.
+// Any other resource is checked if it has a tags argument.
+var taggableTypes = map[string]bool{
+	"aws_cloudwatch_log_group": true,
+	"aws_db_instance":          true,
+	"aws_dynamodb_table":       true,
+	"aws_ebs_volume":           true,
+	"aws_ecr_repository":       true,
+	"aws_ecs_cluster":          true,
+	"aws_eks_cluster":          true,
+	"aws_elasticache_cluster":  true,
+	"aws_iam_role":             true,
+	"aws_instance":             true,
+	"aws_kms_key":              true,
+	"aws_lambda_function":      true,
+	"aws_launch_template":      true,
+	"aws_lb":                   true,
+	"aws_rds_cluster":          true,
+	"aws_s3_bucket":            true,
+	"aws_security_group":       true,
+	"aws_sns_topic":            true,
+	"aws_sqs_queue":            true,
+	"aws_subnet":               true,
+	"aws_vpc":                  true,
+}
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "tagging",
+		ShortCode: "require-tags",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Resources should carry the tags required by your organisation",
+			Explanation: `Consistent tagging makes it possible to attribute cost, ownership and environment to every resource. The required tag keys are set with required_tags in the config file; the check does nothing until they are set. Tags applied through the default_tags of the aws provider are taken into account.`,
+			Impact:      "Resources can't be attributed to an owner or environment",
+			Resolution:  "Add the required tags to the resource or to the provider default_tags",
+			BadExample: []string{`
+resource "aws_s3_bucket" "bad_example" {
+  bucket = "my-bucket"
+
+  tags = {
+    Environment = "production"
+  }
+}
+`},
+			GoodExample: []string{`
+resource "aws_s3_bucket" "good_example" {
+  bucket = "my-bucket"
+
+  tags = {
+    Environment = "production"
+    Owner       = "platform-team"
+  }
+}
+`, `
+provider "aws" {
+  default_tags {
+    tags = {
+      Environment = "production"
+      Owner       = "platform-team"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "good_example" {
+  bucket = "my-bucket"
+}
+`},
+			Links: []string{
+				"https://docs.aws.amazon.com/general/latest/gr/aws_tagging.html",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/guides/resource-tagging",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		DefaultSeverity: severity.Low,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if len(tagging.RequiredTags()) == 0 || !strings.HasPrefix(resourceBlock.TypeLabel(), "aws_") {
+				return
+			}
+			if resourceBlock.MissingChild("tags") && !taggableTypes[resourceBlock.TypeLabel()] {
+				return
+			}
+
+			missing, ok := tagging.MissingTags(resourceBlock)
+			if !ok || len(missing) == 0 {
+				return
+			}
+
+			set.AddResult().
+				WithDescription("Resource '%s' is missing required tags: %s", resourceBlock.FullName(), strings.Join(missing, ", ")).
+				WithAttribute(resourceBlock.GetAttribute("tags"))
+		},
+	})
+}
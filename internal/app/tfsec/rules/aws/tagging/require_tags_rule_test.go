@@ -0,0 +1,137 @@
+package tagging
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSRequireTags(t *testing.T) {
+	expectedCode := "aws-tagging-require-tags"
+
+	tagging.SetRequiredTags([]string{"Environment", "Owner"})
+	defer tagging.SetRequiredTags(nil)
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "resource missing a required tag fails check",
+			source: `
+resource "aws_s3_bucket" "example" {
+  tags = {
+    Environment = "production"
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "taggable resource without tags fails check",
+			source: `
+resource "aws_instance" "example" {
+  ami = "ami-12345678"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "resource with a tag missing from the default tags of its aliased provider fails check",
+			source: `
+provider "aws" {
+  default_tags {
+    tags = {
+      Environment = "production"
+      Owner       = "platform-team"
+    }
+  }
+}
+
+provider "aws" {
+  alias = "east"
+
+  default_tags {
+    tags = {
+      Environment = "production"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "example" {
+  provider = aws.east
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "resource with all required tags passes check",
+			source: `
+resource "aws_s3_bucket" "example" {
+  tags = {
+    Environment = "production"
+    Owner       = "platform-team"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "resource with tags split between the resource and default tags passes check",
+			source: `
+provider "aws" {
+  default_tags {
+    tags = {
+      Owner = "platform-team"
+    }
+  }
+}
+
+resource "aws_s3_bucket" "example" {
+  tags = {
+    Environment = "production"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "resource which isn't known to be taggable passes check",
+			source: `
+resource "aws_s3_bucket_policy" "example" {
+  bucket = "my-bucket"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "resource with unresolvable tags passes check",
+			source: `
+variable "tags" {}
+
+resource "aws_s3_bucket" "example" {
+  tags = var.tags
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AWSRequireTagsNotConfigured(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_s3_bucket" "example" {
+}
+`, t)
+	testutil.AssertCheckCode(t, "", "aws-tagging-require-tags", results)
+}
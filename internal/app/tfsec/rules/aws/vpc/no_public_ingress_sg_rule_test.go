@@ -107,6 +107,19 @@ func Test_AWSOpenIngressSecurityGroup(t *testing.T) {
 		}`,
 			mustExcludeResultCode: expectedCode,
 		},
+		{
+			name: "check aws_security_group ingress on 0.0.0.0/0 to a sensitive port",
+			source: `
+		resource "aws_security_group" "my-group" {
+			ingress {
+				from_port   = 22
+				to_port     = 22
+				protocol    = "tcp"
+				cidr_blocks = ["0.0.0.0/0"]
+			}
+		}`,
+			mustIncludeResultCode: expectedCode,
+		},
 	}
 
 	for _, test := range tests {
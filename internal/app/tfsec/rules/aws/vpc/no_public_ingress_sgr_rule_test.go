@@ -52,6 +52,18 @@ variable "blocks" {
 resource "aws_security_group_rule" "my-rule" {
 	type = "ingress"
 	ipv6_cidr_blocks = ["::/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check aws_security_group_rule ingress on 0.0.0.0/0 to a sensitive port",
+			source: `
+resource "aws_security_group_rule" "my-rule" {
+	type        = "ingress"
+	from_port   = 5432
+	to_port     = 5432
+	protocol    = "tcp"
+	cidr_blocks = ["0.0.0.0/0"]
 }`,
 			mustIncludeResultCode: expectedCode,
 		},
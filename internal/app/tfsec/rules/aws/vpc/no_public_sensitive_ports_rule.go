@@ -0,0 +1,125 @@
+package vpc
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AWSProvider,
+		Service:   "vpc",
+		ShortCode: "no-public-sensitive-ports",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Security groups should not expose management or database ports to the internet",
+			Explanation: `Management ports such as SSH (22) and RDP (3389), and database ports such as MySQL (3306) and PostgreSQL (5432), are constantly scanned for and attacked from the internet. Access to them should be limited to known addresses, or provided through a bastion or a VPN. The ports which are checked can be changed with sensitive_ports in the config file. Security groups which are attached to an instance are reported against the instance by aws-ec2-no-public-ingress-sensitive-ports instead.`,
+			Impact:      "Management and database services can be attacked from anywhere on the internet",
+			Resolution:  "Restrict the source of ingress to sensitive ports to known addresses",
+			BadExample: []string{`
+resource "aws_security_group" "bad_example" {
+  ingress {
+    from_port   = 22
+    to_port     = 22
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+`, `
+resource "aws_security_group_rule" "bad_example" {
+  type              = "ingress"
+  from_port         = 3000
+  to_port           = 3500
+  protocol          = "tcp"
+  ipv6_cidr_blocks  = ["::/0"]
+  security_group_id = aws_security_group.example.id
+}
+`},
+			GoodExample: []string{`
+resource "aws_security_group" "good_example" {
+  ingress {
+    from_port   = 22
+    to_port     = 22
+    protocol    = "tcp"
+    cidr_blocks = ["10.0.0.0/16"]
+  }
+
+  ingress {
+    from_port   = 443
+    to_port     = 443
+    protocol    = "tcp"
+    cidr_blocks = ["0.0.0.0/0"]
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/security_group#ingress",
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/resources/security_group_rule",
+				"https://docs.aws.amazon.com/vpc/latest/userguide/VPC_SecurityGroups.html",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"aws_security_group",
+			"aws_security_group_rule",
+		},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			if resourceBlock.IsResourceType("aws_security_group_rule") {
+				if !resourceBlock.GetAttribute("type").Equals("ingress") {
+					return
+				}
+				if sgAttr := resourceBlock.GetAttribute("security_group_id"); sgAttr.IsNotNil() {
+					for _, securityGroup := range module.GetReferencedBlocks(sgAttr) {
+						if isAttachedToInstance(securityGroup, module) {
+							return
+						}
+					}
+				}
+				checkSensitivePortIngress(set, resourceBlock, resourceBlock)
+				return
+			}
+			if isAttachedToInstance(resourceBlock, module) {
+				return
+			}
+			for _, ingressBlock := range resourceBlock.GetBlocks("ingress") {
+				checkSensitivePortIngress(set, resourceBlock, ingressBlock)
+			}
+		},
+	})
+}
+
+// isAttachedToInstance checks whether a security group is used by an instance, in which case any exposure is reported
+// against the instance by aws-ec2-no-public-ingress-sensitive-ports instead
+func isAttachedToInstance(securityGroup block.Block, module block.Module) bool {
+	if !securityGroup.IsResourceType("aws_security_group") {
+		return false
+	}
+	for _, attrName := range []string{"vpc_security_group_ids", "security_groups"} {
+		instances, err := module.GetReferencingResources(securityGroup, "aws_instance", attrName)
+		if err == nil && len(instances) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSensitivePortIngress reports an ingress rule which opens a sensitive port to the internet
+func checkSensitivePortIngress(set result.Set, resourceBlock block.Block, ingressBlock block.Block) {
+	portRange, portAttrs, ok := ports.SecurityGroupIngress(ingressBlock)
+	if !ok {
+		return
+	}
+	for _, name := range []string{"cidr_blocks", "ipv6_cidr_blocks"} {
+		if sourceAttr := ingressBlock.GetAttribute(name); cidr.IsAttributeOpen(sourceAttr) {
+			ports.ReportExposure(set, resourceBlock, sourceAttr, []ports.Range{portRange}, portAttrs...)
+		}
+	}
+}
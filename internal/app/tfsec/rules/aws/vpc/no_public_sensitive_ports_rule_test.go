@@ -0,0 +1,139 @@
+package vpc
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AWSNoPublicSensitivePorts(t *testing.T) {
+	expectedCode := "aws-vpc-no-public-sensitive-ports"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "ssh open to the internet in an inline ingress rule",
+			source: `
+resource "aws_security_group" "my-group" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "range covering a database port open to the internet over ipv6",
+			source: `
+resource "aws_security_group_rule" "my-rule" {
+	type             = "ingress"
+	from_port        = 3000
+	to_port          = 3500
+	protocol         = "tcp"
+	ipv6_cidr_blocks = ["::/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "all protocols open to the internet",
+			source: `
+resource "aws_security_group" "my-group" {
+	ingress {
+		from_port   = 0
+		to_port     = 0
+		protocol    = "-1"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "ssh open to a private range",
+			source: `
+resource "aws_security_group" "my-group" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["10.0.0.0/16"]
+	}
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "https open to the internet",
+			source: `
+resource "aws_security_group" "my-group" {
+	ingress {
+		from_port   = 443
+		to_port     = 443
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "egress rule to ssh on the internet",
+			source: `
+resource "aws_security_group_rule" "my-rule" {
+	type        = "egress"
+	from_port   = 22
+	to_port     = 22
+	protocol    = "tcp"
+	cidr_blocks = ["0.0.0.0/0"]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "security group attached to an instance",
+			source: `
+resource "aws_security_group" "my-group" {
+	ingress {
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.my-group.id]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "security group rule for a group attached to an instance",
+			source: `
+resource "aws_security_group" "my-group" {
+}
+
+resource "aws_security_group_rule" "my-rule" {
+	type              = "ingress"
+	security_group_id = aws_security_group.my-group.id
+	from_port         = 3389
+	to_port           = 3389
+	protocol          = "tcp"
+	cidr_blocks       = ["0.0.0.0/0"]
+}
+
+resource "aws_instance" "web" {
+	security_groups = [aws_security_group.my-group.name]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -55,14 +55,10 @@ resource "aws_alb_listener" "good_example" {
 		DefaultSeverity: severity.Critical,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 
-			if sslPolicyAttr := resourceBlock.GetAttribute("ssl_policy"); sslPolicyAttr.IsString() {
-				for _, policy := range outdatedSSLPolicies {
-					if sslPolicyAttr.Equals(policy) {
-						set.AddResult().
-							WithDescription("Resource '%s' is using an outdated SSL policy.", resourceBlock.FullName()).
-							WithAttribute(sslPolicyAttr)
-					}
-				}
+			if sslPolicyAttr := resourceBlock.GetAttribute("ssl_policy"); sslPolicyAttr.IsOneOf(outdatedSSLPolicies...) {
+				set.AddResult().
+					WithDescription("Resource '%s' is using an outdated SSL policy.", resourceBlock.FullName()).
+					WithAttribute(sslPolicyAttr)
 			}
 
 		},
@@ -40,6 +40,15 @@ resource "aws_lb_listener" "my-resource" {
 resource "aws_alb_listener" "my-resource" {
 	ssl_policy = "ELBSecurityPolicy-TLS-1-2-2017-01"
 	protocol = "HTTPS"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check aws_alb_listener with outdated policy in a different case",
+			source: `
+resource "aws_alb_listener" "my-resource" {
+	ssl_policy = "elbsecuritypolicy-tls-1-1-2017-01"
+	protocol = "HTTPS"
 }`,
 			mustExcludeResultCode: expectedCode,
 		},
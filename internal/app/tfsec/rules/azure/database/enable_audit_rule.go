@@ -37,6 +37,22 @@ resource "azurerm_sql_server" "bad_example" {
   administrator_login          = "mradministrator"
   administrator_login_password = "tfsecRocks"
 }
+`, `
+resource "azurerm_mssql_server" "bad_example" {
+  name                         = "mssqlserver"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = azurerm_resource_group.example.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "tfsecRocks"
+}
+
+resource "azurerm_mssql_server_extended_auditing_policy" "bad_example" {
+  server_id         = azurerm_mssql_server.bad_example.id
+  storage_endpoint  = azurerm_storage_account.example.primary_blob_endpoint
+  retention_in_days = 6
+  enabled           = false
+}
 `},
 			GoodExample: []string{`
 resource "azurerm_sql_server" "good_example" {
@@ -54,9 +70,26 @@ resource "azurerm_sql_server" "good_example" {
     retention_in_days                       = 6
   }
 }
+`, `
+resource "azurerm_mssql_server" "good_example" {
+  name                         = "mssqlserver"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = azurerm_resource_group.example.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "tfsecRocks"
+}
+
+resource "azurerm_mssql_server_extended_auditing_policy" "good_example" {
+  server_id                  = azurerm_mssql_server.good_example.id
+  storage_endpoint           = azurerm_storage_account.example.primary_blob_endpoint
+  storage_account_access_key = azurerm_storage_account.example.primary_access_key
+  retention_in_days          = 6
+}
 `},
 			Links: []string{
 				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/sql_server#extended_auditing_policy",
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/mssql_server_extended_auditing_policy",
 				"https://docs.microsoft.com/en-us/azure/azure-sql/database/auditing-overview",
 			},
 		},
@@ -76,12 +109,16 @@ resource "azurerm_sql_server" "good_example" {
 				return
 			}
 
-			if len(blocks) > 0 {
-				return
+			for _, policyBlock := range blocks {
+				// a policy which is disabled doesn't audit the server
+				if !policyBlock.GetAttribute("enabled").IsFalse() {
+					return
+				}
 			}
 
 			set.AddResult().
-				WithDescription("Resource '%s' does not have an extended audit policy configured.", resourceBlock.FullName())
+				WithDescription("Resource '%s' does not have an extended audit policy configured.", resourceBlock.FullName()).
+				WithBlock(resourceBlock)
 
 		},
 	})
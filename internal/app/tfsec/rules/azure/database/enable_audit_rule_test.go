@@ -124,6 +124,56 @@ resource "azurerm_mssql_server" "good_example" {
 			  }`,
 			mustExcludeResultCode: expectedCode,
 		},
+		{
+			name: "check fails when the separate extended auditing policy is disabled",
+			source: `
+resource "azurerm_mssql_server" "bad_example" {
+  name                         = "mssqlserver"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = azurerm_resource_group.example.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "tfsecRocks"
+}
+
+resource "azurerm_mssql_server_extended_auditing_policy" "bad_example" {
+  server_id         = azurerm_mssql_server.bad_example.id
+  storage_endpoint  = azurerm_storage_account.example.primary_blob_endpoint
+  retention_in_days = 6
+  enabled           = false
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when the separate extended auditing policy belongs to another server",
+			source: `
+resource "azurerm_mssql_server" "bad_example" {
+  name                         = "mssqlserver"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = azurerm_resource_group.example.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "tfsecRocks"
+}
+
+resource "azurerm_mssql_server" "audited" {
+  name                         = "auditedserver"
+  resource_group_name          = azurerm_resource_group.example.name
+  location                     = azurerm_resource_group.example.location
+  version                      = "12.0"
+  administrator_login          = "mradministrator"
+  administrator_login_password = "tfsecRocks"
+}
+
+resource "azurerm_mssql_server_extended_auditing_policy" "audited" {
+  server_id         = azurerm_mssql_server.audited.id
+  storage_endpoint  = azurerm_storage_account.example.primary_blob_endpoint
+  retention_in_days = 6
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,138 @@
+package network
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AzureProvider,
+		Service:   "network",
+		ShortCode: "no-public-sensitive-ports",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Network security rules should not expose management or database ports to the internet",
+			Explanation: `Management ports such as SSH (22) and RDP (3389), and database ports such as MSSQL (1433) and MySQL (3306), are constantly scanned for and attacked from the internet. Access to them should be limited to known addresses, or provided through Azure Bastion or a VPN. The ports which are checked can be changed with sensitive_ports in the config file.`,
+			Impact:      "Management and database services can be attacked from anywhere on the internet",
+			Resolution:  "Restrict the source address of inbound rules for sensitive ports to known addresses",
+			BadExample: []string{`
+resource "azurerm_network_security_rule" "bad_example" {
+  name                        = "ssh"
+  direction                   = "Inbound"
+  access                      = "Allow"
+  protocol                    = "Tcp"
+  source_port_range           = "*"
+  destination_port_range      = "22"
+  source_address_prefix       = "Internet"
+  destination_address_prefix  = "*"
+  resource_group_name         = azurerm_resource_group.example.name
+  network_security_group_name = azurerm_network_security_group.example.name
+}
+`, `
+resource "azurerm_network_security_group" "bad_example" {
+  name                = "example"
+  location            = azurerm_resource_group.example.location
+  resource_group_name = azurerm_resource_group.example.name
+
+  security_rule {
+    name                       = "databases"
+    priority                   = 100
+    direction                  = "Inbound"
+    access                     = "Allow"
+    protocol                   = "Tcp"
+    source_port_range          = "*"
+    destination_port_ranges    = ["1433", "3000-4000"]
+    source_address_prefix      = "*"
+    destination_address_prefix = "*"
+  }
+}
+`},
+			GoodExample: []string{`
+resource "azurerm_network_security_rule" "good_example" {
+  name                        = "ssh"
+  direction                   = "Inbound"
+  access                      = "Allow"
+  protocol                    = "Tcp"
+  source_port_range           = "*"
+  destination_port_range      = "22"
+  source_address_prefix       = "10.0.0.0/16"
+  destination_address_prefix  = "*"
+  resource_group_name         = azurerm_resource_group.example.name
+  network_security_group_name = azurerm_network_security_group.example.name
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/network_security_rule",
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/network_security_group#security_rule",
+				"https://docs.microsoft.com/en-us/azure/virtual-network/network-security-groups-overview#service-tags",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"azurerm_network_security_group",
+			"azurerm_network_security_rule",
+		},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			securityRules := block.Blocks{resourceBlock}
+			if resourceBlock.IsResourceType("azurerm_network_security_group") {
+				securityRules = resourceBlock.GetBlocks("security_rule")
+			}
+
+			for _, securityRule := range securityRules {
+				if securityRule.GetAttribute("direction").NotEqual("Inbound", block.IgnoreCase) ||
+					securityRule.GetAttribute("access").NotEqual("Allow", block.IgnoreCase) ||
+					securityRule.GetAttribute("protocol").Equals("Icmp", block.IgnoreCase) {
+					continue
+				}
+				portRangeAttr := securityRule.GetAttribute("destination_port_range")
+				portRangesAttr := securityRule.GetAttribute("destination_port_ranges")
+				portRanges := append(ports.RangesFromAttribute(portRangeAttr), ports.RangesFromAttribute(portRangesAttr)...)
+				for _, name := range []string{"source_address_prefix", "source_address_prefixes"} {
+					if sourceAttr := securityRule.GetAttribute(name); isInternetSource(sourceAttr) {
+						ports.ReportExposure(set, resourceBlock, sourceAttr, portRanges, portRangeAttr, portRangesAttr)
+					}
+				}
+			}
+		},
+	})
+}
+
+// isInternetSource checks for a source address which allows any address, either as a CIDR or as a service tag
+func isInternetSource(sourceAttr block.Attribute) bool {
+	if sourceAttr.IsNil() {
+		return false
+	}
+	if cidr.IsAttributeOpen(sourceAttr) {
+		return true
+	}
+	val := sourceAttr.Value()
+	if val == cty.NilVal || val.IsNull() || !val.IsKnown() {
+		return false
+	}
+	values := []cty.Value{val}
+	if val.Type().IsListType() || val.Type().IsSetType() || val.Type().IsTupleType() {
+		values = val.AsValueSlice()
+	}
+	for _, value := range values {
+		if value.IsNull() || !value.IsKnown() || value.Type() != cty.String {
+			continue
+		}
+		switch strings.ToLower(value.AsString()) {
+		case "internet", "any", "0.0.0.0":
+			return true
+		}
+	}
+	return false
+}
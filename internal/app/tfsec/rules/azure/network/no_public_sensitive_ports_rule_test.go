@@ -0,0 +1,100 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_AzureNoPublicSensitivePorts(t *testing.T) {
+	expectedCode := "azure-network-no-public-sensitive-ports"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "ssh open to the internet service tag",
+			source: `
+resource "azurerm_network_security_rule" "my-rule" {
+	direction               = "Inbound"
+	access                  = "Allow"
+	protocol                = "Tcp"
+	destination_port_range  = "22"
+	source_address_prefix   = "Internet"
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "port range covering a database port open to any address in a security group",
+			source: `
+resource "azurerm_network_security_group" "my-group" {
+	security_rule {
+		direction               = "Inbound"
+		access                  = "Allow"
+		protocol                = "Tcp"
+		destination_port_ranges = ["443", "1000-2000"]
+		source_address_prefix   = "*"
+	}
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "all ports open to 0.0.0.0/0 in a list of prefixes",
+			source: `
+resource "azurerm_network_security_rule" "my-rule" {
+	direction               = "Inbound"
+	access                  = "Allow"
+	protocol                = "*"
+	destination_port_range  = "*"
+	source_address_prefixes = ["10.0.0.0/8", "0.0.0.0/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "ssh open to a known address",
+			source: `
+resource "azurerm_network_security_rule" "my-rule" {
+	direction               = "Inbound"
+	access                  = "Allow"
+	protocol                = "Tcp"
+	destination_port_range  = "22"
+	source_address_prefix   = "82.102.23.23"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "ssh from the internet denied",
+			source: `
+resource "azurerm_network_security_rule" "my-rule" {
+	direction               = "Inbound"
+	access                  = "Deny"
+	protocol                = "Tcp"
+	destination_port_range  = "22"
+	source_address_prefix   = "*"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "https open to the internet",
+			source: `
+resource "azurerm_network_security_rule" "my-rule" {
+	direction               = "Inbound"
+	access                  = "Allow"
+	protocol                = "Tcp"
+	destination_port_range  = "443"
+	source_address_prefix   = "*"
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -66,7 +66,7 @@ resource "azure_storage_container" "good_example" {
 			}
 			properties := resourceBlock.GetAttribute("properties")
 			if properties.Contains("publicAccess") {
-				value := properties.MapValue("publicAccess")
+				value, _ := properties.GetMapValue("publicAccess")
 				if value == cty.StringVal("blob") || value == cty.StringVal("container") {
 					set.AddResult().
 						WithDescription("Resource '%s' defines publicAccess as '%s', should be 'off .", resourceBlock.FullName(), value).WithAttribute(properties)
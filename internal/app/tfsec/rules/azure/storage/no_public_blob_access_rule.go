@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.AzureProvider,
+		Service:   "storage",
+		ShortCode: "no-public-blob-access",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Storage accounts should not allow public access to blobs",
+			Explanation: `When a storage account allows public access, any container in it can be configured for anonymous read access to its blobs, and a single misconfigured container exposes its data to the internet. Disallowing public access at the account level overrides the access level of every container.
+
+Version 3 of the azurerm provider calls this setting allow_nested_items_to_be_public and allows public access unless it is set to false. Earlier versions call it allow_blob_public_access and disallow public access by default. As the default depends on the provider version, accounts which don't set either attribute are reported with INFO severity so the setting can be made explicit.`,
+			Impact:     "Data in the storage account could be exposed publicly",
+			Resolution: "Set allow_nested_items_to_be_public to false",
+			BadExample: []string{`
+resource "azurerm_storage_account" "bad_example" {
+  name                            = "storageaccountname"
+  resource_group_name             = azurerm_resource_group.example.name
+  location                        = azurerm_resource_group.example.location
+  account_tier                    = "Standard"
+  account_replication_type        = "GRS"
+  allow_nested_items_to_be_public = true
+}
+`, `
+resource "azurerm_storage_account" "bad_example" {
+  name                     = "storageaccountname"
+  resource_group_name      = azurerm_resource_group.example.name
+  location                 = azurerm_resource_group.example.location
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+  allow_blob_public_access = true
+}
+`},
+			GoodExample: []string{`
+resource "azurerm_storage_account" "good_example" {
+  name                            = "storageaccountname"
+  resource_group_name             = azurerm_resource_group.example.name
+  location                        = azurerm_resource_group.example.location
+  account_tier                    = "Standard"
+  account_replication_type        = "GRS"
+  allow_nested_items_to_be_public = false
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/resources/storage_account#allow_nested_items_to_be_public",
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/guides/3.0-upgrade-guide",
+				"https://docs.microsoft.com/en-us/azure/storage/blobs/anonymous-read-access-prevent",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"azurerm_storage_account",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			publicAttr := resourceBlock.GetAttribute("allow_nested_items_to_be_public")
+			if publicAttr.IsNil() {
+				publicAttr = resourceBlock.GetAttribute("allow_blob_public_access")
+			}
+
+			if publicAttr.IsNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not set allow_nested_items_to_be_public, so whether public blob access is allowed depends on the provider version.", resourceBlock.FullName()).
+					WithBlock(resourceBlock).
+					WithSeverity(severity.Info)
+				return
+			}
+
+			if publicAttr.IsTrue() {
+				set.AddResult().
+					WithDescription("Resource '%s' allows public access to blobs.", resourceBlock.FullName()).
+					WithAttribute(publicAttr)
+			}
+		},
+	})
+}
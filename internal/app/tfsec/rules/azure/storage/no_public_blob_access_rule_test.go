@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AzureStorageNoPublicBlobAccess(t *testing.T) {
+	expectedCode := "azure-storage-no-public-blob-access"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "public access allowed with allow_nested_items_to_be_public",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	allow_nested_items_to_be_public = true
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public access allowed with the older allow_blob_public_access",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	allow_blob_public_access = true
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public access not set",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "public access disallowed",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	allow_nested_items_to_be_public = false
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "public access disallowed with the older allow_blob_public_access",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	allow_blob_public_access = false
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_AzureStorageNoPublicBlobAccessSeverity(t *testing.T) {
+	expectedCode := "azure-storage-no-public-blob-access"
+
+	var tests = []struct {
+		name     string
+		source   string
+		severity severity.Severity
+		line     int
+	}{
+		{
+			name: "explicitly allowed is high severity and annotates the attribute",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	name = "example"
+	allow_blob_public_access = true
+}`,
+			severity: severity.High,
+			line:     4,
+		},
+		{
+			name: "unset is informational as the default depends on the provider version",
+			source: `
+resource "azurerm_storage_account" "my-account" {
+	name = "example"
+}`,
+			severity: severity.Info,
+			line:     2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var found bool
+			for _, res := range testutil.ScanHCL(test.source, t) {
+				if res.RuleID != expectedCode {
+					continue
+				}
+				found = true
+				assert.Equal(t, test.severity, res.Severity)
+				assert.Equal(t, test.line, res.Range().StartLine)
+			}
+			require.True(t, found)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.DigitalOceanProvider,
+		Service:   "database",
+		ShortCode: "no-public-access",
+		Documentation: rule.RuleDocumentation{
+			Summary: "Database clusters should only accept connections from trusted sources",
+			Explanation: `
+Managed database clusters accept connections from any address until a database firewall restricts them to trusted sources. Trusted sources should be limited to the droplets, tags, apps or addresses which need access.
+`,
+			Impact:     "The database is reachable from the public internet",
+			Resolution: "Restrict connections to trusted sources with a database firewall",
+			BadExample: []string{`
+resource "digitalocean_database_cluster" "bad_example" {
+  name       = "example-postgres-cluster"
+  engine     = "pg"
+  version    = "11"
+  size       = "db-s-1vcpu-1gb"
+  region     = "nyc1"
+  node_count = 1
+}
+`, `
+resource "digitalocean_database_cluster" "bad_example" {
+  name       = "example-postgres-cluster"
+  engine     = "pg"
+  version    = "11"
+  size       = "db-s-1vcpu-1gb"
+  region     = "nyc1"
+  node_count = 1
+}
+
+resource "digitalocean_database_firewall" "bad_example" {
+  cluster_id = digitalocean_database_cluster.bad_example.id
+
+  rule {
+    type  = "ip_addr"
+    value = "0.0.0.0/0"
+  }
+}
+`},
+			GoodExample: []string{`
+resource "digitalocean_database_cluster" "good_example" {
+  name       = "example-postgres-cluster"
+  engine     = "pg"
+  version    = "11"
+  size       = "db-s-1vcpu-1gb"
+  region     = "nyc1"
+  node_count = 1
+}
+
+resource "digitalocean_database_firewall" "good_example" {
+  cluster_id = digitalocean_database_cluster.good_example.id
+
+  rule {
+    type  = "ip_addr"
+    value = "192.168.1.1"
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/database_firewall",
+				"https://docs.digitalocean.com/products/databases/postgresql/how-to/secure/",
+			},
+		},
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"digitalocean_database_cluster"},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+
+			firewalls, err := module.GetReferencingResources(resourceBlock, "digitalocean_database_firewall", "cluster_id")
+			if err != nil || len(firewalls) == 0 {
+				set.AddResult().
+					WithDescription("Resource '%s' does not restrict connections with a database firewall", resourceBlock.FullName())
+				return
+			}
+
+			for _, firewall := range firewalls {
+				for _, ruleBlock := range firewall.GetBlocks("rule") {
+					if !ruleBlock.GetAttribute("type").Equals("ip_addr") {
+						continue
+					}
+					if valueAttr := ruleBlock.GetAttribute("value"); cidr.IsAttributeOpen(valueAttr) {
+						set.AddResult().
+							WithDescription("Resource '%s' allows connections from the public internet", resourceBlock.FullName()).
+							WithAttribute(valueAttr)
+					}
+				}
+			}
+		},
+	})
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_DIGDatabaseNoPublicAccess(t *testing.T) {
+	expectedCode := "digitalocean-database-no-public-access"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "cluster without a firewall fails check",
+			source: `
+resource "digitalocean_database_cluster" "example" {
+  name   = "example"
+  engine = "pg"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "cluster with a firewall open to the internet fails check",
+			source: `
+resource "digitalocean_database_cluster" "example" {
+  name   = "example"
+  engine = "pg"
+}
+
+resource "digitalocean_database_firewall" "example" {
+  cluster_id = digitalocean_database_cluster.example.id
+
+  rule {
+    type  = "ip_addr"
+    value = "0.0.0.0/0"
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "cluster with a firewall for a droplet passes check",
+			source: `
+resource "digitalocean_database_cluster" "example" {
+  name   = "example"
+  engine = "pg"
+}
+
+resource "digitalocean_database_firewall" "example" {
+  cluster_id = digitalocean_database_cluster.example.id
+
+  rule {
+    type  = "droplet"
+    value = "12345"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "cluster with a firewall for a single address passes check",
+			source: `
+resource "digitalocean_database_cluster" "example" {
+  name   = "example"
+  engine = "pg"
+}
+
+resource "digitalocean_database_firewall" "example" {
+  cluster_id = digitalocean_database_cluster.example.id
+
+  rule {
+    type  = "ip_addr"
+    value = "192.168.1.1"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package droplet
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.DigitalOceanProvider,
+		Service:   "droplet",
+		ShortCode: "use-firewall",
+		Documentation: rule.RuleDocumentation{
+			Summary: "Droplets should be protected by a cloud firewall",
+			Explanation: `
+Droplets accept traffic on every port from anywhere unless a cloud firewall is applied to them. Firewalls can be applied directly by listing the droplet in droplet_ids, or indirectly by sharing a tag with the droplet.
+`,
+			Impact:     "All services running on the droplet are exposed to the internet",
+			Resolution: "Apply a cloud firewall to the droplet by ID or by tag",
+			BadExample: []string{`
+resource "digitalocean_droplet" "bad_example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+}
+`},
+			GoodExample: []string{`
+resource "digitalocean_droplet" "good_example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+}
+
+resource "digitalocean_firewall" "web" {
+  name        = "only-22-and-443"
+  droplet_ids = [digitalocean_droplet.good_example.id]
+
+  inbound_rule {
+    protocol         = "tcp"
+    port_range       = "443"
+    source_addresses = ["192.168.1.0/24"]
+  }
+}
+`, `
+resource "digitalocean_tag" "web" {
+  name = "web"
+}
+
+resource "digitalocean_droplet" "good_example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+  tags   = [digitalocean_tag.web.id]
+}
+
+resource "digitalocean_firewall" "web" {
+  name = "only-443"
+  tags = [digitalocean_tag.web.id]
+
+  inbound_rule {
+    protocol         = "tcp"
+    port_range       = "443"
+    source_addresses = ["192.168.1.0/24"]
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/firewall",
+				"https://docs.digitalocean.com/products/networking/firewalls/",
+			},
+		},
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"digitalocean_droplet"},
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+
+			if firewalls, err := module.GetReferencingResources(resourceBlock, "digitalocean_firewall", "droplet_ids"); err == nil && len(firewalls) > 0 {
+				return
+			}
+
+			if tagsAttr := resourceBlock.GetAttribute("tags"); tagsAttr.IsNotNil() {
+				tagBlocks := module.GetReferencedBlocks(tagsAttr)
+				for _, firewall := range module.GetResourcesByType("digitalocean_firewall") {
+					if sharesTag(tagsAttr, tagBlocks, firewall.GetAttribute("tags")) {
+						return
+					}
+				}
+			}
+
+			set.AddResult().
+				WithDescription("Resource '%s' is not protected by a firewall", resourceBlock.FullName())
+		},
+	})
+}
+
+// sharesTag reports whether the firewall tags include one of the droplet tags, either by value or by referencing the same tag resource
+func sharesTag(dropletTags block.Attribute, tagBlocks block.Blocks, firewallTags block.Attribute) bool {
+	if firewallTags.IsNil() {
+		return false
+	}
+	for _, tag := range dropletTags.ValueAsStrings() {
+		if firewallTags.Contains(tag) {
+			return true
+		}
+	}
+	for _, tagBlock := range tagBlocks {
+		if firewallTags.ReferencesBlock(tagBlock) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package droplet
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_DIGDropletUseFirewall(t *testing.T) {
+	expectedCode := "digitalocean-droplet-use-firewall"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "droplet without a firewall fails check",
+			source: `
+resource "digitalocean_droplet" "example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "droplet with a firewall for a different tag fails check",
+			source: `
+resource "digitalocean_droplet" "example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+  tags   = ["web"]
+}
+
+resource "digitalocean_firewall" "example" {
+  name = "db"
+  tags = ["db"]
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "droplet attached to a firewall by id passes check",
+			source: `
+resource "digitalocean_droplet" "example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+}
+
+resource "digitalocean_firewall" "example" {
+  name        = "web"
+  droplet_ids = [digitalocean_droplet.example.id]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "droplet sharing a literal tag with a firewall passes check",
+			source: `
+resource "digitalocean_droplet" "example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+  tags   = ["web"]
+}
+
+resource "digitalocean_firewall" "example" {
+  name = "web"
+  tags = ["web"]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "droplet sharing a tag resource with a firewall passes check",
+			source: `
+resource "digitalocean_tag" "web" {
+  name = "web"
+}
+
+resource "digitalocean_droplet" "example" {
+  image  = "ubuntu-18-04-x64"
+  name   = "web-1"
+  region = "nyc2"
+  size   = "s-1vcpu-1gb"
+  tags   = [digitalocean_tag.web.id]
+}
+
+resource "digitalocean_firewall" "example" {
+  name = "web"
+  tags = [digitalocean_tag.web.id]
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package spaces
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/iam"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.DigitalOceanProvider,
+		Service:   "spaces",
+		ShortCode: "no-public-listing",
+		Documentation: rule.RuleDocumentation{
+			Summary: "Spaces bucket policies should not allow anyone to list the bucket contents",
+			Explanation: `
+A bucket policy which grants s3:ListBucket to every principal allows anyone to enumerate the objects in the bucket, even if the objects themselves are private. Listing should be limited to known principals.
+`,
+			Impact:     "Anyone can enumerate the contents of the bucket",
+			Resolution: "Remove the public principal from statements which allow listing",
+			BadExample: []string{`
+resource "digitalocean_spaces_bucket" "bad_example" {
+  name   = "foobar"
+  region = "nyc3"
+}
+
+resource "digitalocean_spaces_bucket_policy" "bad_example" {
+  region = digitalocean_spaces_bucket.bad_example.region
+  bucket = digitalocean_spaces_bucket.bad_example.name
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicList"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:ListBucket"
+        Resource  = "arn:aws:s3:::foobar"
+      },
+    ]
+  })
+}
+`},
+			GoodExample: []string{`
+resource "digitalocean_spaces_bucket" "good_example" {
+  name   = "foobar"
+  region = "nyc3"
+}
+
+resource "digitalocean_spaces_bucket_policy" "good_example" {
+  region = digitalocean_spaces_bucket.good_example.region
+  bucket = digitalocean_spaces_bucket.good_example.name
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Sid       = "PublicRead"
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3:::foobar/*"
+      },
+    ]
+  })
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/digitalocean/digitalocean/latest/docs/resources/spaces_bucket_policy",
+				"https://docs.digitalocean.com/reference/api/spaces-api/#bucket-policies",
+			},
+		},
+		RequiredTypes:   []string{"resource"},
+		RequiredLabels:  []string{"digitalocean_spaces_bucket_policy"},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+
+			policyAttr := resourceBlock.GetAttribute("policy")
+			if policyAttr.IsNil() || !policyAttr.IsString() {
+				return
+			}
+
+			var document iam.PolicyDocument
+			if err := json.Unmarshal([]byte(policyAttr.Value().AsString()), &document); err != nil {
+				debug.Log("Error decoding bucket policy JSON at %s: %s", policyAttr.Range(), err)
+				return
+			}
+
+			for _, statement := range document.Statements {
+				if !strings.EqualFold(statement.Effect, "allow") || !allowsListing(statement.Action) {
+					continue
+				}
+				for _, principal := range statement.Principal.AWS {
+					if principal == "*" {
+						set.AddResult().
+							WithDescription("Resource '%s' allows anyone to list the bucket contents", resourceBlock.FullName()).
+							WithAttribute(policyAttr)
+						return
+					}
+				}
+			}
+		},
+	})
+}
+
+func allowsListing(actions []string) bool {
+	for _, action := range actions {
+		switch strings.ToLower(action) {
+		case "*", "s3:*", "s3:list*", "s3:listbucket":
+			return true
+		}
+	}
+	return false
+}
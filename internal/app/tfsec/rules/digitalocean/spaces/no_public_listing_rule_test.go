@@ -0,0 +1,109 @@
+package spaces
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_DIGSpacesNoPublicListing(t *testing.T) {
+	expectedCode := "digitalocean-spaces-no-public-listing"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "policy allowing anyone to list the bucket fails check",
+			source: `
+resource "digitalocean_spaces_bucket_policy" "example" {
+  region = "nyc3"
+  bucket = "foobar"
+  policy = <<EOF
+{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Effect": "Allow",
+      "Principal": "*",
+      "Action": ["s3:GetObject", "s3:ListBucket"],
+      "Resource": "arn:aws:s3:::foobar"
+    }
+  ]
+}
+EOF
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "policy allowing anyone all actions fails check",
+			source: `
+resource "digitalocean_spaces_bucket_policy" "example" {
+  region = "nyc3"
+  bucket = "foobar"
+  policy = jsonencode({
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:*"
+        Resource  = "arn:aws:s3:::foobar"
+      },
+    ]
+  })
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "policy denying public listing passes check",
+			source: `
+resource "digitalocean_spaces_bucket_policy" "example" {
+  region = "nyc3"
+  bucket = "foobar"
+  policy = jsonencode({
+    Statement = [
+      {
+        Effect    = "Deny"
+        Principal = "*"
+        Action    = "s3:ListBucket"
+        Resource  = "arn:aws:s3:::foobar"
+      },
+    ]
+  })
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "policy allowing public reads only passes check",
+			source: `
+resource "digitalocean_spaces_bucket_policy" "example" {
+  region = "nyc3"
+  bucket = "foobar"
+  policy = jsonencode({
+    Statement = [
+      {
+        Effect    = "Allow"
+        Principal = "*"
+        Action    = "s3:GetObject"
+        Resource  = "arn:aws:s3:::foobar/*"
+      },
+    ]
+  })
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
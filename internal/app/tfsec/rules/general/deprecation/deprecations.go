@@ -0,0 +1,52 @@
+package deprecation
+
+import (
+	"sort"
+)
+
+// deprecation describes a resource type, or an argument of one, which a provider has deprecated. An empty argument
+// means the whole resource type is deprecated. Arguments may be attributes or nested blocks.
+type deprecation struct {
+	resourceType string
+	argument     string
+	replacement  string
+}
+
+// deprecations is the list of deprecated usage to report. Add entries here as providers evolve.
+var deprecations = []deprecation{
+	// aws provider v4 also moved most of the configuration of aws_s3_bucket into separate resources, but those
+	// arguments aren't listed while the s3 checks only understand the inline configuration
+	{resourceType: "aws_s3_bucket_object", replacement: "the aws_s3_object resource"},
+	{resourceType: "aws_db_instance", argument: "name", replacement: "db_name"},
+	{resourceType: "aws_elasticache_replication_group", argument: "replication_group_description", replacement: "description"},
+	{resourceType: "aws_elasticache_replication_group", argument: "number_cache_clusters", replacement: "num_cache_clusters"},
+	{resourceType: "aws_elasticache_replication_group", argument: "cluster_mode", replacement: "num_node_groups and replicas_per_node_group"},
+
+	// azurerm provider v3 replaced these with OS or engine specific resources
+	{resourceType: "azurerm_virtual_machine", replacement: "the azurerm_linux_virtual_machine or azurerm_windows_virtual_machine resource"},
+	{resourceType: "azurerm_virtual_machine_scale_set", replacement: "the azurerm_linux_virtual_machine_scale_set or azurerm_windows_virtual_machine_scale_set resource"},
+	{resourceType: "azurerm_app_service", replacement: "the azurerm_linux_web_app or azurerm_windows_web_app resource"},
+	{resourceType: "azurerm_app_service_plan", replacement: "the azurerm_service_plan resource"},
+	{resourceType: "azurerm_function_app", replacement: "the azurerm_linux_function_app or azurerm_windows_function_app resource"},
+	{resourceType: "azurerm_sql_server", replacement: "the azurerm_mssql_server resource"},
+	{resourceType: "azurerm_sql_database", replacement: "the azurerm_mssql_database resource"},
+	{resourceType: "azurerm_sql_firewall_rule", replacement: "the azurerm_mssql_firewall_rule resource"},
+
+	// google provider
+	{resourceType: "google_container_cluster", argument: "enable_binary_authorization", replacement: "the binary_authorization block"},
+}
+
+// deprecatedResourceTypes lists each resource type with any deprecated usage, for the rule's required labels
+func deprecatedResourceTypes() []string {
+	seen := make(map[string]struct{})
+	var types []string
+	for _, d := range deprecations {
+		if _, ok := seen[d.resourceType]; ok {
+			continue
+		}
+		seen[d.resourceType] = struct{}{}
+		types = append(types, d.resourceType)
+	}
+	sort.Strings(types)
+	return types
+}
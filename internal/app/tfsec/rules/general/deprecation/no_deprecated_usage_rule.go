@@ -0,0 +1,81 @@
+package deprecation
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.GeneralProvider,
+		Service:   "deprecation",
+		ShortCode: "no-deprecated-usage",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Resource types and arguments deprecated by their provider should not be used",
+			Explanation: `Providers deprecate resource types and arguments when they are replaced, and remove them in a later major version. Configuration which uses them will stop working when the provider is upgraded, and checks written for the replacement may not apply to it in the meantime.`,
+			Impact:      "The configuration will break when the provider removes the deprecated feature",
+			Resolution:  "Move to the replacement named in the result",
+			BadExample: []string{`
+resource "aws_db_instance" "bad_example" {
+  engine = "mysql"
+  name   = "mydb"
+}
+`, `
+resource "azurerm_sql_server" "bad_example" {
+  name                = "example-sqlserver"
+  resource_group_name = "example-resources"
+  location            = "westeurope"
+  version             = "12.0"
+}
+`},
+			GoodExample: []string{`
+resource "aws_db_instance" "good_example" {
+  engine  = "mysql"
+  db_name = "mydb"
+}
+`, `
+resource "azurerm_mssql_server" "good_example" {
+  name                = "example-sqlserver"
+  resource_group_name = "example-resources"
+  location            = "westeurope"
+  version             = "12.0"
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/aws/latest/docs/guides/version-4-upgrade",
+				"https://registry.terraform.io/providers/hashicorp/azurerm/latest/docs/guides/3.0-upgrade-guide",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels:  deprecatedResourceTypes(),
+		DefaultSeverity: severity.Info,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			for _, d := range deprecations {
+				if d.resourceType != resourceBlock.TypeLabel() {
+					continue
+				}
+				if d.argument == "" {
+					set.AddResult().
+						WithDescription("Resource '%s' uses the deprecated resource type '%s', use %s instead", resourceBlock.FullName(), d.resourceType, d.replacement)
+					continue
+				}
+				if resourceBlock.MissingChild(d.argument) {
+					continue
+				}
+				res := set.AddResult().
+					WithDescription("Resource '%s' uses the deprecated argument '%s', use %s instead", resourceBlock.FullName(), d.argument, d.replacement)
+				if attr := resourceBlock.GetAttribute(d.argument); attr.IsNotNil() {
+					res.WithAttribute(attr)
+				} else {
+					res.WithBlock(resourceBlock.GetBlock(d.argument))
+				}
+			}
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package deprecation
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NoDeprecatedUsage(t *testing.T) {
+	expectedCode := "general-deprecation-no-deprecated-usage"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check deprecated attribute is reported",
+			source: `
+resource "aws_db_instance" "db" {
+  engine = "mysql"
+  name   = "mydb"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check deprecated nested block is reported",
+			source: `
+resource "aws_elasticache_replication_group" "group" {
+  description = "example"
+
+  cluster_mode {
+    num_node_groups         = 2
+    replicas_per_node_group = 1
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check deprecated resource type is reported",
+			source: `
+resource "azurerm_sql_server" "server" {
+  name    = "example-sqlserver"
+  version = "12.0"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check resource without deprecated arguments is not reported",
+			source: `
+resource "aws_db_instance" "db" {
+  engine  = "mysql"
+  db_name = "mydb"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check inline s3 bucket configuration which the s3 checks rely on is not reported",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+  bucket = "my-bucket"
+  acl    = "private"
+
+  versioning {
+    enabled = true
+  }
+
+  server_side_encryption_configuration {
+    rule {
+      apply_server_side_encryption_by_default {
+        sse_algorithm = "aws:kms"
+      }
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check replacement resource type is not reported",
+			source: `
+resource "azurerm_mssql_server" "server" {
+  name    = "example-sqlserver"
+  version = "12.0"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_NoDeprecatedUsageNamesReplacement(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_db_instance" "db" {
+  name = "mydb"
+}
+`, t)
+
+	var found bool
+	for _, res := range results {
+		if res.RuleID != "general-deprecation-no-deprecated-usage" {
+			continue
+		}
+		found = true
+		assert.Equal(t, "Resource 'aws_db_instance.db' uses the deprecated argument 'name', use db_name instead", res.Description)
+		assert.Equal(t, 3, res.Range().StartLine)
+	}
+	assert.True(t, found)
+}
+
+func Test_DeprecationsAreWellFormed(t *testing.T) {
+	for _, d := range deprecations {
+		assert.NotEmpty(t, d.resourceType)
+		assert.NotEmpty(t, d.replacement, d.resourceType)
+	}
+}
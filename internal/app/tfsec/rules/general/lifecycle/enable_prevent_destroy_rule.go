@@ -0,0 +1,83 @@
+package lifecycle
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.GeneralProvider,
+		Service:   "lifecycle",
+		ShortCode: "enable-prevent-destroy",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Resources holding data which can't be recreated should set prevent_destroy",
+			Explanation: `With prevent_destroy set in its lifecycle block, Terraform refuses any plan which would destroy the resource, including a replacement forced by changing an argument. This catches the mistake before anything is applied, whatever deletion protection the provider offers.
+
+The check applies to the resource types listed in protected_resource_types in the config file. By default these are aws_db_instance, aws_dynamodb_table and aws_rds_cluster.`,
+			Impact:     "A plan could destroy the resource and its data",
+			Resolution: "Set prevent_destroy to true in the lifecycle block of the resource",
+			BadExample: []string{`
+resource "aws_db_instance" "bad_example" {
+  allocated_storage   = 10
+  engine              = "mysql"
+  instance_class      = "db.t3.micro"
+  deletion_protection = true
+}
+`, `
+resource "aws_dynamodb_table" "bad_example" {
+  name     = "example"
+  hash_key = "TestTableHashKey"
+
+  lifecycle {
+    prevent_destroy = false
+  }
+}
+`},
+			GoodExample: []string{`
+resource "aws_db_instance" "good_example" {
+  allocated_storage   = 10
+  engine              = "mysql"
+  instance_class      = "db.t3.micro"
+  deletion_protection = true
+
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+`},
+			Links: []string{
+				"https://www.terraform.io/docs/language/meta-arguments/lifecycle.html#prevent_destroy",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if !protection.IsProtected(resourceBlock) {
+				return
+			}
+
+			lifecycleBlock := resourceBlock.GetBlock("lifecycle")
+			preventDestroyAttr := lifecycleBlock.GetAttribute("prevent_destroy")
+			if preventDestroyAttr.IsNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not set prevent_destroy in its lifecycle block.", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
+				return
+			}
+
+			if preventDestroyAttr.IsFalse() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not prevent being destroyed.", resourceBlock.FullName()).
+					WithAttribute(preventDestroyAttr)
+			}
+		},
+	})
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/protection"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_GeneralLifecycleEnablePreventDestroy(t *testing.T) {
+	expectedCode := "general-lifecycle-enable-prevent-destroy"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "protected resource without lifecycle block fails check",
+			source: `
+resource "aws_db_instance" "bad_example" {
+	allocated_storage   = 10
+	deletion_protection = true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "protected resource with lifecycle block but no prevent_destroy fails check",
+			source: `
+resource "aws_rds_cluster" "bad_example" {
+	cluster_identifier = "aurora-cluster-demo"
+
+	lifecycle {
+		ignore_changes = [tags]
+	}
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "protected resource with prevent_destroy disabled fails check",
+			source: `
+resource "aws_dynamodb_table" "bad_example" {
+	name = "example"
+
+	lifecycle {
+		prevent_destroy = false
+	}
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "protected resource with prevent_destroy enabled passes check",
+			source: `
+resource "aws_db_instance" "good_example" {
+	allocated_storage = 10
+
+	lifecycle {
+		prevent_destroy = true
+	}
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "resource which isn't protected passes check",
+			source: `
+resource "aws_s3_bucket" "good_example" {
+	bucket = "my-bucket"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_GeneralLifecycleEnablePreventDestroyChecksConfiguredTypes(t *testing.T) {
+	protection.SetProtectedTypes([]string{"aws_s3_bucket"})
+	defer protection.SetProtectedTypes(nil)
+
+	results := testutil.ScanHCL(`
+resource "aws_s3_bucket" "example" {
+	bucket = "my-bucket"
+}
+
+resource "aws_db_instance" "example" {
+	allocated_storage = 10
+
+	lifecycle {
+		prevent_destroy = false
+	}
+}
+`, t)
+	testutil.AssertCheckCode(t, "general-lifecycle-enable-prevent-destroy", "", results)
+	for _, res := range results {
+		if res.RuleID == "general-lifecycle-enable-prevent-destroy" && res.ResourceName() != "aws_s3_bucket.example" {
+			t.Errorf("unexpected result for %s", res.ResourceName())
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/security"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Service:   "secrets",
+		ShortCode: "no-hardcoded-secrets",
+		Documentation: rule.RuleDocumentation{
+			Summary:    "A literal string in the template looks like a secret",
+			Impact:     "Secrets committed to source control can be read by anyone with access to the repository and its history",
+			Resolution: "Pass secrets in through sensitive variables or read them from a secret store",
+			Explanation: `
+Credentials which are written into templates as literal strings end up in source control, and in plan output and state. Literal strings are reported when they match a known secret format such as an AWS access key ID or private key, when they contain random looking tokens such as API keys, or when they are assigned to an attribute named like a secret, e.g. <code>password</code>.
+
+Values built from variables, locals or other references are not reported, as they are not hardcoded. Top level attributes of resources, providers and modules which are named like a secret or match a known secret format are left to <code>general-secrets-sensitive-in-attribute</code> and <code>general-secrets-sensitive-in-attribute-value</code>, so they are only reported once. The entropy above which tokens are reported can be set with <code>--secret-entropy-threshold</code>.
+`,
+			BadExample: []string{`
+resource "datadog_integration" "bad_example" {
+	config = "key=Zx8fQ2rT9vLm4KpW7sYb3NcH6jGd1Ea5"
+}
+`, `
+resource "aws_instance" "bad_example" {
+	provisioner "remote-exec" {
+		connection {
+			password = "hunter2hunter2"
+		}
+	}
+}
+`},
+			GoodExample: []string{`
+variable "db_password" {
+	type      = string
+	sensitive = true
+}
+
+resource "aws_db_instance" "good_example" {
+	password = var.db_password
+}
+`},
+			Links: []string{
+				"https://www.terraform.io/docs/language/values/variables.html#suppressing-values-in-cli-output",
+			},
+		},
+		Provider:        provider.GeneralProvider,
+		RequiredTypes:   []string{"resource", "data", "provider", "module"},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			for _, b := range resourceBlock.AllBlocksRecursive() {
+			ATTRIBUTES:
+				for _, attribute := range b.GetAttributes() {
+					if !attribute.IsLiteral() || !attribute.IsString() || attribute.Equals("") {
+						continue
+					}
+					for _, whitelisted := range sensitiveWhitelist {
+						if whitelisted.Resource == resourceBlock.TypeLabel() && whitelisted.Attribute == attribute.Name() {
+							continue ATTRIBUTES
+						}
+					}
+
+					value := attribute.Value().AsString()
+					if b == resourceBlock && isReportedAsSensitive(resourceBlock, attribute.Name(), value) {
+						continue
+					}
+					if scanResult := security.StringScanner.Scan(value); scanResult.TransgressionFound {
+						set.AddResult().
+							WithDescription("Block '%s' has a hardcoded secret in '%s'. %s", resourceBlock.FullName(), attribute.Name(), scanResult.Description).
+							WithAttribute(attribute)
+					} else if tokens := security.HighEntropyTokens(value); len(tokens) > 0 {
+						set.AddResult().
+							WithDescription("Block '%s' has a hardcoded random token in '%s' which looks like a secret.", resourceBlock.FullName(), attribute.Name()).
+							WithAttribute(attribute)
+					} else if security.IsSensitiveAttribute(attribute.Name()) {
+						set.AddResult().
+							WithDescription("Block '%s' has a hardcoded value for the sensitive attribute '%s'.", resourceBlock.FullName(), attribute.Name()).
+							WithAttribute(attribute)
+					}
+				}
+			}
+		},
+	})
+}
+
+// isReportedAsSensitive checks whether an attribute at the top level of a block is already reported by
+// general-secrets-sensitive-in-attribute or general-secrets-sensitive-in-attribute-value
+func isReportedAsSensitive(resourceBlock block.Block, name string, value string) bool {
+	switch resourceBlock.Type() {
+	case "resource", "provider", "module":
+	default:
+		return false
+	}
+	return security.IsSensitiveAttribute(name) || security.StringScanner.Scan(value).TransgressionFound
+}
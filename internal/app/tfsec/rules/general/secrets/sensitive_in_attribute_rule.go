@@ -29,6 +29,14 @@ var sensitiveWhitelist = []struct {
 		Resource:  "aws_instance",
 		Attribute: "get_password_data",
 	},
+	{
+		Resource:  "aws_instance",
+		Attribute: "http_tokens",
+	},
+	{
+		Resource:  "aws_launch_template",
+		Attribute: "http_tokens",
+	},
 	{
 		Resource:  "github_actions_secret",
 		Attribute: "secret_name",
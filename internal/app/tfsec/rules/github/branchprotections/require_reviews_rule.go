@@ -0,0 +1,76 @@
+package branchprotections
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.GitHubProvider,
+		Service:   "branch-protections",
+		ShortCode: "require-reviews",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Github branch protection should require pull request reviews.",
+			Explanation: `Without required reviews, changes can be merged into a protected branch without anybody else looking at them. Add a <code>required_pull_request_reviews</code> block which requires at least one approving review.`,
+			Impact:      "Unreviewed changes can be merged into protected branches.",
+			Resolution:  "Require at least one approving review for pull requests to protected branches.",
+			BadExample: []string{`
+resource "github_branch_protection" "bad_example" {
+  repository_id = github_repository.example.node_id
+  pattern       = "main"
+}
+`, `
+resource "github_branch_protection" "bad_example" {
+  repository_id = github_repository.example.node_id
+  pattern       = "main"
+
+  required_pull_request_reviews {
+    required_approving_review_count = 0
+  }
+}
+`},
+			GoodExample: []string{`
+resource "github_branch_protection" "good_example" {
+  repository_id = github_repository.example.node_id
+  pattern       = "main"
+
+  required_pull_request_reviews {
+    required_approving_review_count = 1
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/integrations/github/latest/docs/resources/branch_protection#required_pull_request_reviews",
+				"https://docs.github.com/en/repositories/configuring-branches-and-merges-in-your-repository/defining-the-mergeability-of-pull-requests/about-protected-branches#require-pull-request-reviews-before-merging",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"github_branch_protection",
+			"github_branch_protection_v3",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, module block.Module) {
+			if resourceBlock.MissingChild("required_pull_request_reviews") {
+				set.AddResult().
+					WithDescription("Resource '%s' does not require pull request reviews", resourceBlock.FullName())
+				return
+			}
+
+			reviewsBlock := resourceBlock.GetBlock("required_pull_request_reviews")
+			// the provider requires one approving review by default
+			if countAttr := reviewsBlock.GetAttribute("required_approving_review_count"); countAttr.IsNotNil() && countAttr.LessThan(1) {
+				set.AddResult().
+					WithDescription("Resource '%s' does not require any approving reviews", resourceBlock.FullName()).
+					WithAttribute(countAttr)
+			}
+		},
+	})
+}
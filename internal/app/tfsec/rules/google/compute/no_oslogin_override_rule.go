@@ -80,7 +80,7 @@ resource "google_compute_instance" "default" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 			metadataAttr := resourceBlock.GetAttribute("metadata")
-			val := metadataAttr.MapValue("enable-oslogin")
+			val, _ := metadataAttr.GetMapValue("enable-oslogin")
 			if val.Type() == cty.Bool && val.False() {
 				set.AddResult().
 					WithDescription("Resource'%s' has OS Login disabled at instance-level", resourceBlock).
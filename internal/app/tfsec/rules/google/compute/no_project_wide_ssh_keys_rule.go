@@ -127,7 +127,7 @@ resource "google_compute_instance" "default" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 			metadataAttr := resourceBlock.GetAttribute("metadata")
-			val := metadataAttr.MapValue("block-project-ssh-keys")
+			val, _ := metadataAttr.GetMapValue("block-project-ssh-keys")
 			if val.Type() == cty.NilType {
 				set.AddResult().
 					WithDescription("Resource'%s' allows the use of project-wide SSH keys by default", resourceBlock)
@@ -6,6 +6,7 @@ package compute
 
 import (
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/publicip"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 	"github.com/aquasecurity/tfsec/pkg/provider"
 	"github.com/aquasecurity/tfsec/pkg/result"
@@ -20,9 +21,11 @@ func init() {
 		ShortCode: "no-public-ip",
 		Documentation: rule.RuleDocumentation{
 			Summary:     "Instances should not have public IP addresses",
-			Explanation: `Instances should not be publicly exposed to the internet`,
+			Explanation: `Instances should not be publicly exposed to the internet.
+
+Each access_config block in a network_interface gives the instance an external IP address, which is ephemeral unless nat_ip is set. Instances which need a public IP, such as bastion hosts, can be allowed one by network tag or label with public_ip_allowed_tags or public_ip_allowed_labels in the config file.`,
 			Impact:      "Direct exposure of an instance to the public internet",
-			Resolution:  "Remove public IP",
+			Resolution:  "Remove the access_config blocks from the network interfaces",
 			BadExample: []string{`
 resource "google_compute_instance" "bad_example" {
   name         = "test"
@@ -87,10 +90,15 @@ resource "google_compute_instance" "good_example" {
 		},
 		DefaultSeverity: severity.High,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
-			if accessConfigBlock := resourceBlock.GetBlock("network_interface").GetBlock("access_config"); !accessConfigBlock.IsNil() {
-				set.AddResult().
-					WithDescription("Resource '%s' sets access_config", resourceBlock.FullName()).
-					WithBlock(accessConfigBlock)
+			if publicip.IsAllowed(resourceBlock) {
+				return
+			}
+			for _, networkInterfaceBlock := range resourceBlock.GetBlocks("network_interface") {
+				for _, accessConfigBlock := range networkInterfaceBlock.GetBlocks("access_config") {
+					set.AddResult().
+						WithDescription("Resource '%s' sets access_config", resourceBlock.FullName()).
+						WithBlock(accessConfigBlock)
+				}
 			}
 		},
 	})
@@ -4,8 +4,10 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/publicip"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_GoogleNoPublicIp_FailureExamples(t *testing.T) {
@@ -51,3 +53,203 @@ func Test_GoogleNoPublicIp_SuccessExamples(t *testing.T) {
 		testutil.AssertCheckCode(t, "", rule.ID(), results)
 	}
 }
+
+func Test_GoogleNoPublicIp(t *testing.T) {
+	expectedCode := "google-compute-no-public-ip"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check fails when a later network interface has an access_config",
+			source: `
+resource "google_compute_instance" "bad_example" {
+  name = "test"
+
+  network_interface {
+    network = "internal"
+  }
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when an access_config sets a static IP",
+			source: `
+resource "google_compute_instance" "bad_example" {
+  name = "test"
+
+  network_interface {
+    network = "default"
+
+    access_config {
+      nat_ip = google_compute_address.static.address
+    }
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when no network interface has an access_config",
+			source: `
+resource "google_compute_instance" "good_example" {
+  name = "test"
+
+  network_interface {
+    network = "default"
+  }
+
+  network_interface {
+    network = "internal"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_GoogleNoPublicIpReportsEachAccessConfig(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "google_compute_instance" "bad_example" {
+  name = "test"
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+
+  network_interface {
+    network = "other"
+
+    access_config {
+    }
+  }
+}
+`, t)
+
+	var lines []int
+	for _, res := range results {
+		if res.RuleID == "google-compute-no-public-ip" {
+			lines = append(lines, res.Range().StartLine)
+		}
+	}
+	assert.ElementsMatch(t, []int{8, 15}, lines)
+}
+
+func Test_GoogleNoPublicIpAllowedInstances(t *testing.T) {
+	expectedCode := "google-compute-no-public-ip"
+
+	publicip.SetAllowed([]string{"bastion"}, map[string]string{"public-ip": "", "exposure": "internet"})
+	defer publicip.SetAllowed(nil, nil)
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "check passes when the instance has an allowed network tag",
+			source: `
+resource "google_compute_instance" "bastion" {
+  name = "bastion"
+  tags = ["ssh", "bastion"]
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when the instance has an allowed label key",
+			source: `
+resource "google_compute_instance" "bastion" {
+  name = "bastion"
+  labels = {
+    public-ip = "true"
+  }
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check passes when the instance has an allowed label value",
+			source: `
+resource "google_compute_instance" "bastion" {
+  name = "bastion"
+  labels = {
+    exposure = "internet"
+  }
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "check fails when the instance has a different label value",
+			source: `
+resource "google_compute_instance" "web" {
+  name = "web"
+  tags = ["http"]
+  labels = {
+    exposure = "internal"
+  }
+
+  network_interface {
+    network = "default"
+
+    access_config {
+    }
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package compute
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/ports"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.GoogleProvider,
+		Service:   "compute",
+		ShortCode: "no-public-sensitive-ports",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Firewall rules should not expose management or database ports to the internet",
+			Explanation: `Management ports such as SSH (22) and RDP (3389), and database ports such as MySQL (3306) and PostgreSQL (5432), are constantly scanned for and attacked from the internet. Access to them should be limited to known addresses, or provided through Identity-Aware Proxy or a VPN. The ports which are checked can be changed with sensitive_ports in the config file.`,
+			Impact:      "Management and database services can be attacked from anywhere on the internet",
+			Resolution:  "Restrict the source ranges of firewall rules for sensitive ports to known addresses",
+			BadExample: []string{`
+resource "google_compute_firewall" "bad_example" {
+  name    = "allow-ssh"
+  network = google_compute_network.example.name
+
+  allow {
+    protocol = "tcp"
+    ports    = ["22", "8080"]
+  }
+
+  source_ranges = ["0.0.0.0/0"]
+}
+`},
+			GoodExample: []string{`
+resource "google_compute_firewall" "good_example" {
+  name    = "allow-ssh"
+  network = google_compute_network.example.name
+
+  allow {
+    protocol = "tcp"
+    ports    = ["22"]
+  }
+
+  # the range used by Identity-Aware Proxy for TCP forwarding
+  source_ranges = ["35.235.240.0/20"]
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/hashicorp/google/latest/docs/resources/compute_firewall",
+				"https://cloud.google.com/vpc/docs/firewalls",
+				"https://cloud.google.com/iap/docs/using-tcp-forwarding",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"google_compute_firewall",
+		},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			// firewall rules are for ingress unless stated otherwise
+			if resourceBlock.GetAttribute("direction").Equals("EGRESS", block.IgnoreCase) {
+				return
+			}
+			sourceRangesAttr := resourceBlock.GetAttribute("source_ranges")
+			if !cidr.IsAttributeOpen(sourceRangesAttr) {
+				return
+			}
+
+			var portRanges []ports.Range
+			var portAttrs []block.Attribute
+			for _, allowBlock := range resourceBlock.GetBlocks("allow") {
+				protocolAttr := allowBlock.GetAttribute("protocol")
+				if protocolAttr.IsAny("icmp", "esp", "ah", "ipip") {
+					continue
+				}
+				// without ports, every port of the protocol is allowed
+				portsAttr := allowBlock.GetAttribute("ports")
+				if portsAttr.IsNil() {
+					portRanges = append(portRanges, ports.AllPorts)
+					portAttrs = append(portAttrs, protocolAttr)
+					continue
+				}
+				portRanges = append(portRanges, ports.RangesFromAttribute(portsAttr)...)
+				portAttrs = append(portAttrs, portsAttr)
+			}
+			ports.ReportExposure(set, resourceBlock, sourceRangesAttr, portRanges, portAttrs...)
+		},
+	})
+}
@@ -0,0 +1,97 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_GoogleNoPublicSensitivePorts(t *testing.T) {
+	expectedCode := "google-compute-no-public-sensitive-ports"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "ssh open to the internet",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	allow {
+		protocol = "tcp"
+		ports    = ["22", "8080"]
+	}
+	source_ranges = ["0.0.0.0/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "every tcp port open to the internet",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	allow {
+		protocol = "tcp"
+	}
+	source_ranges = ["0.0.0.0/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "port range covering rdp open to the internet",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	allow {
+		protocol = "tcp"
+		ports    = ["3000-4000"]
+	}
+	source_ranges = ["0.0.0.0/0"]
+}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "ssh open to the identity aware proxy range",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	allow {
+		protocol = "tcp"
+		ports    = ["22"]
+	}
+	source_ranges = ["35.235.240.0/20"]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "icmp open to the internet",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	allow {
+		protocol = "icmp"
+	}
+	source_ranges = ["0.0.0.0/0"]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "egress to ssh on the internet",
+			source: `
+resource "google_compute_firewall" "my-firewall" {
+	direction = "EGRESS"
+	allow {
+		protocol = "tcp"
+		ports    = ["22"]
+	}
+	source_ranges = ["0.0.0.0/0"]
+}`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
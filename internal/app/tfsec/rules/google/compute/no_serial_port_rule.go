@@ -127,7 +127,7 @@ resource "google_compute_instance" "default" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 			metadataAttr := resourceBlock.GetAttribute("metadata")
-			val := metadataAttr.MapValue("serial-port-enable")
+			val, _ := metadataAttr.GetMapValue("serial-port-enable")
 			if val.Type() == cty.Bool && val.True() {
 				set.AddResult().
 					WithDescription("Resource'%s' explicitly enables serial port", resourceBlock).
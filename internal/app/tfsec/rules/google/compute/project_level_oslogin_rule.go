@@ -51,7 +51,7 @@ resource "google_compute_project_metadata" "default" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 			metadataAttr := resourceBlock.GetAttribute("metadata")
-			val := metadataAttr.MapValue("enable-oslogin")
+			val, _ := metadataAttr.GetMapValue("enable-oslogin")
 			if val.Type() == cty.NilType {
 				set.AddResult().
 					WithDescription("Resource'%s' has OS Login disabled by default", resourceBlock)
@@ -22,7 +22,9 @@ func init() {
 			Summary:     "Ensure that Cloud Storage buckets have uniform bucket-level access enabled",
 			Impact:      "ACLs are difficult to manage and often lead to incorrect/unintended configurations.",
 			Resolution:  "Enable uniform bucket level access to provide a uniform permissioning system.",
-			Explanation: `When you enable uniform bucket-level access on a bucket, Access Control Lists (ACLs) are disabled, and only bucket-level Identity and Access Management (IAM) permissions grant access to that bucket and the objects it contains. You revoke all access granted by object ACLs and the ability to administrate permissions using bucket ACLs.`,
+			Explanation: `When you enable uniform bucket-level access on a bucket, Access Control Lists (ACLs) are disabled, and only bucket-level Identity and Access Management (IAM) permissions grant access to that bucket and the objects it contains. You revoke all access granted by object ACLs and the ability to administrate permissions using bucket ACLs.
+
+Older versions of the provider call this setting bucket_policy_only, which is checked when uniform_bucket_level_access isn't set.`,
 			BadExample: []string{`
 resource "google_storage_bucket" "static-site" {
 	name          = "image-store.com"
@@ -77,12 +79,18 @@ resource "google_storage_bucket" "static-site" {
 		DefaultSeverity: severity.Medium,
 		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
 
-			if attr := resourceBlock.GetAttribute("uniform_bucket_level_access"); attr.IsNil() {
+			attr := resourceBlock.GetAttribute("uniform_bucket_level_access")
+			if attr.IsNil() {
+				// bucket_policy_only is the name used by older versions of the provider
+				attr = resourceBlock.GetAttribute("bucket_policy_only")
+			}
+			if attr.IsNil() {
 				set.AddResult().
-					WithDescription("Resource '%s' does not have uniform_bucket_level_access enabled.", resourceBlock.FullName())
+					WithDescription("Resource '%s' does not have uniform_bucket_level_access enabled.", resourceBlock.FullName()).
+					WithBlock(resourceBlock)
 			} else if attr.Value().IsKnown() && attr.IsFalse() {
 				set.AddResult().
-					WithDescription("Resource '%s' has uniform_bucket_level_access explicitly disabled.", resourceBlock.FullName()).
+					WithDescription("Resource '%s' has %s explicitly disabled.", resourceBlock.FullName(), attr.Name()).
 					WithAttribute(attr)
 			}
 		},
@@ -83,6 +83,28 @@ func Test_GoogleStorageUniformBucketLevelAccess(t *testing.T) {
 			}`,
 			mustExcludeResultCode: expectedCode,
 		},
+		{
+			name: "check google_storage_bucket with legacy bucket_policy_only = false",
+			source: `
+			resource "google_storage_bucket" "static-site" {
+				name     = "image-store.com"
+				location = "EU"
+
+				bucket_policy_only = false
+			}`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "check google_storage_bucket with legacy bucket_policy_only = true",
+			source: `
+			resource "google_storage_bucket" "static-site" {
+				name     = "image-store.com"
+				location = "EU"
+
+				bucket_policy_only = true
+			}`,
+			mustExcludeResultCode: expectedCode,
+		},
 	}
 
 	for _, test := range tests {
@@ -38,6 +38,7 @@ import (
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/sns"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/sqs"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/ssm"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/tagging"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/vpc"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/aws/workspace"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/azure/appservice"
@@ -57,10 +58,14 @@ import (
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/azure/functionapp"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/cloudstack/compute"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/digitalocean/compute"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/digitalocean/database"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/digitalocean/droplet"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/digitalocean/loadbalancing"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/digitalocean/spaces"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/general/deprecation"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/general/lifecycle"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/general/secrets"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/github/branchprotections"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/github/repositories"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/google/bigquery"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/google/compute"
@@ -72,7 +77,10 @@ import (
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/google/sql"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/google/storage"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/kubernetes/network"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/kubernetes/workloads"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/openstack/compute"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/openstack/fw"
 	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/oracle/compute"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/oracle/networking"
+	_ "github.com/aquasecurity/tfsec/internal/app/tfsec/rules/oracle/objectstorage"
 )
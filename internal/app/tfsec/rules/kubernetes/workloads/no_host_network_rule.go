@@ -0,0 +1,102 @@
+package workloads
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.KubernetesProvider,
+		Service:   "workloads",
+		ShortCode: "no-host-network",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Pods should not share the host network namespace",
+			Explanation: `Pods using the host network can see and bind to all of the node's network interfaces, bypassing network policies and exposing services listening on localhost.`,
+			Impact:      "Pods can access host network interfaces and bypass network policies",
+			Resolution:  "Do not enable host_network on the pod spec",
+			BadExample: []string{`
+resource "kubernetes_daemonset" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    selector {
+      match_labels = {
+        app = "example"
+      }
+    }
+
+    template {
+      metadata {
+        labels = {
+          app = "example"
+        }
+      }
+
+      spec {
+        host_network = true
+
+        container {
+          name  = "example"
+          image = "nginx:1.21"
+        }
+      }
+    }
+  }
+}
+`},
+			GoodExample: []string{`
+resource "kubernetes_daemonset" "good_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    selector {
+      match_labels = {
+        app = "example"
+      }
+    }
+
+    template {
+      metadata {
+        labels = {
+          app = "example"
+        }
+      }
+
+      spec {
+        container {
+          name  = "example"
+          image = "nginx:1.21"
+        }
+      }
+    }
+  }
+}
+`},
+			Links: []string{
+				"https://kubernetes.io/docs/concepts/security/pod-security-standards/#baseline",
+				"https://registry.terraform.io/providers/hashicorp/kubernetes/latest/docs/resources/pod#host_network",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels:  workloadTypes,
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if hostNetworkAttr := podSpec(resourceBlock).GetAttribute("host_network"); hostNetworkAttr.IsTrue() {
+				set.AddResult().
+					WithDescription("Resource '%s' shares the host network namespace", resourceBlock.FullName()).
+					WithAttribute(hostNetworkAttr)
+			}
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package workloads
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.KubernetesProvider,
+		Service:   "workloads",
+		ShortCode: "no-privileged-containers",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Containers should not run in privileged mode",
+			Explanation: `Privileged containers have access to all devices on the host and run with most of the capabilities of host processes. A compromised privileged container can trivially take over the node it is running on.`,
+			Impact:      "A compromised container can take over the host",
+			Resolution:  "Do not set privileged in the container security context",
+			BadExample: []string{`
+resource "kubernetes_pod" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+
+      security_context {
+        privileged = true
+      }
+    }
+  }
+}
+`},
+			GoodExample: []string{`
+resource "kubernetes_pod" "good_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+
+      security_context {
+        privileged = false
+      }
+    }
+  }
+}
+`},
+			Links: []string{
+				"https://kubernetes.io/docs/concepts/security/pod-security-standards/#baseline",
+				"https://registry.terraform.io/providers/hashicorp/kubernetes/latest/docs/resources/pod#privileged",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels:  workloadTypes,
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			for _, container := range containers(podSpec(resourceBlock)) {
+				if privilegedAttr := container.GetBlock("security_context").GetAttribute("privileged"); privilegedAttr.IsTrue() {
+					set.AddResult().
+						WithDescription("Resource '%s' runs container '%s' in privileged mode", resourceBlock.FullName(), containerName(container)).
+						WithAttribute(privilegedAttr)
+				}
+			}
+		},
+	})
+}
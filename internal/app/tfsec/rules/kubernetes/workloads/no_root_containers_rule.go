@@ -0,0 +1,148 @@
+package workloads
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.KubernetesProvider,
+		Service:   "workloads",
+		ShortCode: "no-root-containers",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Containers should not run as root",
+			Explanation: `Containers running as root have full administrative access inside the container, which makes a container breakout far more damaging. Containers should be forced to run as a non-root user, either for the whole pod or per container.`,
+			Impact:      "A compromised container has root privileges",
+			Resolution:  "Set run_as_non_root in the security context",
+			BadExample: []string{`
+resource "kubernetes_deployment" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    template {
+      metadata {
+        labels = {
+          app = "example"
+        }
+      }
+
+      spec {
+        container {
+          name  = "example"
+          image = "nginx:1.21"
+        }
+      }
+    }
+  }
+}
+`, `
+resource "kubernetes_pod" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+
+      security_context {
+        run_as_user = 0
+      }
+    }
+  }
+}
+`},
+			GoodExample: []string{`
+resource "kubernetes_deployment" "good_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    template {
+      metadata {
+        labels = {
+          app = "example"
+        }
+      }
+
+      spec {
+        security_context {
+          run_as_non_root = true
+        }
+
+        container {
+          name  = "example"
+          image = "nginx:1.21"
+        }
+      }
+    }
+  }
+}
+`, `
+resource "kubernetes_pod" "good_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+
+      security_context {
+        run_as_user = 1000
+      }
+    }
+  }
+}
+`},
+			Links: []string{
+				"https://kubernetes.io/docs/tasks/configure-pod-container/security-context/",
+				"https://registry.terraform.io/providers/hashicorp/kubernetes/latest/docs/resources/pod#security_context",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels:  workloadTypes,
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			spec := podSpec(resourceBlock)
+			podNonRoot := runsAsNonRoot(spec.GetBlock("security_context"))
+
+			for _, container := range containers(spec) {
+				securityContext := container.GetBlock("security_context")
+				if runAsUser := securityContext.GetAttribute("run_as_user"); runAsUser.Equals(0) {
+					set.AddResult().
+						WithDescription("Resource '%s' runs container '%s' as root", resourceBlock.FullName(), containerName(container)).
+						WithAttribute(runAsUser)
+					continue
+				}
+				if podNonRoot || runsAsNonRoot(securityContext) {
+					continue
+				}
+				set.AddResult().
+					WithDescription("Resource '%s' does not prevent container '%s' from running as root", resourceBlock.FullName(), containerName(container)).
+					WithBlock(container)
+			}
+		},
+	})
+}
+
+// runsAsNonRoot reports whether a security context forces a non-root user
+func runsAsNonRoot(securityContext block.Block) bool {
+	if securityContext.IsNil() {
+		return false
+	}
+	return securityContext.GetAttribute("run_as_non_root").IsTrue() ||
+		securityContext.GetAttribute("run_as_user").GreaterThan(0)
+}
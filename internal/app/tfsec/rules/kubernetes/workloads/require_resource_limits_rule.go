@@ -0,0 +1,118 @@
+package workloads
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.KubernetesProvider,
+		Service:   "workloads",
+		ShortCode: "require-resource-limits",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Containers should have resource limits",
+			Explanation: `Containers without CPU and memory limits can consume all of the resources on a node, starving other workloads and making denial of service attacks easier.`,
+			Impact:      "A single container can exhaust the resources of a node",
+			Resolution:  "Set resource limits on every container",
+			BadExample: []string{`
+resource "kubernetes_pod" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+    }
+  }
+}
+`, `
+resource "kubernetes_cron_job" "bad_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    schedule = "*/5 * * * *"
+
+    job_template {
+      metadata {}
+
+      spec {
+        template {
+          metadata {}
+
+          spec {
+            container {
+              name  = "example"
+              image = "busybox"
+
+              resources {
+                requests = {
+                  cpu = "250m"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}
+`},
+			GoodExample: []string{`
+resource "kubernetes_pod" "good_example" {
+  metadata {
+    name = "example"
+  }
+
+  spec {
+    container {
+      name  = "example"
+      image = "nginx:1.21"
+
+      resources {
+        limits = {
+          cpu    = "500m"
+          memory = "512Mi"
+        }
+      }
+    }
+  }
+}
+`},
+			Links: []string{
+				"https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/",
+				"https://registry.terraform.io/providers/hashicorp/kubernetes/latest/docs/resources/pod#resources",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels:  workloadTypes,
+		DefaultSeverity: severity.Low,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			for _, container := range containers(podSpec(resourceBlock)) {
+				resources := container.GetBlock("resources")
+				if resources.IsNil() {
+					set.AddResult().
+						WithDescription("Resource '%s' does not set resource limits on container '%s'", resourceBlock.FullName(), containerName(container)).
+						WithBlock(container)
+					continue
+				}
+				// older provider versions declare limits as a nested block rather than a map
+				if resources.MissingChild("limits") {
+					set.AddResult().
+						WithDescription("Resource '%s' does not set resource limits on container '%s'", resourceBlock.FullName(), containerName(container)).
+						WithBlock(resources)
+				}
+			}
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package workloads
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+)
+
+// workloadTypes are the resources which define pods, either directly or through a pod template
+var workloadTypes = []string{
+	"kubernetes_pod",
+	"kubernetes_pod_v1",
+	"kubernetes_deployment",
+	"kubernetes_deployment_v1",
+	"kubernetes_daemonset",
+	"kubernetes_daemon_set_v1",
+	"kubernetes_stateful_set",
+	"kubernetes_stateful_set_v1",
+	"kubernetes_replication_controller",
+	"kubernetes_replication_controller_v1",
+	"kubernetes_job",
+	"kubernetes_job_v1",
+	"kubernetes_cron_job",
+	"kubernetes_cron_job_v1",
+}
+
+// podSpec returns the pod spec of a workload, descending through the job and pod templates where present
+func podSpec(resourceBlock block.Block) block.Block {
+	spec := resourceBlock.GetBlock("spec")
+	if jobTemplate := spec.GetBlock("job_template"); jobTemplate.IsNotNil() {
+		spec = jobTemplate.GetBlock("spec")
+	}
+	if template := spec.GetBlock("template"); template.IsNotNil() {
+		spec = template.GetBlock("spec")
+	}
+	return spec
+}
+
+// containers returns both the regular and init containers of a pod spec
+func containers(spec block.Block) block.Blocks {
+	return append(spec.GetBlocks("container"), spec.GetBlocks("init_container")...)
+}
+
+// containerName returns the name of a container, falling back to its block type if the name is not a known string
+func containerName(container block.Block) string {
+	if nameAttr := container.GetAttribute("name"); nameAttr.IsString() {
+		return nameAttr.Value().AsString()
+	}
+	return container.Type()
+}
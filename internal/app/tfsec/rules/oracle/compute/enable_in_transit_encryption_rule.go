@@ -0,0 +1,89 @@
+package compute
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.OracleProvider,
+		Service:   "compute",
+		ShortCode: "enable-in-transit-encryption",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Instances should encrypt data in transit between the instance and its volumes",
+			Explanation: `Paravirtualized boot and block volume attachments are not encrypted in transit unless in-transit encryption is enabled on the instance. Enabling it protects volume traffic from interception on the host network.`,
+			Impact:      "Volume traffic can be intercepted on the host network",
+			Resolution:  "Enable is_pv_encryption_in_transit_enabled on the instance",
+			BadExample: []string{`
+resource "oci_core_instance" "bad_example" {
+  availability_domain = var.availability_domain
+  compartment_id      = var.compartment_id
+  shape               = "VM.Standard2.1"
+}
+`, `
+resource "oci_core_instance" "bad_example" {
+  availability_domain = var.availability_domain
+  compartment_id      = var.compartment_id
+  shape               = "VM.Standard2.1"
+
+  launch_options {
+    is_pv_encryption_in_transit_enabled = false
+  }
+}
+`},
+			GoodExample: []string{`
+resource "oci_core_instance" "good_example" {
+  availability_domain                 = var.availability_domain
+  compartment_id                      = var.compartment_id
+  shape                               = "VM.Standard2.1"
+  is_pv_encryption_in_transit_enabled = true
+}
+`, `
+resource "oci_core_instance" "good_example" {
+  availability_domain = var.availability_domain
+  compartment_id      = var.compartment_id
+  shape               = "VM.Standard2.1"
+
+  launch_options {
+    is_pv_encryption_in_transit_enabled = true
+  }
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/oracle/oci/latest/docs/resources/core_instance#is_pv_encryption_in_transit_enabled",
+				"https://docs.oracle.com/en-us/iaas/Content/Block/Concepts/overview.htm#BlockVolumeEncryption",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"oci_core_instance",
+		},
+		DefaultSeverity: severity.Medium,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if resourceBlock.GetAttribute("is_pv_encryption_in_transit_enabled").IsTrue() {
+				return
+			}
+
+			launchOptionsAttr := resourceBlock.GetBlock("launch_options").GetAttribute("is_pv_encryption_in_transit_enabled")
+			if launchOptionsAttr.IsTrue() {
+				return
+			}
+			if launchOptionsAttr.IsNotNil() {
+				set.AddResult().
+					WithDescription("Resource '%s' does not enable in-transit encryption", resourceBlock.FullName()).
+					WithAttribute(launchOptionsAttr)
+				return
+			}
+
+			set.AddResult().
+				WithDescription("Resource '%s' does not enable in-transit encryption", resourceBlock.FullName())
+		},
+	})
+}
@@ -0,0 +1,71 @@
+package compute
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_OCIComputeEnableInTransitEncryption(t *testing.T) {
+	expectedCode := "oracle-compute-enable-in-transit-encryption"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "instance without in-transit encryption fails check",
+			source: `
+resource "oci_core_instance" "example" {
+  shape = "VM.Standard2.1"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with in-transit encryption disabled in launch options fails check",
+			source: `
+resource "oci_core_instance" "example" {
+  shape = "VM.Standard2.1"
+
+  launch_options {
+    is_pv_encryption_in_transit_enabled = false
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with in-transit encryption enabled passes check",
+			source: `
+resource "oci_core_instance" "example" {
+  shape                               = "VM.Standard2.1"
+  is_pv_encryption_in_transit_enabled = true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "instance with in-transit encryption enabled in launch options passes check",
+			source: `
+resource "oci_core_instance" "example" {
+  shape = "VM.Standard2.1"
+
+  launch_options {
+    is_pv_encryption_in_transit_enabled = true
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
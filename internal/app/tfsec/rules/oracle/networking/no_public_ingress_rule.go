@@ -0,0 +1,94 @@
+package networking
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/cidr"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.OracleProvider,
+		Service:   "networking",
+		ShortCode: "no-public-ingress",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Security lists should not allow ingress from the public internet",
+			Explanation: `Security list and network security group rules which allow ingress from 0.0.0.0/0 expose every instance in the subnet to the internet. Ingress should be limited to the address ranges which need access.`,
+			Impact:      "Instances are exposed to the public internet",
+			Resolution:  "Restrict ingress sources to known address ranges",
+			BadExample: []string{`
+resource "oci_core_security_list" "bad_example" {
+  compartment_id = var.compartment_id
+  vcn_id         = oci_core_vcn.example.id
+
+  ingress_security_rules {
+    protocol = "6"
+    source   = "0.0.0.0/0"
+  }
+}
+`, `
+resource "oci_core_network_security_group_security_rule" "bad_example" {
+  network_security_group_id = oci_core_network_security_group.example.id
+  direction                 = "INGRESS"
+  protocol                  = "6"
+  source                    = "0.0.0.0/0"
+}
+`},
+			GoodExample: []string{`
+resource "oci_core_security_list" "good_example" {
+  compartment_id = var.compartment_id
+  vcn_id         = oci_core_vcn.example.id
+
+  ingress_security_rules {
+    protocol = "6"
+    source   = "10.0.0.0/16"
+  }
+}
+`, `
+resource "oci_core_network_security_group_security_rule" "good_example" {
+  network_security_group_id = oci_core_network_security_group.example.id
+  direction                 = "EGRESS"
+  protocol                  = "6"
+  destination               = "0.0.0.0/0"
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/oracle/oci/latest/docs/resources/core_security_list#ingress_security_rules",
+				"https://registry.terraform.io/providers/oracle/oci/latest/docs/resources/core_network_security_group_security_rule",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"oci_core_security_list",
+			"oci_core_network_security_group_security_rule",
+		},
+		DefaultSeverity: severity.Critical,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			if resourceBlock.TypeLabel() == "oci_core_network_security_group_security_rule" {
+				if !resourceBlock.GetAttribute("direction").Equals("INGRESS", block.IgnoreCase) {
+					return
+				}
+				if sourceAttr := resourceBlock.GetAttribute("source"); cidr.IsAttributeOpen(sourceAttr) {
+					set.AddResult().
+						WithDescription("Resource '%s' allows ingress from the public internet", resourceBlock.FullName()).
+						WithAttribute(sourceAttr)
+				}
+				return
+			}
+
+			for _, ingress := range resourceBlock.GetBlocks("ingress_security_rules") {
+				if sourceAttr := ingress.GetAttribute("source"); cidr.IsAttributeOpen(sourceAttr) {
+					set.AddResult().
+						WithDescription("Resource '%s' allows ingress from the public internet", resourceBlock.FullName()).
+						WithAttribute(sourceAttr)
+				}
+			}
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package networking
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_OCINetworkingNoPublicIngress(t *testing.T) {
+	expectedCode := "oracle-networking-no-public-ingress"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "security list with public ingress fails check",
+			source: `
+resource "oci_core_security_list" "example" {
+  ingress_security_rules {
+    protocol = "6"
+    source   = "10.0.0.0/16"
+  }
+
+  ingress_security_rules {
+    protocol = "6"
+    source   = "0.0.0.0/0"
+  }
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "network security group rule with public ingress fails check",
+			source: `
+resource "oci_core_network_security_group_security_rule" "example" {
+  direction = "INGRESS"
+  protocol  = "6"
+  source    = "0.0.0.0/0"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "security list with private ingress passes check",
+			source: `
+resource "oci_core_security_list" "example" {
+  ingress_security_rules {
+    protocol = "6"
+    source   = "10.0.0.0/16"
+  }
+
+  egress_security_rules {
+    protocol    = "6"
+    destination = "0.0.0.0/0"
+  }
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "network security group egress rule passes check",
+			source: `
+resource "oci_core_network_security_group_security_rule" "example" {
+  direction   = "EGRESS"
+  protocol    = "6"
+  destination = "0.0.0.0/0"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
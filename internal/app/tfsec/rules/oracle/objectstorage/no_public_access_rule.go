@@ -0,0 +1,59 @@
+package objectstorage
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+)
+
+func init() {
+	scanner.RegisterCheckRule(rule.Rule{
+		Provider:  provider.OracleProvider,
+		Service:   "objectstorage",
+		ShortCode: "no-public-access",
+		Documentation: rule.RuleDocumentation{
+			Summary:     "Object storage buckets should not allow public access",
+			Explanation: `Buckets with an access type of ObjectRead or ObjectReadWithoutList allow anyone to download their objects without authenticating. Buckets should be private unless they are explicitly intended to serve public content.`,
+			Impact:      "Objects in the bucket can be read by anyone",
+			Resolution:  "Set the bucket access type to NoPublicAccess",
+			BadExample: []string{`
+resource "oci_objectstorage_bucket" "bad_example" {
+  compartment_id = var.compartment_id
+  name           = "example"
+  namespace      = var.namespace
+  access_type    = "ObjectRead"
+}
+`},
+			GoodExample: []string{`
+resource "oci_objectstorage_bucket" "good_example" {
+  compartment_id = var.compartment_id
+  name           = "example"
+  namespace      = var.namespace
+  access_type    = "NoPublicAccess"
+}
+`},
+			Links: []string{
+				"https://registry.terraform.io/providers/oracle/oci/latest/docs/resources/objectstorage_bucket#access_type",
+				"https://docs.oracle.com/en-us/iaas/Content/Object/Tasks/managingbuckets.htm#publicbuckets",
+			},
+		},
+		RequiredTypes: []string{
+			"resource",
+		},
+		RequiredLabels: []string{
+			"oci_objectstorage_bucket",
+		},
+		DefaultSeverity: severity.High,
+		CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+			// buckets default to NoPublicAccess when access_type is omitted
+			if accessTypeAttr := resourceBlock.GetAttribute("access_type"); accessTypeAttr.IsAny("ObjectRead", "ObjectReadWithoutList") {
+				set.AddResult().
+					WithDescription("Resource '%s' allows public access to its objects", resourceBlock.FullName()).
+					WithAttribute(accessTypeAttr)
+			}
+		},
+	})
+}
@@ -0,0 +1,65 @@
+package objectstorage
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_OCIObjectStorageNoPublicAccess(t *testing.T) {
+	expectedCode := "oracle-objectstorage-no-public-access"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "bucket with public object read fails check",
+			source: `
+resource "oci_objectstorage_bucket" "example" {
+  name        = "example"
+  access_type = "ObjectRead"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "bucket with public object read without listing fails check",
+			source: `
+resource "oci_objectstorage_bucket" "example" {
+  name        = "example"
+  access_type = "ObjectReadWithoutList"
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "bucket with the default access type passes check",
+			source: `
+resource "oci_objectstorage_bucket" "example" {
+  name = "example"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "bucket with no public access passes check",
+			source: `
+resource "oci_objectstorage_bucket" "example" {
+  name        = "example"
+  access_type = "NoPublicAccess"
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
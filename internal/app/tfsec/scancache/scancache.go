@@ -0,0 +1,186 @@
+package scancache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/coverage"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+// Parsed HCL can't be written to disk, so the results of a scan are stored instead. A stored scan is found by a key
+// made from the tfsec build and the settings of the scan, and is only reused while the content of every file it read,
+// and the listing of every directory it read, is unchanged.
+
+// Inputs are the files and directories which a scan read
+type Inputs struct {
+	Files []string
+	Dirs  []string
+}
+
+type entry struct {
+	// Files holds the sha256 of each file read, or an empty string for a file which didn't exist
+	Files map[string]string `json:"files"`
+	// Dirs holds the names of the entries of each directory read, or none for a directory which didn't exist
+	Dirs    map[string][]string `json:"dirs"`
+	Results []result.Snapshot   `json:"results"`
+	// KnownFiles, Counts, Passed, Skipped and Diagnostics are restored so the output of a cached scan matches that of
+	// the scan which was cached
+	KnownFiles  []string                 `json:"known_files"`
+	Counts      map[metrics.Count]int    `json:"counts"`
+	Passed      []coverage.Record        `json:"passed"`
+	Skipped     []coverage.Record        `json:"skipped"`
+	Diagnostics []diagnostics.Diagnostic `json:"diagnostics"`
+}
+
+// Key identifies a scan. The parts must include everything other than the scanned files which changes the results,
+// such as the tfsec build and the command line.
+func Key(parts ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+func entryPath(cacheDir string, key string) string {
+	return filepath.Join(cacheDir, "scans", key+".json")
+}
+
+// Load returns the results stored for the key, provided none of the inputs of the stored scan have changed since. The
+// files, counts, coverage and diagnostics recorded by the stored scan are restored, and its warnings written again, as
+// if it had been run again.
+func Load(cacheDir string, key string) ([]result.Result, bool) {
+	data, err := ioutil.ReadFile(entryPath(cacheDir, key))
+	if err != nil {
+		return nil, false
+	}
+	var cached entry
+	if err := json.Unmarshal(data, &cached); err != nil {
+		debug.Log("Ignoring unreadable cached scan %s: %s", key, err)
+		return nil, false
+	}
+	for path, hash := range cached.Files {
+		if current, err := hashFile(path); err != nil || current != hash {
+			debug.Log("Not using cached scan %s, as %s has changed", key, path)
+			return nil, false
+		}
+	}
+	for path, names := range cached.Dirs {
+		if current, err := listDir(path); err != nil || strings.Join(current, "/") != strings.Join(names, "/") {
+			debug.Log("Not using cached scan %s, as the content of %s has changed", key, path)
+			return nil, false
+		}
+	}
+
+	parser.AddKnownFiles(cached.KnownFiles...)
+	for count, value := range cached.Counts {
+		// the modules were downloaded by the stored scan, not this one
+		if count != metrics.ModuleDownloadCount {
+			metrics.Add(count, value)
+		}
+	}
+
+	for _, record := range append(cached.Passed, cached.Skipped...) {
+		coverage.Add(record)
+	}
+	for _, diagnostic := range cached.Diagnostics {
+		if diagnostics.Add(diagnostic) {
+			_, _ = fmt.Fprintln(os.Stderr, diagnostic.Warning())
+		}
+	}
+
+	results := make([]result.Result, 0, len(cached.Results))
+	for _, snapshot := range cached.Results {
+		results = append(results, snapshot.Restore())
+	}
+	return results, true
+}
+
+// Save stores the results of a scan under the key, along with the content hashes of its inputs
+func Save(cacheDir string, key string, inputs Inputs, results []result.Result) error {
+	cached := entry{
+		Files:       make(map[string]string),
+		Dirs:        make(map[string][]string),
+		Results:     make([]result.Snapshot, 0, len(results)),
+		KnownFiles:  parser.KnownFiles(),
+		Counts:      metrics.CountSummary(),
+		Passed:      coverage.Records(coverage.Passed),
+		Skipped:     coverage.Records(coverage.NotApplicable),
+		Diagnostics: diagnostics.All(),
+	}
+	for _, path := range inputs.Files {
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		cached.Files[path] = hash
+	}
+	for _, path := range inputs.Dirs {
+		names, err := listDir(path)
+		if err != nil {
+			return err
+		}
+		cached.Dirs[path] = names
+	}
+	for i := range results {
+		cached.Results = append(cached.Results, results[i].Snapshot())
+	}
+
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	path := entryPath(cacheDir, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	// the entry is written alongside and renamed into place, so a concurrent run never reads half of it
+	tmp, err := ioutil.TempFile(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func hashFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func listDir(path string) ([]string, error) {
+	infos, err := ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(infos))
+	for _, info := range infos {
+		names = append(names, info.Name())
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -1,5 +1,11 @@
 package scanner
 
+import (
+	"context"
+
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
 type Option func(s *Scanner)
 
 func OptionIncludePassed() func(s *Scanner) {
@@ -14,6 +20,27 @@ func OptionIncludeIgnored() func(s *Scanner) {
 	}
 }
 
+// OptionNoIgnores disables ignore comments, so every finding is reported
+func OptionNoIgnores() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.noIgnores = true
+	}
+}
+
+// OptionOnlyIgnored reports only the findings which are suppressed by an ignore comment, marked as ignored and with
+// the comment which suppresses them
+func OptionOnlyIgnored() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.onlyIgnored = true
+	}
+}
+
+func OptionRequireIgnoreJustification() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.requireIgnoreJustification = true
+	}
+}
+
 func OptionExcludeRules(ruleIDs []string) func(s *Scanner) {
 	return func(s *Scanner) {
 		s.excludedRuleIDs = ruleIDs
@@ -37,3 +64,52 @@ func OptionWithWorkspaceName(workspaceName string) func(s *Scanner) {
 		s.workspaceName = workspaceName
 	}
 }
+
+func OptionConcurrency(concurrency int) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.concurrency = concurrency
+	}
+}
+
+func OptionFilterProviders(providers []string) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.providers = providers
+	}
+}
+
+func OptionDisableDeduplication() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.disableDeduplication = true
+	}
+}
+
+func OptionTrackPassed() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.trackPassed = true
+	}
+}
+
+// OptionConservative checks blocks with conditions which can't be resolved against both results of each conditional,
+// reporting a finding if either is insecure
+func OptionConservative() func(s *Scanner) {
+	return func(s *Scanner) {
+		s.conservative = true
+	}
+}
+
+// OptionWithContext stops the scan when ctx is cancelled or its deadline passes. Results from the blocks which were
+// already checked are still returned.
+func OptionWithContext(ctx context.Context) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.ctx = ctx
+	}
+}
+
+// OptionWithResultHandler passes the results of each block to handler as soon as the block has been checked, so they
+// can be reported while the scan is running. The results aren't deduplicated against other blocks or sorted, and
+// handler is never called concurrently.
+func OptionWithResultHandler(handler func(results []result.Result)) func(s *Scanner) {
+	return func(s *Scanner) {
+		s.resultHandler = handler
+	}
+}
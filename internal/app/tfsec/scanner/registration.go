@@ -3,7 +3,9 @@ package scanner
 import (
 	"fmt"
 	"os"
+	"path"
 	"sort"
+	"strings"
 	"sync"
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
@@ -54,6 +56,23 @@ func GetRegisteredRules() []rule.Rule {
 	return registeredRules
 }
 
+// FilterRulesByProvider returns the rules for any of the given providers, or all of the rules if no providers are given
+func FilterRulesByProvider(rules []rule.Rule, providers []string) []rule.Rule {
+	if len(providers) == 0 {
+		return rules
+	}
+	var filtered []rule.Rule
+	for _, r := range rules {
+		for _, p := range providers {
+			if strings.EqualFold(string(r.Provider), p) {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 func GetRuleById(ID string) (*rule.Rule, error) {
 	for _, r := range registeredRules {
 		if r.ID() == ID {
@@ -71,3 +90,33 @@ func GetRuleByLegacyID(legacyID string) (*rule.Rule, error) {
 	}
 	return nil, fmt.Errorf("could not find rule with legacyID '%s'", legacyID)
 }
+
+// IsRuleIDPattern reports whether a rule ID given to include or exclude is a wildcard pattern such as aws-s3-*
+func IsRuleIDPattern(id string) bool {
+	return strings.ContainsAny(id, "*?[")
+}
+
+// ExpandRuleIDPatterns replaces wildcard patterns with the IDs of the registered rules they match. IDs which aren't
+// patterns are kept as they are. Patterns which don't match any rule are returned separately.
+func ExpandRuleIDPatterns(ids []string) (expanded []string, unmatched []string) {
+	for _, id := range ids {
+		if !IsRuleIDPattern(id) {
+			expanded = append(expanded, id)
+			continue
+		}
+		matched := false
+		for _, r := range GetRegisteredRules() {
+			if ok, _ := path.Match(strings.ToLower(id), r.ID()); ok {
+				expanded = append(expanded, r.ID())
+				matched = true
+			} else if ok, _ := path.Match(id, r.LegacyID); ok && r.LegacyID != "" {
+				expanded = append(expanded, r.ID())
+				matched = true
+			}
+		}
+		if !matched {
+			unmatched = append(unmatched, id)
+		}
+	}
+	return expanded, unmatched
+}
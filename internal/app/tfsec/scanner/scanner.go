@@ -1,12 +1,17 @@
 package scanner
 
 import (
+	"context"
+	"fmt"
+	"runtime"
 	"sort"
+	"sync"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/coverage"
 
 	"github.com/aquasecurity/tfsec/pkg/rule"
 
@@ -17,22 +22,38 @@ import (
 
 // Scanner scans HCL blocks by running all registered rules against them
 type Scanner struct {
-	includePassed     bool
-	includeIgnored    bool
-	excludedRuleIDs   []string
-	includedRuleIDs   []string
-	ignoreCheckErrors bool
-	workspaceName     string
+	includePassed              bool
+	includeIgnored             bool
+	excludedRuleIDs            []string
+	includedRuleIDs            []string
+	ignoreCheckErrors          bool
+	workspaceName              string
+	requireIgnoreJustification bool
+	concurrency                int
+	providers                  []string
+	disableDeduplication       bool
+	trackPassed                bool
+	noIgnores                  bool
+	onlyIgnored                bool
+	conservative               bool
+	ctx                        context.Context
+	resultHandler              func(results []result.Result)
+	collectedLock              sync.Mutex
+	collected                  []result.Result
 }
 
 // New creates a new Scanner
 func New(options ...Option) *Scanner {
 	s := &Scanner{
 		ignoreCheckErrors: true,
+		ctx:               context.Background(),
 	}
 	for _, option := range options {
 		option(s)
 	}
+	if s.concurrency <= 0 {
+		s.concurrency = runtime.GOMAXPROCS(0)
+	}
 	return s
 }
 
@@ -46,15 +67,73 @@ func checkInList(id string, legacyID string, list []string) bool {
 	return false
 }
 
+type scanJob struct {
+	module block.Module
+	block  block.Block
+}
+
 func (scanner *Scanner) Scan(modules []block.Module) []result.Result {
 	checkTime := metrics.Start(metrics.Check)
 	defer checkTime.Stop()
-	var results []result.Result
-	rules := GetRegisteredRules()
+	rules := FilterRulesByProvider(GetRegisteredRules(), scanner.providers)
+
+	var jobs []scanJob
 	for _, module := range modules {
-		results = append(results, scanner.scanModule(module, rules)...)
+		for _, checkBlock := range module.GetBlocks() {
+			jobs = append(jobs, scanJob{module: module, block: checkBlock})
+		}
+	}
+
+	// each job writes to its own slot, so results are collected in the same order regardless of scheduling
+	jobResults := make([][]result.Result, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	var panicOnce sync.Once
+	var recovered interface{}
+	for w := 0; w < scanner.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				// once the scan is cancelled, remaining jobs are drained without being run
+				if scanner.ctx.Err() != nil {
+					continue
+				}
+				func() {
+					// with errors not being ignored, a failing check must still panic in the caller's goroutine
+					defer func() {
+						if err := recover(); err != nil {
+							panicOnce.Do(func() { recovered = err })
+						}
+					}()
+					jobResults[i] = scanner.scanBlock(jobs[i].module, jobs[i].block, rules)
+					scanner.collect(jobResults[i])
+				}()
+			}
+		}()
+	}
+queue:
+	for i := range jobs {
+		select {
+		case indexes <- i:
+		case <-scanner.ctx.Done():
+			break queue
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	if recovered != nil {
+		panic(recovered)
+	}
+
+	var results []result.Result
+	for _, blockResults := range jobResults {
+		results = append(results, blockResults...)
+	}
+	if !scanner.disableDeduplication {
+		results = result.Deduplicate(results)
 	}
-	sort.Slice(results, func(i, j int) bool {
+	sort.SliceStable(results, func(i, j int) bool {
 		switch {
 		case results[i].RuleID < results[j].RuleID:
 			return true
@@ -67,42 +146,123 @@ func (scanner *Scanner) Scan(modules []block.Module) []result.Result {
 	return results
 }
 
-func (scanner *Scanner) scanModule(module block.Module, rules []rule.Rule) []result.Result {
+// collect records the results of a block as soon as it has been checked
+func (scanner *Scanner) collect(results []result.Result) {
+	scanner.collectedLock.Lock()
+	defer scanner.collectedLock.Unlock()
+	scanner.collected = append(scanner.collected, results...)
+	if scanner.resultHandler != nil && len(results) > 0 {
+		scanner.resultHandler(results)
+	}
+}
+
+// CollectedResults returns the results of the blocks which have been checked so far. It can be called while Scan is
+// running, to report what was found by a scan which can't be waited for. The results aren't deduplicated or sorted.
+func (scanner *Scanner) CollectedResults() []result.Result {
+	scanner.collectedLock.Lock()
+	defer scanner.collectedLock.Unlock()
+	return append([]result.Result(nil), scanner.collected...)
+}
+
+func (scanner *Scanner) scanBlock(module block.Module, checkBlock block.Block, rules []rule.Rule) []result.Result {
 	var results []result.Result
-	for _, checkBlock := range module.GetBlocks() {
-		for _, r := range rules {
-			if rule.IsRuleRequiredForBlock(&r, checkBlock) {
-				debug.Log("Running rule for %s on %s (%s)...", r.ID(), checkBlock.Reference(), checkBlock.Range().Filename)
-				ruleResults := rule.CheckRule(&r, checkBlock, module, scanner.ignoreCheckErrors)
-				if scanner.includePassed && ruleResults.All() == nil {
-					res := result.New(checkBlock).
-						WithLegacyRuleID(r.LegacyID).
-						WithRuleID(r.ID()).
-						WithDescription("Resource '%s' passed check: %s", checkBlock.FullName(), r.Documentation.Summary).
-						WithStatus(result.Passed).
-						WithImpact(r.Documentation.Impact).
-						WithResolution(r.Documentation.Resolution).
-						WithSeverity(r.DefaultSeverity)
-					results = append(results, *res)
-				} else if ruleResults != nil {
-					for _, ruleResult := range ruleResults.All() {
-						if ruleResult.Severity == severity.None {
-							ruleResult.Severity = r.DefaultSeverity
+	checkBranches := scanner.conservative && checkBlock.HasUnknownCondition()
+	for _, r := range rules {
+		if scanner.ctx.Err() != nil {
+			break
+		}
+		if rule.IsRuleRequiredForBlock(&r, checkBlock) {
+			debug.Log("Running rule for %s on %s (%s)...", r.ID(), checkBlock.Reference(), checkBlock.Range().Filename)
+			ruleResults := scanner.checkRule(&r, checkBlock, module, checkBranches)
+			metrics.Add(metrics.ChecksRun, 1)
+			if ruleResults == nil {
+				metrics.Add(metrics.ChecksPassed, 1)
+			}
+			if scanner.trackPassed && ruleResults == nil {
+				scanner.recordCoverage(r, checkBlock, coverage.Passed, coverage.ReasonNoFindings)
+			}
+			if scanner.includePassed && ruleResults == nil {
+				res := result.New(checkBlock).
+					WithLegacyRuleID(r.LegacyID).
+					WithRuleID(r.ID()).
+					WithRuleProvider(r.Provider).
+					WithRuleService(r.Service).
+					WithDescription("Resource '%s' passed check: %s", checkBlock.FullName(), r.Documentation.Summary).
+					WithStatus(result.Passed).
+					WithImpact(r.Documentation.Impact).
+					WithResolution(r.Documentation.Resolution).
+					WithSeverity(r.DefaultSeverity)
+				results = append(results, *res)
+			} else if ruleResults != nil {
+				for _, ruleResult := range ruleResults {
+					if ruleResult.Severity == severity.None {
+						ruleResult.Severity = r.DefaultSeverity
+					}
+					if len(scanner.includedRuleIDs) == 0 || len(scanner.includedRuleIDs) > 0 && checkInList(ruleResult.RuleID, ruleResult.LegacyRuleID, scanner.includedRuleIDs) {
+						var annotation result.Annotation
+						var ignored bool
+						if !scanner.noIgnores {
+							annotation, ignored = ruleResult.IgnoredBy(scanner.workspaceName, scanner.requireIgnoreJustification)
 						}
-						if len(scanner.includedRuleIDs) == 0 || len(scanner.includedRuleIDs) > 0 && checkInList(ruleResult.RuleID, ruleResult.LegacyRuleID, scanner.includedRuleIDs) {
-							if !scanner.includeIgnored && (ruleResult.IsIgnored(scanner.workspaceName) || checkInList(ruleResult.RuleID, ruleResult.LegacyRuleID, scanner.excludedRuleIDs)) {
-								// rule was ignored
-								metrics.Add(metrics.IgnoredChecks, 1)
-								debug.Log("Ignoring '%s'", ruleResult.RuleID)
-							} else {
+						if scanner.onlyIgnored {
+							if ignored && !checkInList(ruleResult.RuleID, ruleResult.LegacyRuleID, scanner.excludedRuleIDs) {
+								ruleResult.Justification = annotation.Justification
+								ruleResult.IgnoreDirective = annotation.Directive
+								ruleResult.Status = result.Ignored
 								results = append(results, *ruleResult)
-
 							}
+							continue
+						}
+						if !scanner.includeIgnored && (ignored || checkInList(ruleResult.RuleID, ruleResult.LegacyRuleID, scanner.excludedRuleIDs)) {
+							// rule was ignored
+							metrics.Add(metrics.IgnoredChecks, 1)
+							debug.Log("Ignoring '%s'", ruleResult.RuleID)
+						} else {
+							if ignored {
+								ruleResult.Justification = annotation.Justification
+								ruleResult.IgnoreDirective = annotation.Directive
+							}
+							results = append(results, *ruleResult)
+
 						}
 					}
 				}
 			}
+		} else if scanner.trackPassed && rule.IsRuleApplicableToBlockType(&r, checkBlock) {
+			scanner.recordCoverage(r, checkBlock, coverage.NotApplicable, coverage.ReasonRequiredLabelsMismatch)
 		}
 	}
 	return results
 }
+
+// checkRule runs a rule against a block. When checkBranches is set, the block is checked with every condition which
+// can't be resolved taking its true result and then its false result, so a finding from either outcome is reported.
+func (scanner *Scanner) checkRule(r *rule.Rule, checkBlock block.Block, module block.Module, checkBranches bool) []*result.Result {
+	if !checkBranches {
+		return rule.CheckRule(r, checkBlock, module, scanner.ignoreCheckErrors).All()
+	}
+	var ruleResults []*result.Result
+	seen := make(map[string]bool)
+	for _, branch := range []bool{true, false} {
+		for _, res := range rule.CheckRule(r, checkBlock.WithConditionalBranch(branch), module, scanner.ignoreCheckErrors).All() {
+			key := fmt.Sprintf("%s|%s", res.Range().String(), res.Description)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			ruleResults = append(ruleResults, res)
+		}
+	}
+	return ruleResults
+}
+
+func (scanner *Scanner) recordCoverage(r rule.Rule, checkBlock block.Block, status coverage.Status, reason string) {
+	coverage.Add(coverage.Record{
+		RuleID:       r.ID(),
+		LegacyRuleID: r.LegacyID,
+		Resource:     checkBlock.FullName(),
+		Location:     checkBlock.Range(),
+		Status:       status,
+		Reason:       reason,
+	})
+}
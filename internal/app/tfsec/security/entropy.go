@@ -0,0 +1,86 @@
+package security
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// DefaultEntropyThreshold is the Shannon entropy, in bits per character, above which a token is random enough to
+// likely be a secret. Random base64 strings score around 5 or more, whereas hex digests can't exceed 4.
+const DefaultEntropyThreshold = 4.5
+
+// minimumSecretLength is the shortest token which is checked for entropy, as short tokens score too unreliably
+const minimumSecretLength = 20
+
+var entropyThreshold = DefaultEntropyThreshold
+
+// SetEntropyThreshold sets the entropy above which tokens are reported by HighEntropyTokens
+func SetEntropyThreshold(threshold float64) {
+	entropyThreshold = threshold
+}
+
+// EntropyThreshold returns the entropy above which tokens are reported by HighEntropyTokens
+func EntropyThreshold() float64 {
+	return entropyThreshold
+}
+
+// Entropy calculates the Shannon entropy of the string in bits per character
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	var length int
+	for _, r := range s {
+		counts[r]++
+		length++
+	}
+	var entropy float64
+	for _, count := range counts {
+		p := float64(count) / float64(length)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// HighEntropyTokens returns the tokens in the content which are long and random enough to likely be secrets, such as
+// API keys. Tokens are runs of characters used by base64 and similar encodings, and must mix letters and digits or
+// letter cases, so long identifiers aren't reported.
+func HighEntropyTokens(content string) []string {
+	tokens := strings.FieldsFunc(content, func(r rune) bool {
+		return !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("+/=_-", r))
+	})
+
+	var found []string
+	for _, token := range tokens {
+		if len(token) < minimumSecretLength || !hasMixedCharacterClasses(token) {
+			continue
+		}
+		if Entropy(token) >= entropyThreshold {
+			found = append(found, token)
+		}
+	}
+	return found
+}
+
+func hasMixedCharacterClasses(token string) bool {
+	var lower, upper, digit bool
+	for _, r := range token {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	classes := 0
+	for _, present := range []bool{lower, upper, digit} {
+		if present {
+			classes++
+		}
+	}
+	return classes >= 2
+}
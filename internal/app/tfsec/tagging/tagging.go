@@ -0,0 +1,60 @@
+package tagging
+
+import (
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/zclconf/go-cty/cty"
+)
+
+var requiredTags []string
+
+// SetRequiredTags sets the tag keys which every taggable resource must carry
+func SetRequiredTags(tags []string) {
+	requiredTags = tags
+}
+
+// RequiredTags returns the tag keys which every taggable resource must carry
+func RequiredTags() []string {
+	return requiredTags
+}
+
+// EffectiveTags returns the tags a resource will carry once the default_tags of its provider are applied. Tags set on
+// the resource take precedence over the defaults. The second return value is false if the tags can't be resolved.
+func EffectiveTags(resourceBlock block.Block) (map[string]cty.Value, bool) {
+	tagsAttr := resourceBlock.GetAttribute("tags")
+	if tagsAttr.IsNotNil() && tagsAttr.IsNotResolvable() {
+		return nil, false
+	}
+
+	tags := make(map[string]cty.Value)
+	if defaultTags, ok := resourceBlock.Context().DefaultTags(block.ProviderRef(resourceBlock)); ok {
+		if defaultTags == cty.NilVal || !defaultTags.IsWhollyKnown() {
+			return nil, false
+		}
+		if !defaultTags.IsNull() && (defaultTags.Type().IsObjectType() || defaultTags.Type().IsMapType()) {
+			for key, value := range defaultTags.AsValueMap() {
+				tags[key] = value
+			}
+		}
+	}
+	for key, value := range tagsAttr.MapValue() {
+		tags[key] = value
+	}
+	return tags, true
+}
+
+// MissingTags returns the required tag keys which the resource won't carry. The second return value is false if the
+// tags can't be resolved.
+func MissingTags(resourceBlock block.Block) ([]string, bool) {
+	tags, ok := EffectiveTags(resourceBlock)
+	if !ok {
+		return nil, false
+	}
+
+	var missing []string
+	for _, key := range requiredTags {
+		if _, ok := tags[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	return missing, true
+}
@@ -0,0 +1,68 @@
+package tagging_test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EffectiveTags(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+provider "aws" {
+	default_tags {
+		tags = {
+			Environment = "production"
+			Owner       = "platform-team"
+		}
+	}
+}
+
+provider "aws" {
+	alias = "east"
+
+	default_tags {
+		tags = {
+			Region = "us-east-1"
+		}
+	}
+}
+
+resource "aws_s3_bucket" "default" {
+	tags = {
+		Owner = "data-team"
+	}
+}
+
+resource "aws_s3_bucket" "east" {
+	provider = aws.east
+}
+
+resource "google_storage_bucket" "other" {
+}
+`, ".tf", t)
+	require.Len(t, modules, 1)
+
+	tagValues := func(name string) map[string]string {
+		for _, b := range modules[0].GetBlocks() {
+			if b.FullName() != name {
+				continue
+			}
+			tags, ok := tagging.EffectiveTags(b)
+			require.True(t, ok)
+			values := make(map[string]string)
+			for key, value := range tags {
+				values[key] = value.AsString()
+			}
+			return values
+		}
+		t.Fatalf("block %s not found", name)
+		return nil
+	}
+
+	assert.Equal(t, map[string]string{"Environment": "production", "Owner": "data-team"}, tagValues("aws_s3_bucket.default"))
+	assert.Equal(t, map[string]string{"Region": "us-east-1"}, tagValues("aws_s3_bucket.east"))
+	assert.Empty(t, tagValues("google_storage_bucket.other"))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func Test_AttributeStartsWith(t *testing.T) {
@@ -271,6 +272,46 @@ resource "aws_security_group" "my-security_group" {
 			checkValue:     "foo",
 			expectedResult: false,
 		},
+		{
+			name: "set of actions contains wildcard",
+			source: `
+resource "aws_iam_policy" "my-policy" {
+	actions = toset(["s3:GetObject", "*"])
+}`,
+			checkAttribute: "actions",
+			checkValue:     "*",
+			expectedResult: true,
+		},
+		{
+			name: "number attribute does not contain string",
+			source: `
+resource "aws_db_instance" "my-db" {
+	port = 5432
+}`,
+			checkAttribute: "port",
+			checkValue:     "54",
+			expectedResult: false,
+		},
+		{
+			name: "unresolvable attribute does not contain string",
+			source: `
+resource "aws_iam_policy" "my-policy" {
+	actions = var.actions
+}`,
+			checkAttribute: "actions",
+			checkValue:     "*",
+			expectedResult: false,
+		},
+		{
+			name: "tuple with mixed element types contains string",
+			source: `
+resource "aws_iam_policy" "my-policy" {
+	actions = [1, true, "*"]
+}`,
+			checkAttribute: "actions",
+			checkValue:     "*",
+			expectedResult: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -284,6 +325,7 @@ resource "aws_security_group" "my-security_group" {
 					attr := b.GetAttribute(test.checkAttribute)
 					if test.ignoreCase {
 						assert.Equal(t, test.expectedResult, attr.Contains(test.checkValue, block.IgnoreCase))
+						assert.Equal(t, test.expectedResult, attr.ContainsIgnoreCase(test.checkValue))
 					} else {
 						assert.Equal(t, test.expectedResult, attr.Contains(test.checkValue))
 					}
@@ -415,6 +457,103 @@ resource "aws_security_group" "my-security_group" {
 	}
 }
 
+func Test_AttributeIsOneOf(t *testing.T) {
+	var tests = []struct {
+		name               string
+		source             string
+		checkAttribute     string
+		values             []string
+		isOneOf            bool
+		isOneOfIgnoreCase  bool
+		isNoneOf           bool
+		isNoneOfIgnoreCase bool
+	}{
+		{
+			name: "value is in the list",
+			source: `
+resource "aws_lb_listener" "my-listener" {
+	ssl_policy = "ELBSecurityPolicy-TLS-1-2-2017-01"
+}`,
+			checkAttribute:     "ssl_policy",
+			values:             []string{"ELBSecurityPolicy-TLS-1-2-2017-01", "ELBSecurityPolicy-FS-1-2-2019-08"},
+			isOneOf:            true,
+			isOneOfIgnoreCase:  true,
+			isNoneOf:           false,
+			isNoneOfIgnoreCase: false,
+		},
+		{
+			name: "value is in the list with a different case",
+			source: `
+resource "aws_lb_listener" "my-listener" {
+	protocol = "https"
+}`,
+			checkAttribute:     "protocol",
+			values:             []string{"HTTPS", "TLS"},
+			isOneOf:            false,
+			isOneOfIgnoreCase:  true,
+			isNoneOf:           true,
+			isNoneOfIgnoreCase: false,
+		},
+		{
+			name: "value is not in the list",
+			source: `
+resource "aws_lb_listener" "my-listener" {
+	protocol = "HTTP"
+}`,
+			checkAttribute:     "protocol",
+			values:             []string{"HTTPS", "TLS"},
+			isOneOf:            false,
+			isOneOfIgnoreCase:  false,
+			isNoneOf:           true,
+			isNoneOfIgnoreCase: true,
+		},
+		{
+			name: "value can't be resolved",
+			source: `
+variable "protocol" {
+}
+
+resource "aws_lb_listener" "my-listener" {
+	protocol = var.protocol
+}`,
+			checkAttribute:     "protocol",
+			values:             []string{"HTTPS", "TLS"},
+			isOneOf:            false,
+			isOneOfIgnoreCase:  false,
+			isNoneOf:           false,
+			isNoneOfIgnoreCase: false,
+		},
+		{
+			name: "value isn't a string",
+			source: `
+resource "aws_lb_listener" "my-listener" {
+	port = 443
+}`,
+			checkAttribute:     "port",
+			values:             []string{"443"},
+			isOneOf:            false,
+			isOneOfIgnoreCase:  false,
+			isNoneOf:           false,
+			isNoneOfIgnoreCase: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(test.source, ".tf", t)
+			for _, module := range modules {
+				for _, block := range module.GetResourcesByType("aws_lb_listener") {
+					attr := block.GetAttribute(test.checkAttribute)
+					assert.Equal(t, test.isOneOf, attr.IsOneOf(test.values...))
+					assert.Equal(t, test.isOneOfIgnoreCase, attr.IsOneOfIgnoreCase(test.values...))
+					assert.Equal(t, test.isNoneOf, attr.IsNoneOf(test.values...))
+					assert.Equal(t, test.isNoneOfIgnoreCase, attr.IsNoneOfIgnoreCase(test.values...))
+				}
+			}
+		})
+	}
+}
+
 func Test_AttributeIsEmpty(t *testing.T) {
 	var tests = []struct {
 		name           string
@@ -510,6 +649,42 @@ resource "aws_security_group_rule" "example" {
 			checkAttribute: "from_port",
 			expectedResult: false,
 		},
+		{
+			name: "null value is empty",
+			source: `
+resource "aws_sns_topic" "example" {
+  kms_master_key_id = null
+}`,
+			checkAttribute: "kms_master_key_id",
+			expectedResult: true,
+		},
+		{
+			name: "unresolvable reference is not empty",
+			source: `
+resource "aws_sns_topic" "example" {
+  kms_master_key_id = aws_kms_key.example.arn
+}`,
+			checkAttribute: "kms_master_key_id",
+			expectedResult: false,
+		},
+		{
+			name: "unresolvable interpolation is not empty",
+			source: `
+resource "aws_sns_topic" "example" {
+  kms_master_key_id = "${aws_kms_key.example.arn}"
+}`,
+			checkAttribute: "kms_master_key_id",
+			expectedResult: false,
+		},
+		{
+			name: "unresolvable function call is not empty",
+			source: `
+resource "aws_security_group_rule" "example" {
+  cidr_blocks = concat(var.cidrs, [])
+}`,
+			checkAttribute: "cidr_blocks",
+			expectedResult: false,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -617,6 +792,98 @@ resource "numerical_something" "my-bucket" {
 	}
 }
 
+func Test_AttributeNumericComparisons(t *testing.T) {
+	var tests = []struct {
+		name                 string
+		source               string
+		checkValue           interface{}
+		lessThan             bool
+		lessThanOrEqualTo    bool
+		greaterThan          bool
+		greaterThanOrEqualTo bool
+	}{
+		{
+			name: "integer equal to check value",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = 30
+}`,
+			checkValue:           30,
+			lessThanOrEqualTo:    true,
+			greaterThanOrEqualTo: true,
+		},
+		{
+			name: "float compared with an integer",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = 29.5
+}`,
+			checkValue:        30,
+			lessThan:          true,
+			lessThanOrEqualTo: true,
+		},
+		{
+			name: "integer compared with a float",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = 30
+}`,
+			checkValue:           29.9,
+			greaterThan:          true,
+			greaterThanOrEqualTo: true,
+		},
+		{
+			name: "unresolvable value",
+			source: `
+variable "value" {
+}
+
+resource "numerical_something" "my-resource" {
+	value = var.value
+}`,
+			checkValue: 30,
+		},
+		{
+			name: "null value",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = null
+}`,
+			checkValue: 30,
+		},
+		{
+			name: "string value",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = "30"
+}`,
+			checkValue: 30,
+		},
+		{
+			name: "non-numeric check value",
+			source: `
+resource "numerical_something" "my-resource" {
+	value = 30
+}`,
+			checkValue: "thirty",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(test.source, ".tf", t)
+			for _, module := range modules {
+				for _, block := range module.GetResourcesByType("numerical_something") {
+					attr := block.GetAttribute("value")
+					assert.Equal(t, test.lessThan, attr.LessThan(test.checkValue))
+					assert.Equal(t, test.lessThanOrEqualTo, attr.LessThanOrEqualTo(test.checkValue))
+					assert.Equal(t, test.greaterThan, attr.GreaterThan(test.checkValue))
+					assert.Equal(t, test.greaterThanOrEqualTo, attr.GreaterThanOrEqualTo(test.checkValue))
+				}
+			}
+		})
+	}
+}
+
 func Test_AttributeIsTrue(t *testing.T) {
 	var tests = []struct {
 		name           string
@@ -718,3 +985,258 @@ resource "boolean_something" "my-something" {
 		})
 	}
 }
+
+func Test_AttributeMapValue(t *testing.T) {
+	var tests = []struct {
+		name           string
+		source         string
+		checkAttribute string
+		checkKey       string
+		expectedLength int
+		expectedFound  bool
+		expectedValue  string
+	}{
+		{
+			name: "tag is present in map",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	tags = {
+		Environment = "production"
+		Owner       = "security"
+	}
+}`,
+			checkAttribute: "tags",
+			checkKey:       "Environment",
+			expectedLength: 2,
+			expectedFound:  true,
+			expectedValue:  "production",
+		},
+		{
+			name: "tag is missing from map",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	tags = {
+		Owner = "security"
+	}
+}`,
+			checkAttribute: "tags",
+			checkKey:       "Environment",
+			expectedLength: 1,
+			expectedFound:  false,
+		},
+		{
+			name: "attribute is not a map",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	tags = "Environment"
+}`,
+			checkAttribute: "tags",
+			checkKey:       "Environment",
+			expectedLength: 0,
+			expectedFound:  false,
+		},
+		{
+			name: "attribute is not resolvable",
+			source: `
+resource "aws_s3_bucket" "my-bucket" {
+	tags = var.tags
+}`,
+			checkAttribute: "tags",
+			checkKey:       "Environment",
+			expectedLength: 0,
+			expectedFound:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(test.source, ".tf", t)
+			for _, module := range modules {
+				for _, block := range module.GetBlocks() {
+					if !block.HasChild(test.checkAttribute) {
+						t.FailNow()
+					}
+					attr := block.GetAttribute(test.checkAttribute)
+					assert.Len(t, attr.MapValue(), test.expectedLength)
+					val, found := attr.GetMapValue(test.checkKey)
+					assert.Equal(t, test.expectedFound, found)
+					if test.expectedFound {
+						assert.Equal(t, test.expectedValue, val.AsString())
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_AttributeTypedValues(t *testing.T) {
+	source := `
+resource "aws_db_instance" "my-db" {
+	name                = "my-db"
+	port                = 5432
+	allocated_storage   = 20.5
+	publicly_accessible = false
+	storage_encrypted   = "true"
+	kms_key_id          = var.kms_key_id
+}`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	for _, module := range modules {
+		for _, b := range module.GetBlocks() {
+			name, ok := b.GetAttribute("name").AsStringValue()
+			assert.True(t, ok)
+			assert.Equal(t, "my-db", name)
+
+			_, ok = b.GetAttribute("port").AsStringValue()
+			assert.False(t, ok)
+
+			port, ok := b.GetAttribute("port").AsIntValue()
+			assert.True(t, ok)
+			assert.Equal(t, 5432, port)
+
+			_, ok = b.GetAttribute("allocated_storage").AsIntValue()
+			assert.False(t, ok)
+
+			public, ok := b.GetAttribute("publicly_accessible").AsBoolValue()
+			assert.True(t, ok)
+			assert.False(t, public)
+
+			_, ok = b.GetAttribute("storage_encrypted").AsBoolValue()
+			assert.False(t, ok)
+
+			_, ok = b.GetAttribute("kms_key_id").AsStringValue()
+			assert.False(t, ok)
+
+			_, ok = b.GetAttribute("missing").AsIntValue()
+			assert.False(t, ok)
+		}
+	}
+}
+
+func Test_AttributeRegexMatches(t *testing.T) {
+	source := `
+resource "aws_s3_bucket" "my-bucket" {
+	bucket = "acme-logs"
+	acl    = var.acl
+	count  = 1
+}`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	for _, module := range modules {
+		for _, b := range module.GetBlocks() {
+			bucket := b.GetAttribute("bucket")
+			assert.True(t, bucket.RegexMatches("^acme-[a-z]+$"))
+			assert.False(t, bucket.RegexMatches("^corp-"))
+			assert.False(t, bucket.RegexMatches("^acme-[a-z+$"))
+			assert.True(t, bucket.RegexMatchesAny([]string{"^corp-", "-logs$"}))
+			assert.False(t, bucket.RegexMatchesAny([]string{"^corp-", "^org-"}))
+			assert.False(t, b.GetAttribute("acl").RegexMatches(".*"))
+			assert.False(t, b.GetAttribute("count").RegexMatches(".*"))
+		}
+	}
+}
+
+func Test_AttributeIsUnknownOfType(t *testing.T) {
+	var tests = []struct {
+		name            string
+		source          string
+		expectedUnknown bool
+		expectedValue   cty.Value
+	}{
+		{
+			name: "typed variable without default",
+			source: `
+variable "encrypted" {
+	type = bool
+}
+
+resource "aws_ebs_volume" "volume" {
+	encrypted = var.encrypted
+}`,
+			expectedUnknown: true,
+		},
+		{
+			name: "untyped variable without default",
+			source: `
+variable "encrypted" {
+}
+
+resource "aws_ebs_volume" "volume" {
+	encrypted = var.encrypted
+}`,
+			expectedUnknown: false,
+		},
+		{
+			name: "validation only allows true",
+			source: `
+variable "encrypted" {
+	type = bool
+	validation {
+		condition     = var.encrypted == true
+		error_message = "Volumes must be encrypted."
+	}
+}
+
+resource "aws_ebs_volume" "volume" {
+	encrypted = var.encrypted
+}`,
+			expectedValue: cty.True,
+		},
+		{
+			name: "validation allows both values",
+			source: `
+variable "encrypted" {
+	type = bool
+	validation {
+		condition     = var.encrypted || !var.encrypted
+		error_message = "Always valid."
+	}
+}
+
+resource "aws_ebs_volume" "volume" {
+	encrypted = var.encrypted
+}`,
+			expectedUnknown: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(test.source, ".tf", t)
+			for _, module := range modules {
+				for _, block := range module.GetResourcesByType("aws_ebs_volume") {
+					attr := block.GetAttribute("encrypted")
+					assert.Equal(t, test.expectedUnknown, attr.IsUnknownOfType(cty.Bool))
+					assert.False(t, attr.IsUnknownOfType(cty.String))
+					if test.expectedValue != cty.NilVal {
+						assert.True(t, attr.Value().RawEquals(test.expectedValue))
+					} else {
+						assert.True(t, attr.IsNotResolvable())
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_AttributeIsUnknownOfTypeTakesConditionalBranch(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+variable "enabled" {
+	type = bool
+}
+
+variable "encrypted" {
+	type = bool
+}
+
+resource "aws_ebs_volume" "volume" {
+	encrypted = var.enabled ? true : var.encrypted
+}`, ".tf", t)
+	var found bool
+	for _, module := range modules {
+		for _, b := range module.GetResourcesByType("aws_ebs_volume") {
+			assert.True(t, b.GetAttribute("encrypted").IsUnknownOfType(cty.Bool))
+			assert.False(t, b.WithConditionalBranch(true).GetAttribute("encrypted").IsUnknownOfType(cty.Bool))
+			assert.True(t, b.WithConditionalBranch(false).GetAttribute("encrypted").IsUnknownOfType(cty.Bool))
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
@@ -3,8 +3,10 @@ package test
 import (
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_IsPresentCheckOnBlock(t *testing.T) {
@@ -137,3 +139,142 @@ resource "aws_s3_bucket" "my-bucket" {
 		})
 	}
 }
+
+func Test_GetReferencedBlocksFromList(t *testing.T) {
+	source := `
+resource "aws_security_group" "web" {
+	description = "web"
+}
+
+resource "aws_security_group" "ssh" {
+	description = "ssh"
+}
+
+resource "aws_instance" "server" {
+	vpc_security_group_ids = [aws_security_group.web.id, aws_security_group.ssh.id]
+}
+`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	require.Len(t, modules, 1)
+	module := modules[0]
+
+	instances := module.GetResourcesByType("aws_instance")
+	require.Len(t, instances, 1)
+	attr := instances[0].GetAttribute("vpc_security_group_ids")
+
+	referenced := module.GetReferencedBlocks(attr)
+	require.Len(t, referenced, 2)
+	assert.Equal(t, "aws_security_group.web", referenced[0].FullName())
+	assert.Equal(t, "aws_security_group.ssh", referenced[1].FullName())
+
+	first, err := module.GetReferencedBlock(attr)
+	require.NoError(t, err)
+	assert.Equal(t, "aws_security_group.web", first.FullName())
+}
+
+func Test_GetBlocksByTypeRecursive(t *testing.T) {
+	source := `
+variable "rules" {
+	default = ["a", "b"]
+}
+
+resource "aws_s3_bucket" "my-bucket" {
+	server_side_encryption_configuration {
+		rule {
+			apply_server_side_encryption_by_default {
+				sse_algorithm = "aws:kms"
+			}
+		}
+	}
+	lifecycle_rule {
+		dynamic "transition" {
+			for_each = var.rules
+			content {
+				storage_class = transition.value
+			}
+		}
+	}
+}`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	require.Len(t, modules, 1)
+	buckets := modules[0].GetResourcesByType("aws_s3_bucket")
+	require.Len(t, buckets, 1)
+	bucket := buckets[0]
+
+	all := bucket.AllBlocksRecursive()
+	assert.Equal(t, bucket, all[0])
+
+	defaults := bucket.GetBlocksByTypeRecursive("apply_server_side_encryption_by_default")
+	require.Len(t, defaults, 1)
+	assert.Equal(t, "aws:kms", defaults[0].GetAttribute("sse_algorithm").Value().AsString())
+
+	transitions := bucket.GetBlocksByTypeRecursive("transition")
+	assert.Len(t, transitions, 2)
+
+	assert.Empty(t, bucket.GetBlocksByTypeRecursive("aws_s3_bucket"))
+}
+
+func Test_BlockIsEmpty(t *testing.T) {
+	source := `
+resource "aws_s3_bucket" "my-bucket" {
+	versioning {}
+
+	logging {
+		target_bucket = "logs"
+	}
+
+	lifecycle_rule {
+		transition {}
+	}
+}`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	require.Len(t, modules, 1)
+	buckets := modules[0].GetResourcesByType("aws_s3_bucket")
+	require.Len(t, buckets, 1)
+	bucket := buckets[0]
+
+	assert.False(t, bucket.IsEmpty())
+	assert.True(t, bucket.GetBlock("versioning").IsEmpty())
+	assert.False(t, bucket.GetBlock("logging").IsEmpty())
+	assert.False(t, bucket.GetBlock("lifecycle_rule").IsEmpty())
+	assert.True(t, bucket.GetBlock("lifecycle_rule").GetBlock("transition").IsEmpty())
+	assert.False(t, bucket.GetBlock("website").IsEmpty())
+}
+
+func Test_GetFirstMatchingBlock(t *testing.T) {
+	source := `
+resource "aws_security_group" "web" {
+	ingress {
+		from_port = 443
+		to_port   = 443
+	}
+
+	ingress {
+		from_port = 22
+		to_port   = 22
+	}
+
+	ingress {
+		from_port = 22
+		to_port   = 23
+	}
+}`
+	modules := testutil.CreateModulesFromSource(source, ".tf", t)
+	require.Len(t, modules, 1)
+	groups := modules[0].GetResourcesByType("aws_security_group")
+	require.Len(t, groups, 1)
+
+	ssh, found := groups[0].GetFirstMatchingBlock("ingress", func(ingress block.Block) bool {
+		return ingress.GetAttribute("from_port").Equals(22)
+	})
+	require.True(t, found)
+	assert.True(t, ssh.GetAttribute("to_port").Equals(22))
+
+	rdp, found := groups[0].GetFirstMatchingBlock("ingress", func(ingress block.Block) bool {
+		return ingress.GetAttribute("from_port").Equals(3389)
+	})
+	assert.False(t, found)
+	assert.True(t, rdp.IsNil())
+
+	assert.Len(t, groups[0].GetBlocks("ingress"), 3)
+}
@@ -0,0 +1,40 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ConcurrentScanIsDeterministic(t *testing.T) {
+	var source strings.Builder
+	for i := 0; i < 50; i++ {
+		source.WriteString(fmt.Sprintf(`
+resource "aws_security_group_rule" "rule-%d" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_s3_bucket" "bucket-%d" {
+}
+`, i, i))
+	}
+
+	modules := testutil.CreateModulesFromSource(source.String(), ".tf", t)
+	sequential := scanner.New(scanner.OptionConcurrency(1)).Scan(modules)
+	require.NotEmpty(t, sequential)
+
+	for i := 0; i < 5; i++ {
+		concurrent := scanner.New(scanner.OptionConcurrency(8)).Scan(modules)
+		require.Len(t, concurrent, len(sequential))
+		for j := range sequential {
+			assert.Equal(t, sequential[j].RuleID, concurrent[j].RuleID)
+			assert.Equal(t, sequential[j].Range(), concurrent[j].Range())
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ConditionalExpressions(t *testing.T) {
+	expectedCode := "aws-rds-no-public-db-access"
+
+	var tests = []struct {
+		name                  string
+		source                string
+		conservative          bool
+		mustIncludeResultCode string
+		mustExcludeResultCode string
+	}{
+		{
+			name: "resolvable condition selecting the insecure result fails check",
+			source: `
+variable "is_prod" {
+	default = false
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "resolvable condition selecting the secure result passes check",
+			source: `
+variable "is_prod" {
+	default = true
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "condition from a local selecting the insecure result fails check",
+			source: `
+locals {
+	environment = "dev"
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = local.environment == "prod" ? false : true
+}
+`,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "unknown condition passes check by default",
+			source: `
+variable "is_prod" {
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : true
+}
+`,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "unknown condition with an insecure result fails conservative check",
+			source: `
+variable "is_prod" {
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : true
+}
+`,
+			conservative:          true,
+			mustIncludeResultCode: expectedCode,
+		},
+		{
+			name: "unknown condition with only secure results passes conservative check",
+			source: `
+variable "is_prod" {
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : false
+}
+`,
+			conservative:          true,
+			mustExcludeResultCode: expectedCode,
+		},
+		{
+			name: "nested unknown condition with an insecure result fails conservative check",
+			source: `
+variable "is_prod" {
+}
+
+variable "is_internal" {
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible = var.is_prod ? false : (var.is_internal ? false : true)
+}
+`,
+			conservative:          true,
+			mustIncludeResultCode: expectedCode,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var options []scanner.Option
+			if test.conservative {
+				options = append(options, scanner.OptionConservative())
+			}
+			results := testutil.ScanHCL(test.source, t, options...)
+			testutil.AssertCheckCode(t, test.mustIncludeResultCode, test.mustExcludeResultCode, results)
+		})
+	}
+}
+
+func Test_ConservativeCheckReportsEachFindingOnce(t *testing.T) {
+	results := testutil.ScanHCL(`
+variable "retention" {
+}
+
+resource "aws_db_instance" "example" {
+	publicly_accessible     = true
+	backup_retention_period = var.retention ? 1 : 0
+}
+`, t, scanner.OptionConservative())
+
+	var public, retention int
+	for _, res := range results {
+		switch res.RuleID {
+		case "aws-rds-no-public-db-access":
+			public++
+		case "aws-rds-backup-retention-specified":
+			retention++
+		}
+	}
+	assert.Equal(t, 1, public)
+	assert.Equal(t, 1, retention)
+}
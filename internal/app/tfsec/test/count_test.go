@@ -175,6 +175,38 @@ variable "trust-sg-rules" {
 			`,
 			mustExcludeResultCode: "aws-vpc-add-description-to-security-group",
 		},
+		{
+			name: "for_each expands instances with each.value",
+			source: `
+resource "aws_security_group_rule" "rules" {
+	for_each = toset(["10.0.0.0/16", "0.0.0.0/0"])
+	description = "rule ${each.key}"
+	type = "ingress"
+	cidr_blocks = [each.value]
+}
+`,
+			mustIncludeResultCode: "aws-vpc-no-public-ingress-sgr",
+		},
+		{
+			name: "for_each over empty map produces no instances",
+			source: `
+resource "aws_default_vpc" "this" {
+	for_each = {}
+}
+`,
+			mustExcludeResultCode: "aws-vpc-no-default-vpc",
+		},
+		{
+			name: "unresolvable for_each falls back to the template block",
+			source: `
+variable "vpcs" {
+}
+resource "aws_default_vpc" "this" {
+	for_each = var.vpcs
+}
+`,
+			mustIncludeResultCode: "aws-vpc-no-default-vpc",
+		},
 	}
 
 	for _, test := range tests {
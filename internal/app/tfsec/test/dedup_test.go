@@ -0,0 +1,119 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/rule"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repeatRule raises the same finding once per element of its repeated attribute
+var repeatRule = rule.Rule{
+	Provider:  provider.AWSProvider,
+	Service:   "service",
+	ShortCode: "repeat",
+	Documentation: rule.RuleDocumentation{
+		Summary: "An example check which repeats its findings.",
+	},
+	RequiredTypes:   []string{"resource"},
+	RequiredLabels:  []string{"repeated"},
+	DefaultSeverity: severity.Low,
+	CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+		for _, attr := range resourceBlock.GetAttributes() {
+			for range attr.ValueAsStrings() {
+				set.AddResult().
+					WithDescription("Resource '%s' has a repeated value", resourceBlock.FullName()).
+					WithAttribute(attr)
+			}
+		}
+	},
+}
+
+func Test_IdenticalResultsAreDeduplicated(t *testing.T) {
+	scanner.RegisterCheckRule(repeatRule)
+	defer scanner.DeregisterCheckRule(repeatRule)
+
+	results := testutil.ScanHCL(`
+resource "repeated" "this" {
+	first  = ["a", "b", "c"]
+	second = ["a", "b"]
+}
+`, t)
+
+	var found []result.Result
+	for _, res := range results {
+		if res.RuleID == repeatRule.ID() {
+			found = append(found, res)
+		}
+	}
+	require.Len(t, found, 2, "findings at different locations should not be merged")
+	assert.ElementsMatch(t, []int{3, 2}, []int{found[0].Occurrences, found[1].Occurrences})
+}
+
+// annotatedRule raises findings with the same description and range, which differ only in their annotations
+var annotatedRule = rule.Rule{
+	Provider:  provider.AWSProvider,
+	Service:   "service",
+	ShortCode: "annotated",
+	Documentation: rule.RuleDocumentation{
+		Summary: "An example check which annotates its findings.",
+	},
+	RequiredTypes:   []string{"resource"},
+	RequiredLabels:  []string{"annotated"},
+	DefaultSeverity: severity.Low,
+	CheckFunc: func(set result.Set, resourceBlock block.Block, _ block.Module) {
+		for _, annotation := range []string{"first statement", "second statement", "first statement"} {
+			set.AddResult().
+				WithDescription("Resource '%s' has a problem", resourceBlock.FullName()).
+				WithRangeAnnotation(annotation)
+		}
+	},
+}
+
+func Test_DifferentlyAnnotatedResultsAreNotDeduplicated(t *testing.T) {
+	scanner.RegisterCheckRule(annotatedRule)
+	defer scanner.DeregisterCheckRule(annotatedRule)
+
+	results := testutil.ScanHCL(`
+resource "annotated" "this" {
+}
+`, t)
+
+	occurrences := make(map[string]int)
+	hashCodes := make(map[string]struct{})
+	for _, res := range results {
+		if res.RuleID == annotatedRule.ID() {
+			occurrences[res.RangeAnnotation] = res.Occurrences
+			hashCodes[res.HashCode()] = struct{}{}
+		}
+	}
+	assert.Equal(t, map[string]int{"first statement": 2, "second statement": 0}, occurrences)
+	assert.Len(t, hashCodes, 2, "the results must be kept apart when duplicates are removed from the output too")
+}
+
+func Test_DeduplicationCanBeDisabled(t *testing.T) {
+	scanner.RegisterCheckRule(repeatRule)
+	defer scanner.DeregisterCheckRule(repeatRule)
+
+	results := testutil.ScanHCL(`
+resource "repeated" "this" {
+	first = ["a", "b", "c"]
+}
+`, t, scanner.OptionDisableDeduplication())
+
+	var count int
+	for _, res := range results {
+		if res.RuleID == repeatRule.ID() {
+			assert.Zero(t, res.Occurrences)
+			count++
+		}
+	}
+	assert.Equal(t, 3, count)
+}
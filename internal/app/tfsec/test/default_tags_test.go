@@ -0,0 +1,128 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/tagging"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DefaultTagsArePassedToModules(t *testing.T) {
+	tagging.SetRequiredTags([]string{"Environment", "Owner"})
+	defer tagging.SetRequiredTags(nil)
+
+	path := testutil.CreateTestFileWithModule(`
+provider "aws" {
+	default_tags {
+		tags = {
+			Environment = "production"
+			Owner       = "platform-team"
+		}
+	}
+}
+
+module "bucket" {
+	source = "../module"
+}
+`, `
+resource "aws_s3_bucket" "bucket" {
+	bucket = "my-bucket"
+}
+`)
+	modules, err := parser.New(path, parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+
+	results := scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+	testutil.AssertCheckCode(t, "", "aws-tagging-require-tags", results)
+}
+
+func Test_AliasedProviderPassedToModuleGovernsItsResources(t *testing.T) {
+	tagging.SetRequiredTags([]string{"Environment", "Owner"})
+	defer tagging.SetRequiredTags(nil)
+
+	path := testutil.CreateTestFileWithModule(`
+provider "aws" {
+	region = "us-east-1"
+}
+
+provider "aws" {
+	alias  = "west"
+	region = "us-west-2"
+	default_tags {
+		tags = {
+			Environment = "production"
+			Owner       = "platform-team"
+		}
+	}
+}
+
+module "bucket" {
+	source = "../module"
+	providers = {
+		aws = aws.west
+	}
+}
+`, `
+resource "aws_s3_bucket" "bucket" {
+	bucket = "my-bucket"
+}
+`)
+	modules, err := parser.New(path, parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+
+	var found bool
+	for _, module := range modules {
+		for _, bucket := range module.GetResourcesByType("aws_s3_bucket") {
+			providerBlock, ok := block.ProviderConfig(bucket)
+			require.True(t, ok)
+			assert.Equal(t, "us-west-2", providerBlock.GetAttribute("region").Value().AsString())
+			found = true
+		}
+	}
+	require.True(t, found)
+
+	results := scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+	testutil.AssertCheckCode(t, "", "aws-tagging-require-tags", results)
+}
+
+func Test_UnaliasedProviderIsNotPassedToModuleGivenOtherProviders(t *testing.T) {
+	tagging.SetRequiredTags([]string{"Environment", "Owner"})
+	defer tagging.SetRequiredTags(nil)
+
+	path := testutil.CreateTestFileWithModule(`
+provider "aws" {
+	default_tags {
+		tags = {
+			Environment = "production"
+			Owner       = "platform-team"
+		}
+	}
+}
+
+provider "aws" {
+	alias  = "west"
+	region = "us-west-2"
+}
+
+module "bucket" {
+	source = "../module"
+	providers = {
+		aws = aws.west
+	}
+}
+`, `
+resource "aws_s3_bucket" "bucket" {
+	bucket = "my-bucket"
+}
+`)
+	modules, err := parser.New(path, parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+
+	results := scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+	testutil.AssertCheckCode(t, "aws-tagging-require-tags", "", results)
+}
@@ -0,0 +1,77 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDirWithBrokenFile(t *testing.T) (string, string) {
+	dir, err := ioutil.TempDir(os.TempDir(), "tfsec")
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`
+resource "aws_s3_bucket" "bucket" {
+}
+`), 0600))
+	brokenFile := filepath.Join(dir, "broken.tf")
+	require.NoError(t, ioutil.WriteFile(brokenFile, []byte(`
+resource "aws_s3_bucket" "broken" {
+	bucket =
+}
+`), 0600))
+	return dir, brokenFile
+}
+
+func findDiagnostic(filename string) (diagnostics.Diagnostic, bool) {
+	for _, diagnostic := range diagnostics.All() {
+		if diagnostic.Location != nil && diagnostic.Location.Filename == filename {
+			return diagnostic, true
+		}
+	}
+	return diagnostics.Diagnostic{}, false
+}
+
+func Test_ParseErrorsAreReportedAsDiagnostics(t *testing.T) {
+	dir, brokenFile := createDirWithBrokenFile(t)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	modules, err := parser.New(dir).ParseDirectory()
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+	assert.Len(t, modules[0].GetResourcesByType("aws_s3_bucket"), 1)
+
+	diagnostic, ok := findDiagnostic(brokenFile)
+	require.True(t, ok)
+	assert.Equal(t, diagnostics.Error, diagnostic.Severity)
+	assert.Equal(t, 3, diagnostic.Location.StartLine)
+	assert.NotEmpty(t, diagnostic.Summary)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, scanner.New().Scan(modules), dir))
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+	assert.Contains(t, output.Errors, diagnostic)
+	assert.NotEmpty(t, output.Results)
+}
+
+func Test_ParseErrorsStopStrictParse(t *testing.T) {
+	dir, brokenFile := createDirWithBrokenFile(t)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	modules, err := parser.New(dir, parser.OptionStopOnHCLError()).ParseDirectory()
+	assert.Error(t, err)
+	assert.Empty(t, modules)
+
+	_, ok := findDiagnostic(brokenFile)
+	assert.True(t, ok)
+}
@@ -0,0 +1,102 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DynamicBlocksAreExpanded(t *testing.T) {
+	var tests = []struct {
+		name             string
+		source           string
+		expectedIngress  int
+		expectedCidrs    []string
+		expectOpenResult bool
+	}{
+		{
+			name: "dynamic block using default iterator",
+			source: `
+variable "cidrs" {
+	default = ["10.0.0.0/16", "0.0.0.0/0"]
+}
+
+resource "aws_security_group" "sg" {
+	description = "test"
+	dynamic "ingress" {
+		for_each = var.cidrs
+		content {
+			description = "ingress"
+			cidr_blocks = [ingress.value]
+		}
+	}
+}`,
+			expectedIngress:  2,
+			expectedCidrs:    []string{"10.0.0.0/16", "0.0.0.0/0"},
+			expectOpenResult: true,
+		},
+		{
+			name: "dynamic block using named iterator",
+			source: `
+variable "cidrs" {
+	default = ["10.0.0.0/16"]
+}
+
+resource "aws_security_group" "sg" {
+	description = "test"
+	dynamic "ingress" {
+		for_each = var.cidrs
+		iterator = rule
+		content {
+			description = "ingress"
+			cidr_blocks = [rule.value]
+		}
+	}
+}`,
+			expectedIngress: 1,
+			expectedCidrs:   []string{"10.0.0.0/16"},
+		},
+		{
+			name: "dynamic block with unresolvable collection is left in place",
+			source: `
+variable "cidrs" {
+}
+
+resource "aws_security_group" "sg" {
+	description = "test"
+	dynamic "ingress" {
+		for_each = var.cidrs
+		content {
+			description = "ingress"
+			cidr_blocks = [ingress.value]
+		}
+	}
+}`,
+			expectedIngress: 0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			modules := testutil.CreateModulesFromSource(test.source, ".tf", t)
+			groups := modules[0].GetResourcesByType("aws_security_group")
+			require.Len(t, groups, 1)
+
+			ingress := groups[0].GetBlocks("ingress")
+			require.Len(t, ingress, test.expectedIngress)
+			assert.Len(t, groups[0].GetBlocks("dynamic"), 1)
+			for i, cidr := range test.expectedCidrs {
+				assert.True(t, ingress[i].GetAttribute("cidr_blocks").Contains(cidr))
+			}
+
+			results := testutil.ScanHCL(test.source, t)
+			if test.expectOpenResult {
+				testutil.AssertCheckCode(t, "aws-vpc-no-public-ingress-sg", "", results)
+			} else {
+				testutil.AssertCheckCode(t, "", "aws-vpc-no-public-ingress-sg", results)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fingerprintOf(t *testing.T, source string, ruleID string) string {
+	for _, res := range testutil.ScanHCL(source, t) {
+		if res.RuleID == ruleID {
+			return res.Fingerprint()
+		}
+	}
+	t.Fatalf("no result for %s", ruleID)
+	return ""
+}
+
+func Test_FingerprintIgnoresFormattingAndLineShifts(t *testing.T) {
+	original := fingerprintOf(t, `
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, "aws-vpc-no-public-ingress-sgr")
+
+	moved := fingerprintOf(t, `
+resource "aws_s3_bucket" "unrelated" {
+}
+
+resource "aws_security_group_rule" "my-rule" {
+	type        = "ingress"
+	# open for the public load balancer
+	cidr_blocks = [ "0.0.0.0/0" ]
+}
+`, "aws-vpc-no-public-ingress-sgr")
+	assert.Equal(t, original, moved)
+
+	changed := fingerprintOf(t, `
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0", "10.0.0.0/8"]
+}
+`, "aws-vpc-no-public-ingress-sgr")
+	assert.NotEqual(t, original, changed)
+
+	renamed := fingerprintOf(t, `
+resource "aws_security_group_rule" "other-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, "aws-vpc-no-public-ingress-sgr")
+	assert.NotEqual(t, original, renamed)
+}
+
+func Test_FingerprintIsIncludedInJSONAndSARIF(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, results)
+
+	var jsonBuffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&jsonBuffer, results, ""))
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(jsonBuffer.Bytes(), &output))
+	require.Len(t, output.Results, len(results))
+	for i, res := range output.Results {
+		assert.Equal(t, results[i].Fingerprint(), res.FingerprintID)
+	}
+
+	var sarifBuffer bytes.Buffer
+	require.NoError(t, formatters.FormatSarif(&sarifBuffer, results, "/"))
+	var report struct {
+		Runs []struct {
+			Results []struct {
+				RuleID              string            `json:"ruleId"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	require.NoError(t, json.Unmarshal(sarifBuffer.Bytes(), &report))
+	require.Len(t, report.Runs, 1)
+	require.Len(t, report.Runs[0].Results, len(results))
+	for i, res := range report.Runs[0].Results {
+		assert.Equal(t, results[i].Fingerprint(), res.PartialFingerprints["tfsecFingerprint/v1"])
+	}
+
+	// the fingerprint can still be worked out for results read back from json output
+	reloaded := output.Results[0]
+	reloaded.FingerprintID = ""
+	assert.Equal(t, results[0].Fingerprint(), (&reloaded).Fingerprint())
+}
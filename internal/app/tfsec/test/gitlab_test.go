@@ -0,0 +1,57 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GitLabReportHasRequiredScanFields(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatGitLab(&buffer, results, ""))
+
+	var report struct {
+		Version string `json:"version"`
+		Scan    struct {
+			Analyzer struct {
+				ID     string `json:"id"`
+				Name   string `json:"name"`
+				Vendor struct {
+					Name string `json:"name"`
+				} `json:"vendor"`
+				Version string `json:"version"`
+			} `json:"analyzer"`
+			StartTime string `json:"start_time"`
+			EndTime   string `json:"end_time"`
+		} `json:"scan"`
+		Vulnerabilities []json.RawMessage `json:"vulnerabilities"`
+	}
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &report))
+
+	assert.Equal(t, "15.0.0", report.Version)
+	assert.Equal(t, "tfsec", report.Scan.Analyzer.ID)
+	assert.Equal(t, "tfsec", report.Scan.Analyzer.Name)
+	assert.Equal(t, "tfsec", report.Scan.Analyzer.Vendor.Name)
+	assert.NotEmpty(t, report.Scan.Analyzer.Version)
+
+	startTime, err := time.Parse("2006-01-02T15:04:05", report.Scan.StartTime)
+	require.NoError(t, err)
+	endTime, err := time.Parse("2006-01-02T15:04:05", report.Scan.EndTime)
+	require.NoError(t, err)
+	assert.False(t, endTime.Before(startTime))
+	assert.Len(t, report.Vulnerabilities, len(results))
+}
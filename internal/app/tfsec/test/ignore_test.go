@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
 
@@ -161,6 +162,167 @@ resource "aws_security_group_rule" "my-rule" {
 	assert.Len(t, results, 1)
 }
 
+func Test_IgnoreWithExpDateOfTodayThenIgnore(t *testing.T) {
+	results := testutil.ScanHCL(fmt.Sprintf(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:AWS006:exp:%s
+	description = "test security group rule"
+}
+`, time.Now().Format("2006-01-02")), t)
+	assert.Len(t, results, 0)
+}
+
+func Test_IgnoreWithExpDateCloseToExpiryThenIgnore(t *testing.T) {
+	results := testutil.ScanHCL(fmt.Sprintf(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:AWS006:exp:%s
+	description = "test security group rule"
+}
+`, time.Now().AddDate(0, 0, 3).Format("2006-01-02")), t)
+	assert.Len(t, results, 0)
+}
+
+func Test_IgnoreWithExpDateInWrongFormatThenDropTheIgnore(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:AWS006:exp:2221-1-2
+	description = "test security group rule"
+}
+`, t)
+	assert.Len(t, results, 1)
+}
+
+func Test_IgnoreRegion(t *testing.T) {
+	results := testutil.ScanHCL(`
+#tfsec:ignore-block-start:aws-vpc-*
+resource "aws_security_group_rule" "ignored" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"]
+	description = "test security group rule"
+}
+#tfsec:ignore-block-end
+
+resource "aws_security_group_rule" "reported" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"]
+	description = "test security group rule"
+}
+`, t)
+	require.Len(t, results, 1)
+	assert.Equal(t, "aws_security_group_rule.reported", results[0].ResourceName())
+}
+
+func Test_IgnoreRegionForDifferentRule(t *testing.T) {
+	results := testutil.ScanHCL(`
+// tfsec:ignore-block-start:aws-s3-*
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"]
+	description = "test security group rule"
+}
+// tfsec:ignore-block-end
+`, t)
+	assert.Len(t, results, 1)
+}
+
+func Test_IgnoreRegionWithoutEndCoversRestOfFile(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "reported" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"]
+	description = "test security group rule"
+}
+
+#tfsec:ignore-block-start:aws-vpc-no-public-ingress-sgr
+resource "aws_security_group_rule" "ignored" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"]
+	description = "test security group rule"
+}
+`, t)
+	require.Len(t, results, 1)
+	assert.Equal(t, "aws_security_group_rule.reported", results[0].ResourceName())
+}
+
+func Test_IgnoreWithoutJustificationWhenRequired(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:aws-vpc-no-public-ingress-sgr
+	description = "test security group rule"
+}
+`, t, scanner.OptionRequireIgnoreJustification())
+	assert.Len(t, results, 1)
+}
+
+func Test_IgnoreWithJustificationWhenRequired(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:aws-vpc-no-public-ingress-sgr "public load balancer"
+	description = "test security group rule"
+}
+`, t, scanner.OptionRequireIgnoreJustification())
+	assert.Len(t, results, 0)
+}
+
+func Test_IgnoreJustificationIsCaptured(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:aws-vpc-no-public-ingress-sgr "public load balancer"
+	description = "test security group rule"
+}
+`, t, scanner.OptionIncludeIgnored())
+	require.Len(t, results, 1)
+	assert.Equal(t, "public load balancer", results[0].Justification)
+}
+
+func Test_NoIgnoresReportsIgnoredFindings(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:aws-vpc-no-public-ingress-sgr
+	description = "test security group rule"
+}
+`, t, scanner.OptionNoIgnores())
+	require.Len(t, results, 1)
+	assert.Equal(t, result.Failed, results[0].Status)
+	assert.Empty(t, results[0].IgnoreDirective)
+}
+
+func Test_OnlyIgnoredListsSuppressingComments(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] # tfsec:ignore:aws-vpc-no-public-ingress-sgr "public load balancer"
+}
+
+// tfsec:ignore-block-start:aws-vpc-add-description-to-security-group
+resource "aws_security_group_rule" "other-rule" {
+    type        = "ingress"
+    cidr_blocks = ["10.0.0.0/16"]
+}
+// tfsec:ignore-block-end
+`, t, scanner.OptionOnlyIgnored())
+	require.Len(t, results, 2)
+	result.SortByLocation(results)
+
+	assert.Equal(t, "aws-vpc-no-public-ingress-sgr", results[0].RuleID)
+	assert.Equal(t, result.Ignored, results[0].Status)
+	assert.Equal(t, "tfsec:ignore:aws-vpc-no-public-ingress-sgr", results[0].IgnoreDirective)
+	assert.Equal(t, "public load balancer", results[0].Justification)
+
+	assert.Equal(t, "aws-vpc-add-description-to-security-group", results[1].RuleID)
+	assert.Equal(t, "tfsec:ignore-block-start:aws-vpc-add-description-to-security-group (line 7)", results[1].IgnoreDirective)
+}
+
 func Test_IgnoreAboveResourceBlockWithExpDateIfDateNotBreachedThenIgnoreIgnore(t *testing.T) {
 	results := testutil.ScanHCL(`
 #tfsec:ignore:AWS006:exp:2221-01-02
@@ -204,6 +366,9 @@ func Test_IgnoreInline(t *testing.T) {
 		metadata_options {
 		  http_tokens = "optional" # tfsec:ignore:aws-ec2-enforce-http-token-imds
 		}
+		root_block_device {
+		  encrypted = true
+		}
 	  }
 	  `, t)
 	assert.Len(t, results, 0)
@@ -221,6 +386,20 @@ resource "aws_security_group_rule" "my-rule" {
 	assert.Len(t, results, 1)
 }
 
+func Test_IgnoreWithMultipleWorkspaces(t *testing.T) {
+	source := `
+resource "aws_security_group_rule" "my-rule" {
+    type        = "ingress"
+    cidr_blocks = ["0.0.0.0/0"] #tfsec:ignore:aws-vpc-no-public-ingress-sgr:ws:dev,staging
+	description = "test security group rule"
+}
+`
+	assert.Len(t, testutil.ScanHCL(source, t, scanner.OptionWithWorkspaceName("dev")), 0)
+	assert.Len(t, testutil.ScanHCL(source, t, scanner.OptionWithWorkspaceName("staging")), 0)
+	assert.Len(t, testutil.ScanHCL(source, t, scanner.OptionWithWorkspaceName("prod")), 1)
+	assert.Len(t, testutil.ScanHCL(source, t), 1)
+}
+
 func TestBlockLevelIgnoresForAllRules(t *testing.T) {
 	for _, check := range scanner.GetRegisteredRules() {
 		for _, badExample := range check.Documentation.BadExample {
@@ -0,0 +1,70 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_JSONLinesOutputHasOneResultPerLine(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSONLines(&buffer, results, ""))
+
+	var lines int
+	scanner := bufio.NewScanner(&buffer)
+	for scanner.Scan() {
+		var res result.Result
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &res))
+		assert.Equal(t, results[lines].RuleID, res.RuleID)
+		assert.Equal(t, results[lines].Severity, res.Severity)
+		assert.Equal(t, results[lines].Range(), res.Location)
+		lines++
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, len(results), lines)
+}
+
+func Test_ResultHandlerIsGivenResultsAsBlocksAreChecked(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_security_group_rule" "first" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "second" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, ".tf", t)
+
+	var handled []result.Result
+	resources := make(map[string]bool)
+	s := scanner.New(scanner.OptionDisableDeduplication(), scanner.OptionWithResultHandler(func(results []result.Result) {
+		// each call holds the results of a single block
+		for _, res := range results {
+			assert.Equal(t, results[0].ResourceName(), res.ResourceName())
+		}
+		resources[results[0].ResourceName()] = true
+		handled = append(handled, results...)
+	}))
+	results := s.Scan(modules)
+	require.NotEmpty(t, results)
+	assert.Len(t, resources, 2)
+	assert.ElementsMatch(t, results, handled)
+}
@@ -1,9 +1,14 @@
 package test
 
 import (
+	"strings"
 	"testing"
 
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestScanningJSON(t *testing.T) {
@@ -79,6 +84,42 @@ func TestScanningJSON(t *testing.T) {
 			}`,
 			mustExcludeResultCode: "aws-vpc-no-public-ingress-sgr",
 		},
+		{
+			name: "check nested blocks are picked up in tf json configs",
+			source: `
+			{
+				"resource": {
+					"aws_s3_bucket": {
+						"bucket": {
+							"bucket": "my-bucket",
+							"versioning": {
+								"enabled": false
+							}
+						}
+					}
+				}
+			}`,
+			mustIncludeResultCode: "aws-s3-enable-versioning",
+		},
+		{
+			name: "check enabled nested blocks are respected in tf json configs",
+			source: `
+			{
+				"resource": {
+					"aws_s3_bucket": {
+						"bucket": {
+							"bucket": "my-bucket",
+							"versioning": [
+								{
+									"enabled": true
+								}
+							]
+						}
+					}
+				}
+			}`,
+			mustExcludeResultCode: "aws-s3-enable-versioning",
+		},
 	}
 
 	for _, test := range tests {
@@ -88,3 +129,54 @@ func TestScanningJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestScanningMixedHCLAndJSON(t *testing.T) {
+
+	fs, err := testutil.NewFilesystem()
+	require.NoError(t, err)
+	defer fs.Close()
+
+	require.NoError(t, fs.WriteTextFile("project/main.tf", `
+variable "ingress_cidr" {
+  default = "0.0.0.0/0"
+}
+
+resource "aws_security_group" "group" {
+  description = "example"
+}
+`))
+	require.NoError(t, fs.WriteTextFile("project/rules.tf.json", `{
+  "resource": {
+    "aws_security_group_rule": {
+      "rule": {
+        "type": "ingress",
+        "description": "example",
+        "security_group_id": "${aws_security_group.group.id}",
+        "cidr_blocks": ["${var.ingress_cidr}"]
+      }
+    }
+  }
+}
+`))
+
+	modules, err := parser.New(fs.RealPath("project/"), parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	ruleBlock := modules[0].GetResourcesByType("aws_security_group_rule")[0]
+	referenced, err := modules[0].GetReferencedBlock(ruleBlock.GetAttribute("security_group_id"))
+	require.NoError(t, err)
+	assert.Equal(t, "aws_security_group.group", referenced.FullName())
+
+	results := scanner.New().Scan(modules)
+	testutil.AssertCheckCode(t, "aws-vpc-no-public-ingress-sgr", "", results)
+
+	for _, result := range results {
+		if result.RuleID != "aws-vpc-no-public-ingress-sgr" {
+			continue
+		}
+		assert.True(t, strings.HasSuffix(result.Range().Filename, "rules.tf.json"))
+		assert.Equal(t, 8, result.Range().StartLine)
+		assert.Equal(t, 8, result.Range().EndLine)
+	}
+}
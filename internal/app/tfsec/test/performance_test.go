@@ -35,3 +35,38 @@ func BenchmarkCalculate(b *testing.B) {
 		_ = scanner.New().Scan(blocks)
 	}
 }
+
+func BenchmarkParseRepeatedModule(b *testing.B) {
+
+	fs, err := testutil.NewFilesystem()
+	if err != nil {
+		panic(err)
+	}
+	defer fs.Close()
+
+	var source string
+	for i := 0; i < 100; i++ {
+		source += fmt.Sprintf(`
+		module "something_%d" {
+			source = "../modules/problem"
+		}
+		`, i)
+	}
+	_ = fs.WriteTextFile("/project/main.tf", source)
+
+	for _, rule := range scanner.GetRegisteredRules() {
+		_ = fs.WriteTextFile(fmt.Sprintf("/modules/problem/%s.tf", rule.ID()), rule.Documentation.BadExample[0])
+	}
+
+	for _, enabled := range []bool{true, false} {
+		b.Run(fmt.Sprintf("cache=%t", enabled), func(b *testing.B) {
+			parser.SetParseCacheEnabled(enabled)
+			defer parser.SetParseCacheEnabled(true)
+			for i := 0; i < b.N; i++ {
+				if _, err := parser.New(fs.RealPath("/project"), parser.OptionStopOnHCLError()).ParseDirectory(); err != nil {
+					panic(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PlanFileResourcesAreScanned(t *testing.T) {
+	path := testutil.CreateTestFile("plan.json", `
+{
+	"format_version": "0.2",
+	"planned_values": {
+		"root_module": {
+			"resources": [
+				{
+					"address": "aws_security_group_rule.my-rule",
+					"mode": "managed",
+					"type": "aws_security_group_rule",
+					"name": "my-rule",
+					"values": {
+						"type": "ingress",
+						"cidr_blocks": ["0.0.0.0/0"],
+						"description": "testing"
+					}
+				}
+			],
+			"child_modules": [
+				{
+					"address": "module.storage",
+					"resources": [
+						{
+							"address": "module.storage.aws_s3_bucket.bucket[0]",
+							"mode": "managed",
+							"type": "aws_s3_bucket",
+							"name": "bucket",
+							"index": 0,
+							"values": {
+								"bucket": "my-bucket",
+								"versioning": [
+									{
+										"enabled": false
+									}
+								],
+								"server_side_encryption_configuration": []
+							}
+						}
+					]
+				}
+			]
+		}
+	}
+}
+`)
+
+	modules, err := parser.LoadPlanFile(path)
+	require.NoError(t, err)
+	require.Len(t, modules, 1)
+
+	blocks := modules[0].GetBlocks()
+	require.Len(t, blocks, 2)
+	assert.Equal(t, "aws_security_group_rule.my-rule", blocks[0].FullName())
+	assert.Equal(t, "module.storage:aws_s3_bucket.bucket[0]", blocks[1].FullName())
+	assert.True(t, blocks[1].GetBlock("versioning").GetAttribute("enabled").IsFalse())
+	assert.True(t, blocks[1].MissingChild("server_side_encryption_configuration"))
+
+	results := scanner.New().Scan(modules)
+	testutil.AssertCheckCode(t, "aws-vpc-no-public-ingress-sgr", "", results)
+	testutil.AssertCheckCode(t, "aws-s3-enable-versioning", "", results)
+	testutil.AssertCheckCode(t, "aws-s3-enable-bucket-encryption", "", results)
+}
@@ -0,0 +1,28 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+)
+
+func Test_PolicyDocumentsAreResolvedForPolicyChecks(t *testing.T) {
+	results := testutil.ScanHCL(`
+data "aws_iam_policy_document" "public" {
+  statement {
+    actions = ["ecr:GetDownloadUrlForLayer", "ecr:BatchGetImage"]
+
+    principals {
+      type        = "AWS"
+      identifiers = ["*"]
+    }
+  }
+}
+
+resource "aws_ecr_repository_policy" "public" {
+  repository = "example"
+  policy     = data.aws_iam_policy_document.public.json
+}
+`, t)
+	testutil.AssertCheckCode(t, "aws-ecr-no-public-access", "", results)
+}
@@ -0,0 +1,37 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/provider"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_FilterProviders(t *testing.T) {
+	source := `
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "google_compute_firewall" "my-firewall" {
+	source_ranges = ["0.0.0.0/0"]
+}
+`
+	unfiltered := testutil.ScanHCL(source, t)
+	providers := make(map[provider.Provider]bool)
+	for _, res := range unfiltered {
+		providers[res.RuleProvider] = true
+	}
+	require.True(t, providers[provider.AWSProvider])
+	require.True(t, providers[provider.GoogleProvider])
+
+	filtered := testutil.ScanHCL(source, t, scanner.OptionFilterProviders([]string{"AWS"}))
+	require.NotEmpty(t, filtered)
+	for _, res := range filtered {
+		assert.Equal(t, provider.AWSProvider, res.RuleProvider)
+	}
+}
@@ -0,0 +1,81 @@
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ruleDocsSource = `
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`
+
+func assertRuleDocs(t *testing.T, res result.Result) {
+	r, err := scanner.GetRuleById(res.RuleID)
+	require.NoError(t, err)
+	require.NotNil(t, res.RuleDocs)
+	assert.Equal(t, r.Documentation.Summary, res.RuleDocs.Summary)
+	assert.Equal(t, strings.TrimSpace(r.Documentation.Explanation), res.RuleDocs.Explanation)
+	assert.Equal(t, r.Documentation.Impact, res.RuleDocs.Impact)
+	assert.Equal(t, r.Documentation.Resolution, res.RuleDocs.Resolution)
+}
+
+func Test_JSONOutputIncludesRuleDocsWhenRequested(t *testing.T) {
+	results := testutil.ScanHCL(ruleDocsSource, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, results, "", formatters.IncludeRuleDocs))
+
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+	require.Len(t, output.Results, len(results))
+	for _, res := range output.Results {
+		assertRuleDocs(t, res)
+	}
+
+	// the results passed to the formatter are left as they were
+	for _, res := range results {
+		assert.Nil(t, res.RuleDocs)
+	}
+}
+
+func Test_JSONOutputOmitsRuleDocsByDefault(t *testing.T) {
+	results := testutil.ScanHCL(ruleDocsSource, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, results, ""))
+	assert.NotContains(t, buffer.String(), "rule_docs")
+}
+
+func Test_JSONLinesOutputIncludesRuleDocsWhenRequested(t *testing.T) {
+	results := testutil.ScanHCL(ruleDocsSource, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSONLines(&buffer, results, "", formatters.IncludeRuleDocs))
+
+	var lines int
+	lineScanner := bufio.NewScanner(&buffer)
+	for lineScanner.Scan() {
+		var res result.Result
+		require.NoError(t, json.Unmarshal(lineScanner.Bytes(), &res))
+		assertRuleDocs(t, res)
+		lines++
+	}
+	require.NoError(t, lineScanner.Err())
+	assert.Equal(t, len(results), lines)
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/coverage"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/diagnostics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/metrics"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scancache"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const scanCacheSource = `
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`
+
+// scanForCache scans the source and caches the results, returning the scanned file and the cache directory
+func scanForCache(t *testing.T) (string, string) {
+	results := testutil.ScanHCL(scanCacheSource, t)
+	require.NotEmpty(t, results)
+	tfFile := results[0].Range().Filename
+	cacheDir := t.TempDir()
+	inputs := scancache.Inputs{Files: []string{tfFile}, Dirs: []string{filepath.Dir(tfFile)}}
+	require.NoError(t, scancache.Save(cacheDir, "key", inputs, results))
+	return tfFile, cacheDir
+}
+
+func Test_ScanCacheRestoresResults(t *testing.T) {
+	results := testutil.ScanHCL(scanCacheSource, t)
+	require.NotEmpty(t, results)
+	tfFile := results[0].Range().Filename
+
+	files, dirs := parser.Inputs()
+	assert.Contains(t, files, tfFile)
+	assert.Contains(t, dirs, filepath.Dir(tfFile))
+
+	cacheDir := t.TempDir()
+	inputs := scancache.Inputs{Files: []string{tfFile}, Dirs: []string{filepath.Dir(tfFile)}}
+	require.NoError(t, scancache.Save(cacheDir, "key", inputs, results))
+
+	restored, found := scancache.Load(cacheDir, "key")
+	require.True(t, found)
+	require.Len(t, restored, len(results))
+	for i, res := range results {
+		assert.Equal(t, res.RuleID, restored[i].RuleID)
+		assert.Equal(t, res.Description, restored[i].Description)
+		assert.Equal(t, res.Severity, restored[i].Severity)
+		assert.Equal(t, res.Range(), restored[i].Range())
+		assert.Equal(t, res.RangeAnnotation, restored[i].RangeAnnotation)
+		assert.Equal(t, res.HashCode(), restored[i].HashCode())
+		assert.Equal(t, res.ResourceName(), restored[i].ResourceName())
+		assert.Empty(t, restored[i].Blocks())
+	}
+
+	_, found = scancache.Load(cacheDir, "other-key")
+	assert.False(t, found)
+	_, found = scancache.Load(t.TempDir(), "key")
+	assert.False(t, found)
+}
+
+func Test_ScanCacheIsNotUsedWhenAFileChanges(t *testing.T) {
+	tfFile, cacheDir := scanForCache(t)
+	_, found := scancache.Load(cacheDir, "key")
+	require.True(t, found)
+
+	require.NoError(t, ioutil.WriteFile(tfFile, []byte(scanCacheSource+"\n# changed\n"), 0600))
+	_, found = scancache.Load(cacheDir, "key")
+	assert.False(t, found)
+}
+
+func Test_ScanCacheIsNotUsedWhenAFileIsAdded(t *testing.T) {
+	tfFile, cacheDir := scanForCache(t)
+	_, found := scancache.Load(cacheDir, "key")
+	require.True(t, found)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(filepath.Dir(tfFile), "extra.tf"), []byte(`variable "extra" {}`), 0600))
+	_, found = scancache.Load(cacheDir, "key")
+	assert.False(t, found)
+}
+
+func Test_ScanCacheIsNotUsedWhenAMissingFileIsCreated(t *testing.T) {
+	cacheDir := t.TempDir()
+	missing := filepath.Join(t.TempDir(), "modules.json")
+	require.NoError(t, scancache.Save(cacheDir, "key", scancache.Inputs{Files: []string{missing}}, nil))
+	_, found := scancache.Load(cacheDir, "key")
+	require.True(t, found)
+
+	require.NoError(t, ioutil.WriteFile(missing, []byte(`{"Modules": []}`), 0600))
+	_, found = scancache.Load(cacheDir, "key")
+	assert.False(t, found)
+}
+
+func formatJSONForCache(t *testing.T, results []result.Result) formatters.JSONOutput {
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, results, ""))
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+	// the only part of the output which is expected to differ between runs
+	output.Summary.DurationSeconds = 0
+	return output
+}
+
+func Test_CachedScanHasTheSameJSONOutput(t *testing.T) {
+	coverage.Reset()
+	diagnostics.Reset()
+	defer coverage.Reset()
+	defer diagnostics.Reset()
+
+	dir := t.TempDir()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(scanCacheSource), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "bad.tf"), []byte(`
+resource "aws_s3_bucket" "broken" {
+	acl = 
+}
+`), 0600))
+
+	modules, err := parser.New(dir).ParseDirectory()
+	require.NoError(t, err)
+	results := scanner.New(scanner.OptionTrackPassed()).Scan(modules)
+	first := formatJSONForCache(t, results)
+	require.NotEmpty(t, first.Passed)
+	require.NotEmpty(t, first.Skipped)
+	require.NotEmpty(t, first.Errors)
+
+	cacheDir := t.TempDir()
+	files, dirs := parser.Inputs()
+	require.NoError(t, scancache.Save(cacheDir, "key", scancache.Inputs{Files: files, Dirs: dirs}, results))
+
+	// a later run starts with nothing recorded
+	coverage.Reset()
+	diagnostics.Reset()
+	counts := metrics.CountSummary()
+	metrics.ResetCounts()
+	defer func() {
+		metrics.ResetCounts()
+		for count, value := range counts {
+			metrics.Add(count, value)
+		}
+	}()
+
+	restored, found := scancache.Load(cacheDir, "key")
+	require.True(t, found)
+	assert.Equal(t, first, formatJSONForCache(t, restored))
+}
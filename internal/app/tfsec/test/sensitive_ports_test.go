@@ -0,0 +1,144 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/stretchr/testify/assert"
+)
+
+func failedRuleIDs(results []result.Result) []string {
+	var ruleIDs []string
+	for _, res := range results {
+		if res.Status == result.Failed {
+			ruleIDs = append(ruleIDs, res.RuleID)
+		}
+	}
+	return ruleIDs
+}
+
+// the sensitive port rules report alongside the open ingress rules, which keep reporting so existing ignores,
+// baselines and rule selections still apply
+func Test_SensitivePortOpenToInternetIsReportedOnceBySensitivePortRules(t *testing.T) {
+	var tests = []struct {
+		name     string
+		source   string
+		expected []string
+	}{
+		{
+			name: "security group",
+			source: `
+resource "aws_security_group" "sg" {
+	description = "ssh"
+	ingress {
+		description = "ssh"
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+`,
+			expected: []string{"aws-vpc-no-public-sensitive-ports", "aws-vpc-no-public-ingress-sg"},
+		},
+		{
+			name: "security group attached to an instance",
+			source: `
+resource "aws_security_group" "sg" {
+	description = "ssh"
+	ingress {
+		description = "ssh"
+		from_port   = 22
+		to_port     = 22
+		protocol    = "tcp"
+		cidr_blocks = ["0.0.0.0/0"]
+	}
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+	metadata_options {
+		http_tokens = "required"
+	}
+	root_block_device {
+		encrypted = true
+	}
+}
+`,
+			expected: []string{"aws-ec2-no-public-ingress-sensitive-ports", "aws-vpc-no-public-ingress-sg"},
+		},
+		{
+			name: "security group rule attached to an instance",
+			source: `
+resource "aws_security_group" "sg" {
+	description = "ssh"
+}
+
+resource "aws_security_group_rule" "ssh" {
+	type              = "ingress"
+	description       = "ssh"
+	security_group_id = aws_security_group.sg.id
+	from_port         = 22
+	to_port           = 22
+	protocol          = "tcp"
+	cidr_blocks       = ["0.0.0.0/0"]
+}
+
+resource "aws_instance" "web" {
+	vpc_security_group_ids = [aws_security_group.sg.id]
+	metadata_options {
+		http_tokens = "required"
+	}
+	root_block_device {
+		encrypted = true
+	}
+}
+`,
+			expected: []string{"aws-ec2-no-public-ingress-sensitive-ports", "aws-vpc-no-public-ingress-sgr"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			results := testutil.ScanHCL(test.source, t)
+			assert.ElementsMatch(t, test.expected, failedRuleIDs(results))
+		})
+	}
+}
+
+func Test_OpenIngressToSensitivePortIsReportedWhenSensitivePortRuleIsExcluded(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "ssh" {
+	type        = "ingress"
+	description = "ssh"
+	from_port   = 22
+	to_port     = 22
+	protocol    = "tcp"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t, scanner.OptionExcludeRules([]string{"aws-vpc-no-public-sensitive-ports"}))
+	assert.Equal(t, []string{"aws-vpc-no-public-ingress-sgr"}, failedRuleIDs(results))
+}
+
+func Test_SensitivePortExposureAnnotatesPortAttributes(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "ssh" {
+	type        = "ingress"
+	from_port   = 22
+	to_port     = 22
+	protocol    = "tcp"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+
+	var found bool
+	for _, res := range results {
+		if res.RuleID == "aws-vpc-no-public-sensitive-ports" {
+			found = true
+			assert.Equal(t, "port(s) 22 are open to the internet by from_port on line 4, to_port on line 5", res.RangeAnnotation)
+		}
+	}
+	assert.True(t, found)
+}
@@ -0,0 +1,64 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sortSource = `
+resource "aws_security_group_rule" "second" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_security_group_rule" "first" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+
+resource "aws_sns_topic" "topic" {
+}
+`
+
+func Test_SortByLocation(t *testing.T) {
+	results := testutil.ScanHCL(sortSource, t)
+	require.Greater(t, len(results), 2)
+
+	result.SortByLocation(results)
+	for i := 1; i < len(results); i++ {
+		previous, current := results[i-1].Range(), results[i].Range()
+		require.Equal(t, previous.Filename, current.Filename)
+		assert.LessOrEqual(t, previous.StartLine, current.StartLine)
+		if previous.StartLine == current.StartLine {
+			assert.LessOrEqual(t, results[i-1].RuleID, results[i].RuleID)
+		}
+	}
+
+	// sorting is deterministic regardless of the order the results arrive in
+	reversed := make([]result.Result, len(results))
+	for i, res := range results {
+		reversed[len(results)-1-i] = res
+	}
+	result.SortByLocation(reversed)
+	assert.Equal(t, results, reversed)
+}
+
+func Test_SortBySeverity(t *testing.T) {
+	results := testutil.ScanHCL(sortSource, t)
+	require.Greater(t, len(results), 2)
+
+	result.SortBySeverity(results)
+	for i := 1; i < len(results); i++ {
+		previous, current := results[i-1], results[i]
+		assert.GreaterOrEqual(t, previous.Severity.Rank(), current.Severity.Rank())
+		if previous.Severity == current.Severity {
+			assert.LessOrEqual(t, previous.Range().StartLine, current.Range().StartLine)
+		}
+	}
+	assert.NotEqual(t, severity.Low, results[0].Severity)
+}
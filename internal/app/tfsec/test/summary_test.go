@@ -0,0 +1,82 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_JSONOutputIncludesSummary(t *testing.T) {
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, t)
+	require.NotEmpty(t, results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, results, ""))
+
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+
+	assert.Equal(t, len(results), output.Summary.Failed)
+	assert.Equal(t, len(results), output.Summary.ByProvider["aws"])
+	total := 0
+	for _, count := range output.Summary.BySeverity {
+		total += count
+	}
+	assert.Equal(t, len(results), total)
+}
+
+func Test_SummaryCountsChecksRunAndPassed(t *testing.T) {
+	before := formatters.NewSummary(nil)
+
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+	description = "my rule"
+}
+`, t)
+	require.Len(t, results, 1)
+
+	after := formatters.NewSummary(results)
+	checksRun := after.ChecksRun - before.ChecksRun
+	checksPassed := after.ChecksPassed - before.ChecksPassed
+	assert.Greater(t, checksRun, 1)
+	assert.Equal(t, checksRun-1, checksPassed)
+	assert.Equal(t, 1, after.Failed)
+}
+
+func Test_PrometheusMetricsCountFindingsByLabels(t *testing.T) {
+	results := []result.Result{
+		{RuleID: "aws-s3-enable-versioning", RuleProvider: "aws", RuleService: "s3", Severity: severity.Medium},
+		{RuleID: "aws-s3-enable-bucket-logging", RuleProvider: "aws", RuleService: "s3", Severity: severity.Medium},
+		{RuleID: "aws-s3-block-public-acls", RuleProvider: "aws", RuleService: "s3", Severity: severity.High},
+		{RuleID: "google-compute-no-public-ip", RuleProvider: "google", RuleService: "compute", Severity: severity.High},
+		{RuleID: "aws-s3-enable-bucket-encryption", RuleProvider: "aws", RuleService: "s3", Severity: severity.High, Status: result.Passed},
+		{RuleID: "aws-s3-ignore-public-acls", RuleProvider: "aws", RuleService: "s3", Severity: severity.High, Status: result.Ignored},
+	}
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.WritePrometheusMetrics(&buffer, results))
+	output := buffer.String()
+
+	assert.Contains(t, output, "# TYPE tfsec_findings_total counter\n")
+	assert.Contains(t, output, `tfsec_findings_total{provider="aws",service="s3",severity="high"} 1`+"\n")
+	assert.Contains(t, output, `tfsec_findings_total{provider="aws",service="s3",severity="medium"} 2`+"\n")
+	assert.Contains(t, output, `tfsec_findings_total{provider="google",service="compute",severity="high"} 1`+"\n")
+	assert.Equal(t, 3, strings.Count(output, "tfsec_findings_total{"))
+	assert.Contains(t, output, "# TYPE tfsec_scan_duration_seconds gauge\ntfsec_scan_duration_seconds ")
+	assert.Contains(t, output, "# TYPE tfsec_files_scanned gauge\ntfsec_files_scanned ")
+}
@@ -0,0 +1,26 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SeverityThemes(t *testing.T) {
+	defer func() { _ = formatters.SetSeverityTheme("default", nil) }()
+
+	for _, name := range formatters.ThemeNames() {
+		assert.NoError(t, formatters.SetSeverityTheme(name, nil))
+	}
+	assert.Contains(t, formatters.ThemeNames(), "high-contrast")
+
+	assert.NoError(t, formatters.SetSeverityTheme("high-contrast", map[string]string{
+		"critical": "bold underline lightred",
+		"LOW":      "darkgrey",
+	}))
+	assert.Error(t, formatters.SetSeverityTheme("neon", nil))
+	assert.Error(t, formatters.SetSeverityTheme("default", map[string]string{"URGENT": "red"}))
+	assert.Error(t, formatters.SetSeverityTheme("default", map[string]string{"HIGH": "sparkly"}))
+	assert.Error(t, formatters.SetSeverityTheme("default", map[string]string{"HIGH": "red></red><blink"}))
+}
@@ -0,0 +1,73 @@
+package test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_CancelledScanStopsRunningChecks(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_s3_bucket" "bucket" {
+}
+`, ".tf", t)
+	require.NotEmpty(t, scanner.New().Scan(modules))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Empty(t, scanner.New(scanner.OptionWithContext(ctx)).Scan(modules))
+}
+
+func Test_CancelledParseReturnsContextError(t *testing.T) {
+	dir, err := ioutil.TempDir(os.TempDir(), "tfsec")
+	require.NoError(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "aws_s3_bucket" "bucket" {}`), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	modules, err := parser.New(dir, parser.OptionWithContext(ctx)).ParseDirectory()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, modules)
+}
+
+func Test_CancelledParseReturnsBlocksParsedSoFar(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	modules, err := parser.New(".", parser.OptionWithContext(ctx)).ParseSource([]byte(`
+resource "aws_s3_bucket" "bucket" {
+}
+`), "main.tf")
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, modules, 1)
+	assert.Len(t, modules[0].GetResourcesByType("aws_s3_bucket"), 1)
+
+	// the blocks which were parsed can still be checked
+	assert.NotEmpty(t, scanner.New().Scan(modules))
+}
+
+func Test_ScannerCollectsResultsAsBlocksAreChecked(t *testing.T) {
+	modules := testutil.CreateModulesFromSource(`
+resource "aws_s3_bucket" "bucket" {
+}
+
+resource "aws_security_group_rule" "rule" {
+	type        = "ingress"
+	cidr_blocks = ["0.0.0.0/0"]
+}
+`, ".tf", t)
+
+	s := scanner.New(scanner.OptionDisableDeduplication())
+	assert.Empty(t, s.CollectedResults())
+	results := s.Scan(modules)
+	require.NotEmpty(t, results)
+	assert.ElementsMatch(t, results, s.CollectedResults())
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/coverage"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findRecord(records []coverage.Record, ruleID string) *coverage.Record {
+	for _, record := range records {
+		if record.RuleID == ruleID {
+			return &record
+		}
+	}
+	return nil
+}
+
+func Test_TrackPassedRecordsPassedAndSkippedChecks(t *testing.T) {
+	coverage.Reset()
+	defer coverage.Reset()
+
+	results := testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type        = "ingress"
+	description = "internal access"
+	cidr_blocks = ["10.0.0.0/16"]
+}
+`, t, scanner.OptionTrackPassed())
+	testutil.AssertCheckCode(t, "", "aws-vpc-no-public-ingress-sgr", results)
+
+	var buffer bytes.Buffer
+	require.NoError(t, formatters.FormatJSON(&buffer, results, ""))
+
+	var output formatters.JSONOutput
+	require.NoError(t, json.Unmarshal(buffer.Bytes(), &output))
+
+	passed := findRecord(output.Passed, "aws-vpc-no-public-ingress-sgr")
+	require.NotNil(t, passed)
+	assert.Equal(t, "aws_security_group_rule.my-rule", passed.Resource)
+	assert.Equal(t, coverage.ReasonNoFindings, passed.Reason)
+	assert.Nil(t, findRecord(output.Skipped, "aws-vpc-no-public-ingress-sgr"))
+
+	skipped := findRecord(output.Skipped, "aws-ebs-enable-volume-encryption")
+	require.NotNil(t, skipped)
+	assert.Equal(t, coverage.NotApplicable, skipped.Status)
+	assert.Equal(t, coverage.ReasonRequiredLabelsMismatch, skipped.Reason)
+}
+
+func Test_ChecksAreNotTrackedByDefault(t *testing.T) {
+	coverage.Reset()
+
+	_ = testutil.ScanHCL(`
+resource "aws_security_group_rule" "my-rule" {
+	type        = "ingress"
+	description = "internal access"
+	cidr_blocks = ["10.0.0.0/16"]
+}
+`, t)
+	assert.Empty(t, coverage.Records(coverage.Passed))
+	assert.Empty(t, coverage.Records(coverage.NotApplicable))
+}
@@ -0,0 +1,54 @@
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/parser"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/scanner"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+const workspaceSource = `
+locals {
+	encrypted = terraform.workspace == "prod" ? true : false
+}
+
+resource "aws_ebs_volume" "data" {
+	availability_zone = "us-west-2a"
+	encrypted         = local.encrypted
+	kms_key_id        = "key"
+}
+`
+
+func Test_WorkspaceDefaultsToDefault(t *testing.T) {
+	path := testutil.CreateTestFile("main.tf", workspaceSource)
+	modules, err := parser.New(filepath.Dir(path), parser.OptionStopOnHCLError()).ParseDirectory()
+	require.NoError(t, err)
+
+	results := scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+	testutil.AssertCheckCode(t, "aws-ebs-enable-volume-encryption", "", results)
+}
+
+func Test_WorkspaceIsUsedInEvaluation(t *testing.T) {
+	path := testutil.CreateTestFile("main.tf", workspaceSource)
+	modules, err := parser.New(filepath.Dir(path), parser.OptionStopOnHCLError(), parser.OptionWithWorkspaceName("prod")).ParseDirectory()
+	require.NoError(t, err)
+
+	results := scanner.New(scanner.OptionStopOnErrors(), scanner.OptionWithWorkspaceName("prod")).Scan(modules)
+	testutil.AssertCheckCode(t, "", "aws-ebs-enable-volume-encryption", results)
+}
+
+func Test_WorkspaceIsPassedToModules(t *testing.T) {
+	path := testutil.CreateTestFileWithModule(`
+module "volume" {
+	source = "../module"
+}
+`, workspaceSource)
+	modules, err := parser.New(path, parser.OptionStopOnHCLError(), parser.OptionWithWorkspaceName("prod")).ParseDirectory()
+	require.NoError(t, err)
+
+	results := scanner.New(scanner.OptionStopOnErrors()).Scan(modules)
+	testutil.AssertCheckCode(t, "", "aws-ebs-enable-volume-encryption", results)
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/pkg/result"
+)
+
+// maxAttempts is the number of times a request is made before giving up
+const maxAttempts = 3
+
+// initialBackoff is the wait after the first failed attempt, which doubles after each further failure
+var initialBackoff = time.Second
+
+// Post sends the results to the url as the json formatter would write them. Headers are given as "Name: value".
+// Requests which fail to connect or receive a 5xx or 429 response are retried with backoff, and each attempt is
+// abandoned after the timeout.
+func Post(url string, results []result.Result, headers []string, timeout time.Duration) error {
+	header, err := parseHeaders(headers)
+	if err != nil {
+		return err
+	}
+
+	var body bytes.Buffer
+	if err := formatters.FormatJSON(&body, results, ""); err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+
+	client := &http.Client{Timeout: timeout}
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		retry, err := send(client, url, header, body.Bytes())
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt == maxAttempts {
+			return err
+		}
+		debug.Log("Attempt %d to post results failed, retrying in %s: %s", attempt, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// send makes a single request, returning whether a failure is worth retrying
+func send(client *http.Client, url string, header http.Header, body []byte) (bool, error) {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("invalid url '%s': %w", url, err)
+	}
+	request.Header = header.Clone()
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := client.Do(request)
+	if err != nil {
+		return true, fmt.Errorf("failed to post results to '%s': %w", url, err)
+	}
+	_ = response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return false, nil
+	}
+	retry := response.StatusCode >= 500 || response.StatusCode == http.StatusTooManyRequests
+	return retry, fmt.Errorf("posting results to '%s' returned status %s", url, response.Status)
+}
+
+func parseHeaders(headers []string) (http.Header, error) {
+	header := make(http.Header)
+	for i, h := range headers {
+		parts := strings.SplitN(h, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			// the header isn't printed, as it is likely to hold a credential
+			return nil, fmt.Errorf("invalid header %d, expected 'Name: value'", i+1)
+		}
+		header.Add(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+	return header, nil
+}
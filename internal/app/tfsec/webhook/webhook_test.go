@@ -0,0 +1,104 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/formatters"
+	"github.com/aquasecurity/tfsec/pkg/result"
+	"github.com/aquasecurity/tfsec/pkg/severity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	initialBackoff = time.Millisecond
+}
+
+var testResults = []result.Result{
+	{
+		RuleID:   "aws-vpc-no-public-ingress-sgr",
+		Severity: severity.Critical,
+		Status:   result.Failed,
+	},
+}
+
+func Test_PostSendsResultsWithHeaders(t *testing.T) {
+	var received formatters.JSONOutput
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	require.NoError(t, Post(server.URL, testResults, []string{"Authorization: Bearer token"}, time.Second))
+	assert.Equal(t, "Bearer token", authorization)
+	require.Len(t, received.Results, 1)
+	assert.Equal(t, "aws-vpc-no-public-ingress-sgr", received.Results[0].RuleID)
+}
+
+func Test_PostRetriesServerErrors(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < maxAttempts {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	require.NoError(t, Post(server.URL, testResults, nil, time.Second))
+	assert.Equal(t, maxAttempts, attempts)
+}
+
+func Test_PostGivesUpAfterMaxAttempts(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	assert.Error(t, Post(server.URL, testResults, nil, time.Second))
+	assert.Equal(t, maxAttempts, attempts)
+}
+
+func Test_PostDoesNotRetryClientErrors(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	assert.Error(t, Post(server.URL, testResults, nil, time.Second))
+	assert.Equal(t, 1, attempts)
+}
+
+func Test_PostTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	assert.Error(t, Post(server.URL, testResults, nil, 10*time.Millisecond))
+}
+
+func Test_PostRejectsInvalidHeaders(t *testing.T) {
+	assert.Error(t, Post("http://localhost", testResults, []string{"no-separator"}, time.Second))
+}
+
+func Test_InvalidHeaderIsNotPrinted(t *testing.T) {
+	err := Post("http://localhost", testResults, []string{"X-Team: security", "Bearer secret-token"}, time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid header 2")
+	assert.NotContains(t, err.Error(), "secret-token")
+}
@@ -0,0 +1,29 @@
+package result
+
+import "fmt"
+
+// Deduplicate removes results which have the same rule ID, range, description and range annotation as an earlier
+// result. The first occurrence is kept and records how many times it was raised in Occurrences. Results at different
+// locations, or annotated differently, are never merged.
+func Deduplicate(results []Result) []Result {
+	var deduplicated []Result
+	seen := make(map[string]int)
+	for _, res := range results {
+		key := res.DeduplicationKey()
+		if index, ok := seen[key]; ok {
+			if deduplicated[index].Occurrences == 0 {
+				deduplicated[index].Occurrences = 1
+			}
+			deduplicated[index].Occurrences++
+			continue
+		}
+		seen[key] = len(deduplicated)
+		deduplicated = append(deduplicated, res)
+	}
+	return deduplicated
+}
+
+// DeduplicationKey is the same for results which Deduplicate merges into one
+func (r *Result) DeduplicationKey() string {
+	return fmt.Sprintf("%s|%s|%s|%s", r.RuleID, r.Range().String(), r.Description, r.RangeAnnotation)
+}
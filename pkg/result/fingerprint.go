@@ -0,0 +1,49 @@
+package result
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+)
+
+// Fingerprint identifies a finding independently of where it sits in the file. It hashes the rule ID, the resource
+// and the source code of the result range with whitespace and comment lines removed, so reformatting the code or
+// moving the resource up or down the file doesn't change it.
+func (r *Result) Fingerprint() string {
+	hash := sha256.New()
+	for _, part := range []string{r.RuleID, r.Resource, normalisedSource(r.Location)} {
+		_, _ = hash.Write([]byte(part))
+		_, _ = hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// WithFingerprint records the fingerprint of the result so it is included in formatted output
+func (r *Result) WithFingerprint() *Result {
+	r.FingerprintID = r.Fingerprint()
+	return r
+}
+
+// normalisedSource reads the lines of a range, removing all whitespace and dropping blank and comment lines
+func normalisedSource(rng block.Range) string {
+	if rng.Filename == "" || rng.StartLine <= 0 {
+		return ""
+	}
+	data, err := block.ReadSource(rng.Filename)
+	if err != nil {
+		return ""
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var normalised []string
+	for lineNo := rng.StartLine; lineNo <= rng.EndLine && lineNo <= len(lines); lineNo++ {
+		line := strings.Join(strings.Fields(lines[lineNo-1]), "")
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		normalised = append(normalised, line)
+	}
+	return strings.Join(normalised, "\n")
+}
@@ -2,30 +2,150 @@ package result
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
 )
 
 func (res *Result) IsIgnored(workspace string) bool {
-	for _, annotation := range res.Annotations() {
+	_, ignored := res.IgnoredBy(workspace, false)
+	return ignored
+}
+
+// IgnoredBy finds the annotation which ignores the result. When requireJustification is set, ignores without a
+// justification are not applied.
+func (res *Result) IgnoredBy(workspace string, requireJustification bool) (Annotation, bool) {
+	for _, annotation := range append(res.Annotations(), res.regionAnnotations()...) {
 		// if there is an ignore code
-		if annotation.IgnoreRuleID == "" || (annotation.IgnoreRuleID != res.RuleID && annotation.IgnoreRuleID != res.LegacyRuleID && annotation.IgnoreRuleID != "*") {
+		if annotation.IgnoreRuleID == "" || !ignoreRuleMatches(annotation.IgnoreRuleID, res) {
 			continue
 		}
-		if annotation.Workspace != "" && annotation.Workspace != workspace {
+		if !annotation.appliesToWorkspace(workspace) {
 			continue
 		}
-		if annotation.Expiry != nil && time.Now().After(*annotation.Expiry) {
+		if annotation.Expiry != nil {
+			// the ignore remains active until the end of the expiry date
+			lapses := annotation.Expiry.AddDate(0, 0, 1)
+			if time.Now().After(lapses) {
+				debug.Log("Ignore for '%s' expired on %s", res.RuleID, annotation.Expiry.Format(expiryDateFormat))
+				continue
+			}
+			if time.Until(lapses) <= expiryWarningPeriod {
+				warnOnce(fmt.Sprintf("WARNING: the ignore for %s at %s expires on %s\n", res.RuleID, res.Range(), annotation.Expiry.Format(expiryDateFormat)))
+			}
+		}
+		if requireJustification && annotation.Justification == "" {
+			warnOnce(fmt.Sprintf("WARNING: the ignore for %s at %s has no justification and will not be applied\n", res.RuleID, res.Range()))
 			continue
 		}
 
 		// ignore rule matches!
-		return true
+		return annotation, true
 	}
 	// no ignore rule found for this result
-	return false
+	return Annotation{}, false
+}
+
+// regionAnnotations returns the tfsec:ignore-block-start ... tfsec:ignore-block-end regions which contain the result
+func (res *Result) regionAnnotations() []Annotation {
+	rng := res.Range()
+	if rng.Filename == "" || rng.StartLine <= 0 {
+		return nil
+	}
+	var annotations []Annotation
+	for _, region := range ignoreRegionsInFile(rng.Filename) {
+		if rng.StartLine >= region.startLine && rng.EndLine <= region.endLine {
+			annotations = append(annotations, Annotation{
+				IgnoreRuleID:  region.ruleID,
+				Justification: region.justification,
+				Directive:     fmt.Sprintf("%s%s (line %d)", ignoreRegionStart, region.ruleID, region.startLine),
+			})
+		}
+	}
+	return annotations
+}
+
+func ignoreRuleMatches(pattern string, res *Result) bool {
+	if pattern == "*" || pattern == res.RuleID || (res.LegacyRuleID != "" && pattern == res.LegacyRuleID) {
+		return true
+	}
+	if matched, err := filepath.Match(pattern, res.RuleID); err == nil && matched {
+		return true
+	}
+	if res.LegacyRuleID == "" {
+		return false
+	}
+	matched, err := filepath.Match(pattern, res.LegacyRuleID)
+	return err == nil && matched
+}
+
+const (
+	ignoreRegionStart = "tfsec:ignore-block-start:"
+	ignoreRegionEnd   = "tfsec:ignore-block-end"
+)
+
+type ignoreRegion struct {
+	ruleID        string
+	justification string
+	startLine     int
+	endLine       int
+}
+
+var ignoreRegionCache sync.Map
+
+func ignoreRegionsInFile(filename string) []ignoreRegion {
+	if cached, ok := ignoreRegionCache.Load(filename); ok {
+		return cached.([]ignoreRegion)
+	}
+	var regions []ignoreRegion
+	if data, err := block.ReadSource(filename); err == nil {
+		regions = findIgnoreRegions(filename, strings.Split(string(data), "\n"))
+	}
+	ignoreRegionCache.Store(filename, regions)
+	return regions
+}
+
+func findIgnoreRegions(filename string, lines []string) []ignoreRegion {
+	var regions []ignoreRegion
+	var open []ignoreRegion
+	for i, line := range lines {
+		lineNo := i + 1
+		bits := strings.Fields(line)
+		for j, bit := range bits {
+			bit = strings.TrimPrefix(bit, "#")
+			bit = strings.TrimPrefix(bit, "//")
+			bit = strings.TrimPrefix(bit, "/*")
+			bit = strings.TrimSuffix(bit, "*/")
+			switch {
+			case strings.HasPrefix(bit, ignoreRegionStart):
+				open = append(open, ignoreRegion{
+					ruleID:        strings.TrimPrefix(bit, ignoreRegionStart),
+					justification: findJustification(bits[j+1:]),
+					startLine:     lineNo,
+				})
+			case bit == ignoreRegionEnd:
+				if len(open) == 0 {
+					warnOnce(fmt.Sprintf("WARNING: %s:%d has %s without a matching start\n", filename, lineNo, ignoreRegionEnd))
+					continue
+				}
+				region := open[len(open)-1]
+				open = open[:len(open)-1]
+				region.endLine = lineNo
+				regions = append(regions, region)
+			}
+		}
+	}
+	for _, region := range open {
+		warnOnce(fmt.Sprintf("WARNING: %s:%d starts an ignore region which is never ended, ignoring to the end of the file\n", filename, region.startLine))
+		region.endLine = len(lines)
+		regions = append(regions, region)
+	}
+	return regions
 }
 
 func (res *Result) Annotations() []Annotation {
@@ -74,10 +194,40 @@ func traverseModuleTreeForAnnotations(b block.Block) (annotations []Annotation)
 	return
 }
 
+const expiryDateFormat = "2006-01-02"
+
+// ignores which are close to expiry are reported so they can be revisited before the findings reappear
+const expiryWarningPeriod = 7 * 24 * time.Hour
+
+var warnings sync.Map
+
+// warnOnce writes each warning to stderr a single time, as annotations are read again for every result
+func warnOnce(warning string) {
+	if _, warned := warnings.LoadOrStore(warning, struct{}{}); !warned {
+		_, _ = fmt.Fprint(os.Stderr, warning)
+	}
+}
+
 type Annotation struct {
-	IgnoreRuleID string
-	Expiry       *time.Time
-	Workspace    string
+	IgnoreRuleID  string
+	Expiry        *time.Time
+	Workspace     string
+	Justification string
+	// Directive is the comment the annotation was read from, e.g. tfsec:ignore:aws-s3-enable-versioning
+	Directive string
+}
+
+// appliesToWorkspace checks the workspace against the comma separated list given by ws:<workspace>, if there is one
+func (a Annotation) appliesToWorkspace(workspace string) bool {
+	if a.Workspace == "" {
+		return true
+	}
+	for _, ws := range strings.Split(a.Workspace, ",") {
+		if strings.TrimSpace(ws) == workspace {
+			return true
+		}
+	}
+	return false
 }
 
 func findAnnotations(input string) []Annotation {
@@ -85,7 +235,7 @@ func findAnnotations(input string) []Annotation {
 	var annotations []Annotation
 
 	bits := strings.Split(input, " ")
-	for _, bit := range bits {
+	for i, bit := range bits {
 		bit := strings.TrimSpace(bit)
 		bit = strings.TrimPrefix(bit, "#")
 		bit = strings.TrimPrefix(bit, "//")
@@ -94,8 +244,11 @@ func findAnnotations(input string) []Annotation {
 		if strings.HasPrefix(bit, "tfsec:") {
 			annotation, err := newAnnotation(bit)
 			if err != nil {
+				warnOnce(fmt.Sprintf("WARNING: invalid annotation '%s' will not be applied: %s\n", bit, err))
 				continue
 			}
+			annotation.Justification = findJustification(bits[i+1:])
+			annotation.Directive = bit
 			annotations = append(annotations, annotation)
 		}
 	}
@@ -103,6 +256,19 @@ func findAnnotations(input string) []Annotation {
 	return annotations
 }
 
+// findJustification reads a quoted reason following an annotation, e.g. tfsec:ignore:aws-s3-enable-versioning "logs are transient"
+func findJustification(following []string) string {
+	rest := strings.TrimSpace(strings.Join(following, " "))
+	if !strings.HasPrefix(rest, `"`) {
+		return ""
+	}
+	end := strings.Index(rest[1:], `"`)
+	if end < 0 {
+		return ""
+	}
+	return strings.TrimSpace(rest[1 : end+1])
+}
+
 func newAnnotation(input string) (Annotation, error) {
 	var annotation Annotation
 	if !strings.HasPrefix(input, "tfsec:") {
@@ -120,9 +286,9 @@ func newAnnotation(input string) (Annotation, error) {
 		case "ignore":
 			annotation.IgnoreRuleID = val
 		case "exp":
-			parsed, err := time.Parse("2006-01-02", val)
+			parsed, err := time.Parse(expiryDateFormat, val)
 			if err != nil {
-				return annotation, err
+				return annotation, fmt.Errorf("expiry date '%s' is not a valid YYYY-MM-DD date", val)
 			}
 			annotation.Expiry = &parsed
 		case "ws":
@@ -18,6 +18,7 @@ type Result struct {
 	LegacyRuleID    string            `json:"legacy_rule_id"`
 	RuleSummary     string            `json:"rule_description"`
 	RuleProvider    provider.Provider `json:"rule_provider"`
+	RuleService     string            `json:"rule_service"`
 	Impact          string            `json:"impact"`
 	Resolution      string            `json:"resolution"`
 	Links           []string          `json:"links"`
@@ -25,9 +26,26 @@ type Result struct {
 	RangeAnnotation string            `json:"-"`
 	Severity        severity.Severity `json:"severity"`
 	Status          Status            `json:"status"`
+	Resource        string            `json:"resource"`
 	Location        block.Range       `json:"location"`
+	Code            []CodeLine        `json:"code,omitempty"`
+	Justification   string            `json:"justification,omitempty"`
+	IgnoreDirective string            `json:"ignore_directive,omitempty"`
+	SuggestedFix    string            `json:"suggested_fix,omitempty"`
+	Occurrences     int               `json:"occurrences,omitempty"`
+	FingerprintID   string            `json:"fingerprint,omitempty"`
+	RuleDocs        *RuleDocs         `json:"rule_docs,omitempty"`
 	blocks          block.Blocks
 	attribute       block.Attribute
+	hashCode        string
+}
+
+// RuleDocs is the documentation of the rule which raised a result, embedded so consumers don't need to look it up
+type RuleDocs struct {
+	Summary     string `json:"summary"`
+	Explanation string `json:"explanation"`
+	Impact      string `json:"impact"`
+	Resolution  string `json:"resolution"`
 }
 
 type Status string
@@ -43,6 +61,7 @@ func New(resourceBlock block.Block) *Result {
 		Status: Failed,
 		blocks: []block.Block{resourceBlock},
 	}
+	result.Resource = resourceBlock.FullName()
 	result.Location = result.Range()
 	return result
 }
@@ -55,6 +74,14 @@ func (r *Result) Blocks() block.Blocks {
 	return r.blocks
 }
 
+// ResourceName returns the full name of the block the result was raised against
+func (r *Result) ResourceName() string {
+	if len(r.blocks) == 0 {
+		return r.Resource
+	}
+	return r.blocks[0].FullName()
+}
+
 func (r *Result) IsOnAttribute() bool {
 	return r.attribute != nil
 }
@@ -63,10 +90,16 @@ func (r *Result) Range() block.Range {
 	if r.attribute != nil {
 		return r.attribute.Range()
 	}
+	if len(r.blocks) == 0 {
+		return r.Location
+	}
 	return r.blocks[len(r.blocks)-1].Range()
 }
 
 func (r *Result) HashCode() string {
+	if r.hashCode != "" {
+		return r.hashCode
+	}
 	var hash string
 	for _, block := range r.blocks {
 		hash += "!" + block.UniqueName()
@@ -74,6 +107,10 @@ func (r *Result) HashCode() string {
 	if r.attribute != nil {
 		hash += ":" + r.attribute.Name() + ":" + r.attribute.Range().String()
 	}
+	// results can be told apart by their annotations alone, such as one per statement of a policy
+	if r.RangeAnnotation != "" {
+		hash += ":" + r.RangeAnnotation
+	}
 	return fmt.Sprintf("%s:%s", hash, r.RuleID)
 }
 
@@ -97,6 +134,11 @@ func (r *Result) WithRuleProvider(provider provider.Provider) *Result {
 	return r
 }
 
+func (r *Result) WithRuleService(service string) *Result {
+	r.RuleService = service
+	return r
+}
+
 func (r *Result) WithImpact(impact string) *Result {
 	r.Impact = impact
 	return r
@@ -122,6 +164,7 @@ func (r *Result) WithBlock(block block.Block) *Result {
 		return r
 	}
 	r.blocks = append(r.blocks, block)
+	r.Location = r.Range()
 	return r
 }
 
@@ -135,6 +178,18 @@ func (r *Result) WithDescription(description string, parts ...interface{}) *Resu
 	return r
 }
 
+// WithRangeAnnotation replaces the annotation shown alongside the first line of the result range
+func (r *Result) WithRangeAnnotation(annotation string) *Result {
+	r.RangeAnnotation = annotation
+	return r
+}
+
+// WithSuggestedFix adds advisory HCL which would resolve the result. It is displayed to the user, not applied.
+func (r *Result) WithSuggestedFix(fix string) *Result {
+	r.SuggestedFix = strings.TrimSpace(fix)
+	return r
+}
+
 func (r *Result) WithSeverity(sev severity.Severity) *Result {
 	r.Severity = sev
 	return r
@@ -1,6 +1,8 @@
 package result
 
 import (
+	"sync"
+
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
 	"github.com/aquasecurity/tfsec/pkg/provider"
 )
@@ -11,6 +13,7 @@ type Set interface {
 	WithLegacyRuleID(id string) Set
 	WithRuleSummary(description string) Set
 	WithRuleProvider(provider provider.Provider) Set
+	WithRuleService(service string) Set
 	WithImpact(impact string) Set
 	WithResolution(resolution string) Set
 	WithLinks(links []string) Set
@@ -24,12 +27,14 @@ func NewSet(resourceBlock block.Block) *resultSet {
 }
 
 type resultSet struct {
+	lock          sync.Mutex
 	resourceBlock block.Block
 	results       []*Result
 	ruleID        string
 	legacyID      string
 	ruleSummary   string
 	ruleProvider  provider.Provider
+	ruleService   string
 	impact        string
 	resolution    string
 	links         []string
@@ -43,13 +48,21 @@ func (s *resultSet) AddResult() *Result {
 		WithImpact(s.impact).
 		WithResolution(s.resolution).
 		WithRuleProvider(s.ruleProvider).
+		WithRuleService(s.ruleService).
 		WithLinks(s.links)
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	s.results = append(s.results, result)
 	return result
 }
 
 func (s *resultSet) All() []*Result {
-	return s.results
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.results == nil {
+		return nil
+	}
+	return append([]*Result(nil), s.results...)
 }
 
 func (r *resultSet) WithRuleID(id string) Set {
@@ -72,6 +85,11 @@ func (r *resultSet) WithRuleProvider(provider provider.Provider) Set {
 	return r
 }
 
+func (r *resultSet) WithRuleService(service string) Set {
+	r.ruleService = service
+	return r
+}
+
 func (r *resultSet) WithImpact(impact string) Set {
 	r.impact = impact
 	return r
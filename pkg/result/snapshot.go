@@ -0,0 +1,27 @@
+package result
+
+// Snapshot is a result detached from the blocks it was raised against, so it can be stored and reported by a later
+// run. The fields which are otherwise derived from the blocks are kept alongside it.
+type Snapshot struct {
+	Result          Result `json:"result"`
+	RangeAnnotation string `json:"range_annotation,omitempty"`
+	HashCode        string `json:"hash_code"`
+}
+
+func (r *Result) Snapshot() Snapshot {
+	return Snapshot{
+		Result:          *r,
+		RangeAnnotation: r.RangeAnnotation,
+		HashCode:        r.HashCode(),
+	}
+}
+
+// Restore returns the result the snapshot was taken of. It has no blocks, so it can be reported but not fixed.
+func (s Snapshot) Restore() Result {
+	r := s.Result
+	r.RangeAnnotation = s.RangeAnnotation
+	r.hashCode = s.HashCode
+	r.blocks = nil
+	r.attribute = nil
+	return r
+}
@@ -0,0 +1,52 @@
+package result
+
+import (
+	"strings"
+
+	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+)
+
+// CodeLine is a single line of source code captured around the range of a result
+type CodeLine struct {
+	Number  int    `json:"line_number"`
+	Content string `json:"content"`
+}
+
+// Snippet reads the lines covered by the result range from the source file, along with contextLines lines either side
+func (r *Result) Snippet(contextLines int) []CodeLine {
+	rng := r.Range()
+	if rng.StartLine <= 0 {
+		return nil
+	}
+
+	data, err := block.ReadSource(rng.Filename)
+	if err != nil {
+		return nil
+	}
+
+	lines := append([]string{""}, strings.Split(string(data), "\n")...)
+
+	start := rng.StartLine - contextLines
+	if start <= 0 {
+		start = 1
+	}
+	end := rng.EndLine + contextLines
+	if end >= len(lines) {
+		end = len(lines) - 1
+	}
+
+	var snippet []CodeLine
+	for lineNo := start; lineNo <= end; lineNo++ {
+		snippet = append(snippet, CodeLine{
+			Number:  lineNo,
+			Content: strings.TrimRight(lines[lineNo], "\r"),
+		})
+	}
+	return snippet
+}
+
+// WithCodeSnippet captures the source code around the result so it can be included in formatted output
+func (r *Result) WithCodeSnippet(contextLines int) *Result {
+	r.Code = r.Snippet(contextLines)
+	return r
+}
@@ -0,0 +1,49 @@
+package result
+
+import "sort"
+
+// SortByLocation orders results by file, then start line, then rule ID, so output is stable across runs and machines
+func SortByLocation(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		return compareByLocation(results[i], results[j]) < 0
+	})
+}
+
+// SortBySeverity orders results from most to least severe, using the location order within each severity
+func SortBySeverity(results []Result) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if rankI, rankJ := results[i].Severity.Rank(), results[j].Severity.Rank(); rankI != rankJ {
+			return rankI > rankJ
+		}
+		return compareByLocation(results[i], results[j]) < 0
+	})
+}
+
+func compareByLocation(a, b Result) int {
+	rangeA, rangeB := a.Range(), b.Range()
+	switch {
+	case rangeA.Filename != rangeB.Filename:
+		return compareStrings(rangeA.Filename, rangeB.Filename)
+	case rangeA.StartLine != rangeB.StartLine:
+		return rangeA.StartLine - rangeB.StartLine
+	case a.RuleID != b.RuleID:
+		return compareStrings(a.RuleID, b.RuleID)
+	case rangeA.EndLine != rangeB.EndLine:
+		return rangeA.EndLine - rangeB.EndLine
+	case a.Resource != b.Resource:
+		return compareStrings(a.Resource, b.Resource)
+	default:
+		return compareStrings(a.Description, b.Description)
+	}
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/debug"
-	"github.com/aquasecurity/tfsec/pkg/provider"
 
 	"github.com/aquasecurity/tfsec/pkg/result"
 
@@ -26,12 +25,7 @@ func CheckRule(r *Rule, resourceBlock block.Block, module block.Module, ignoreEr
 		}()
 	}
 
-	var links []string
-	if r.Provider != provider.CustomProvider {
-		links = append(links, fmt.Sprintf("https://tfsec.dev/docs/%s/%s/%s#%s/%s", r.Provider, r.Service, r.ShortCode, r.Provider, r.Service))
-	}
-
-	links = append(links, r.Documentation.Links...)
+	links := r.DocumentationLinks()
 
 	resultSet = result.NewSet(resourceBlock).
 		WithRuleID(r.ID()).
@@ -40,6 +34,7 @@ func CheckRule(r *Rule, resourceBlock block.Block, module block.Module, ignoreEr
 		WithImpact(r.Documentation.Impact).
 		WithResolution(r.Documentation.Resolution).
 		WithRuleProvider(r.Provider).
+		WithRuleService(r.Service).
 		WithLinks(links)
 
 	r.CheckFunc(resultSet, resourceBlock, module)
@@ -75,6 +70,11 @@ func IsRuleRequiredForBlock(rule *Rule, b block.Block) bool {
 	return true
 }
 
+// IsRuleApplicableToBlockType returns true if the rule checks blocks of the same type as b, regardless of their labels
+func IsRuleApplicableToBlockType(rule *Rule, b block.Block) bool {
+	return rule.CheckFunc != nil && (len(rule.RequiredTypes) == 0 || checkRequiredTypesMatch(rule, b))
+}
+
 func checkRequiredTypesMatch(rule *Rule, b block.Block) bool {
 	var found bool
 	for _, requiredType := range rule.RequiredTypes {
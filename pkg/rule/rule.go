@@ -9,6 +9,7 @@ import (
 	"github.com/aquasecurity/tfsec/pkg/severity"
 
 	"github.com/aquasecurity/tfsec/internal/app/tfsec/block"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 )
 
 // Rule is a targeted security test which can be applied to terraform templates. It includes the types to run on e.g.
@@ -26,12 +27,23 @@ type Rule struct {
 	RequiredSources []string
 	DefaultSeverity severity.Severity
 	CheckFunc       func(result.Set, block.Block, block.Module)
+	// FixFunc optionally rewrites a failing block so that it passes the check, returning false if it can't
+	FixFunc func(*hclwrite.Body) bool
 }
 
 func (r Rule) ID() string {
 	return strings.ToLower(fmt.Sprintf("%s-%s-%s", r.Provider, r.Service, r.ShortCode))
 }
 
+// DocumentationLinks returns the link to the check's page on tfsec.dev, followed by any further reading
+func (r Rule) DocumentationLinks() []string {
+	var links []string
+	if r.Provider != provider.CustomProvider {
+		links = append(links, fmt.Sprintf("https://tfsec.dev/docs/%s/%s/%s#%s/%s", r.Provider, r.Service, r.ShortCode, r.Provider, r.Service))
+	}
+	return append(links, r.Documentation.Links...)
+}
+
 func (r Rule) MatchesID(id string) bool {
 	return r.LegacyID == id || r.ID() == id
 }
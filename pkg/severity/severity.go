@@ -12,10 +12,13 @@ const (
 	High     Severity = "HIGH"
 	Medium   Severity = "MEDIUM"
 	Low      Severity = "LOW"
+	Info     Severity = "INFO"
 )
 
+// ValidSeverity lists the severities from most to least severe. Info is for advisory findings, such as style or
+// deprecation warnings, rather than security problems.
 var ValidSeverity = []Severity{
-	Critical, High, Medium, Low,
+	Critical, High, Medium, Low, Info,
 }
 
 func (s *Severity) IsValid() bool {
@@ -31,17 +34,25 @@ func (s *Severity) Valid() []Severity {
 	return ValidSeverity
 }
 
+// Rank allows severities to be compared, where a more severe value has a higher rank
+func (s Severity) Rank() int {
+	for i, severity := range ValidSeverity {
+		if severity == s {
+			return len(ValidSeverity) - i
+		}
+	}
+	return 0
+}
+
 func StringToSeverity(sev string) Severity {
 	s := strings.ToUpper(sev)
 	switch s {
-	case "CRITICAL", "HIGH", "MEDIUM", "LOW":
+	case "CRITICAL", "HIGH", "MEDIUM", "LOW", "INFO":
 		return Severity(s)
 	case "ERROR":
 		return High
 	case "WARNING":
 		return Medium
-	case "INFO":
-		return Low
 	default:
 		return None
 	}